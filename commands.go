@@ -1,19 +1,48 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"github.com/jfrog/frogbot/initconfig"
+	"github.com/jfrog/frogbot/migrateconfig"
 	"github.com/jfrog/frogbot/scanpullrequest"
 	"github.com/jfrog/frogbot/scanrepository"
 	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/frogbot/webhookserver"
 	"github.com/jfrog/froggit-go/vcsclient"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	clitool "github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
 	"os"
 )
 
+const (
+	formatFlag    = "format"
+	outputFlag    = "output"
+	configFlag    = "config"
+	planFlag      = "plan"
+	portFlag      = "port"
+	baseFlag      = "base"
+	headFlag      = "head"
+	outputDirFlag = "output-dir"
+
+	// Init flags
+	gitProviderFlag          = "git-provider"
+	repoNameFlag             = "repo-name"
+	branchFlag               = "branch"
+	ciFlag                   = "ci"
+	minSeverityFlag          = "min-severity"
+	watchesFlag              = "watches"
+	jfrogProjectFlag         = "jfrog-project"
+	failOnSecurityIssuesFlag = "fail-on-security-issues"
+	fixableOnlyFlag          = "fixable-only"
+	nonInteractiveFlag       = "non-interactive"
+	forceFlag                = "force"
+)
+
 type FrogbotCommand interface {
 	// Run the command
 	Run(config utils.RepoAggregator, client vcsclient.VcsClient) error
@@ -26,52 +55,203 @@ func GetCommands() []*clitool.Command {
 			Aliases: []string{"spr"},
 			Usage:   "Scans a pull request with JFrog Xray for security vulnerabilities.",
 			Action: func(ctx *clitool.Context) error {
-				return Exec(&scanpullrequest.ScanPullRequestCmd{}, ctx.Command.Name)
+				return Exec(&scanpullrequest.ScanPullRequestCmd{}, ctx)
 			},
-			Flags: []clitool.Flag{},
+			Flags: utils.GetScanPullRequestFlags(),
 		},
 		{
 			Name:    utils.ScanRepository,
 			Aliases: []string{"cfpr", "create-fix-pull-requests"},
 			Usage:   "Scan the current branch and create pull requests with fixes if needed",
 			Action: func(ctx *clitool.Context) error {
-				return Exec(&scanrepository.ScanRepositoryCmd{}, ctx.Command.Name)
+				return Exec((&scanrepository.ScanRepositoryCmd{}).SetPlanMode(ctx.Bool(planFlag)), ctx)
 			},
-			Flags: []clitool.Flag{},
+			Flags: append(utils.GetScanRepositoryFlags(), getPlanFlag()),
 		},
 		{
 			Name:    utils.ScanAllPullRequests,
 			Aliases: []string{"sprs", "scan-pull-requests"},
 			Usage:   "Scans all the open pull requests within a single or multiple repositories with JFrog Xray for security vulnerabilities",
 			Action: func(ctx *clitool.Context) error {
-				return Exec(&scanpullrequest.ScanAllPullRequestsCmd{}, ctx.Command.Name)
+				return Exec(&scanpullrequest.ScanAllPullRequestsCmd{}, ctx)
 			},
-			Flags: []clitool.Flag{},
+			Flags: utils.GetScanAllPullRequestsFlags(),
 		},
 		{
 			Name:    utils.ScanMultipleRepositories,
 			Aliases: []string{"scan-and-fix-repos", "safr"},
 			Usage:   "Scan single or multiple repositories and create pull requests with fixes if any security vulnerabilities are found",
 			Action: func(ctx *clitool.Context) error {
-				return Exec(&scanrepository.ScanMultipleRepositories{}, ctx.Command.Name)
+				return Exec((&scanrepository.ScanMultipleRepositories{}).SetPlanMode(ctx.Bool(planFlag)), ctx)
 			},
-			Flags: []clitool.Flag{},
+			Flags: append(utils.GetScanRepositoryFlags(), getPlanFlag()),
 		},
+		{
+			Name:  utils.ScanLocal,
+			Usage: "Scans the current working tree with JFrog Xray for security vulnerabilities, without interacting with a Git provider. Prints the same report Frogbot adds to pull requests",
+			Action: func(ctx *clitool.Context) error {
+				return Exec(scanpullrequest.NewScanLocalCmd(ctx.String(formatFlag), ctx.String(outputFlag)), ctx)
+			},
+			Flags: append(utils.GetScanLocalFlags(), getFormatFlag(), getOutputFlag()),
+		},
+		{
+			Name:  utils.ScanDiff,
+			Usage: "Scans two refs of the repository (branches, tags or commits) with JFrog Xray, and reports the security vulnerabilities added in the head ref compared to the base ref",
+			Action: func(ctx *clitool.Context) error {
+				return Exec(scanpullrequest.NewScanDiffCmd(ctx.String(baseFlag), ctx.String(headFlag), ctx.String(formatFlag), ctx.String(outputFlag)), ctx)
+			},
+			Flags: append(utils.GetScanDiffFlags(),
+				&clitool.StringFlag{
+					Name:     baseFlag,
+					Usage:    "The ref to compare to, for example, the previous release tag",
+					Required: true,
+				},
+				&clitool.StringFlag{
+					Name:     headFlag,
+					Usage:    "The ref to report the added security vulnerabilities of, for example, the new release tag",
+					Required: true,
+				},
+				getFormatFlag(),
+				getOutputFlag(),
+			),
+		},
+		{
+			Name:  utils.Report,
+			Usage: "Scans all the configured repositories and branches with JFrog Xray, and writes a consolidated security report in HTML and JSON formats",
+			Action: func(ctx *clitool.Context) error {
+				return Exec(scanpullrequest.NewReportCmd(ctx.String(outputDirFlag)), ctx)
+			},
+			Flags: append(utils.GetReportFlags(),
+				&clitool.StringFlag{
+					Name:  outputDirFlag,
+					Usage: "The directory to write the report files to",
+					Value: utils.RootDir,
+				},
+			),
+		},
+		{
+			Name:  utils.Serve,
+			Usage: "Runs an HTTP server that scans pull requests when receiving pull request webhooks from the Git provider",
+			Action: func(ctx *clitool.Context) error {
+				// The secret has no flag, so that it isn't exposed in the process list. It may be mounted as a file, in the JF_WEBHOOK_SECRET_FILE environment variable
+				secret, err := utils.ReadSecretEnv(utils.WebhookSecretEnv)
+				if err != nil {
+					return err
+				}
+				return Exec(webhookserver.NewServeCmd(ctx.Int(portFlag), secret), ctx)
+			},
+			Flags: append(utils.GetServeFlags(),
+				&clitool.IntFlag{
+					Name:    portFlag,
+					Usage:   "The port to listen for webhooks on",
+					Value:   webhookserver.DefaultPort,
+					EnvVars: []string{utils.WebhookPortEnv},
+				},
+			),
+		},
+		{
+			Name:   utils.Init,
+			Usage:  "Detects the projects in the current directory, and generates the " + utils.FrogbotConfigFile + " file and a CI pipeline that runs Frogbot",
+			Action: execInit,
+			Flags:  getInitFlags(),
+		},
+		{
+			Name:   utils.ValidateConfig,
+			Usage:  "Validates the " + utils.FrogbotConfigFile + " file against the Frogbot schema and prints the effective configuration of each repository",
+			Action: execValidateConfig,
+			Flags: append(utils.GetValidateConfigFlags(),
+				&clitool.StringFlag{
+					Name:  configFlag,
+					Usage: "The path of the " + utils.FrogbotConfigFile + " file",
+					Value: utils.OsFrogbotConfigPath,
+				},
+			),
+		},
+		{
+			Name:   utils.MigrateConfig,
+			Usage:  "Writes the configuration resolved from the " + utils.FrogbotConfigFile + " file and the environment variables as a normalized and commented " + utils.FrogbotConfigFile + " file, and reports deprecated values",
+			Action: execMigrateConfig,
+			Flags: append(utils.GetMigrateConfigFlags(),
+				&clitool.StringFlag{
+					Name:  configFlag,
+					Usage: "The path of the current " + utils.FrogbotConfigFile + " file, if exists",
+					Value: utils.OsFrogbotConfigPath,
+				},
+				&clitool.StringFlag{
+					Name:  outputFlag,
+					Usage: "The path to write the migrated " + utils.FrogbotConfigFile + " file to",
+					Value: utils.OsFrogbotConfigPath,
+				},
+			),
+		},
+	}
+}
+
+func getInitFlags() []clitool.Flag {
+	return []clitool.Flag{
+		&clitool.StringFlag{Name: gitProviderFlag, Usage: fmt.Sprintf("Git provider. Possible values: %s, %s, %s, %s", utils.GitHub, utils.GitLab, utils.BitbucketServer, utils.AzureRepos)},
+		&clitool.StringFlag{Name: repoNameFlag, Usage: "Git repository name"},
+		&clitool.StringFlag{Name: branchFlag, Usage: "The branch to scan and open fix pull requests for"},
+		&clitool.StringFlag{Name: ciFlag, Usage: fmt.Sprintf("The CI pipeline to generate. Possible values: %s, %s, %s, %s", initconfig.GitHubActions, initconfig.GitLabCi, initconfig.AzurePipelines, initconfig.Jenkins)},
+		&clitool.StringFlag{Name: minSeverityFlag, Usage: "Minimum severity of the issues to show. Possible values: Low, Medium, High, Critical"},
+		&clitool.StringSliceFlag{Name: watchesFlag, Usage: "Comma separated list of JFrog Xray watches"},
+		&clitool.StringFlag{Name: jfrogProjectFlag, Usage: "JFrog project key"},
+		&clitool.BoolFlag{Name: failOnSecurityIssuesFlag, Usage: "Whether to fail the scan if security issues are found"},
+		&clitool.BoolFlag{Name: fixableOnlyFlag, Usage: "Whether to show only issues with an available fix version"},
+		&clitool.BoolFlag{Name: nonInteractiveFlag, Usage: "Use the default values of the options that weren't provided, instead of asking for them"},
+		&clitool.BoolFlag{Name: forceFlag, Usage: "Overwrite existing files"},
+	}
+}
+
+func getFormatFlag() clitool.Flag {
+	return &clitool.StringFlag{
+		Name:  formatFlag,
+		Usage: fmt.Sprintf("The format of the report. Possible values: %s, %s", scanpullrequest.MarkdownReportFormat, scanpullrequest.JsonReportFormat),
+		Value: scanpullrequest.MarkdownReportFormat,
+	}
+}
+
+func getOutputFlag() clitool.Flag {
+	return &clitool.StringFlag{
+		Name:  outputFlag,
+		Usage: "The path of a file to write the report to. If not provided, the report is printed to the standard output",
 	}
 }
 
-func Exec(command FrogbotCommand, commandName string) (err error) {
+func getPlanFlag() clitool.Flag {
+	return &clitool.BoolFlag{
+		Name:  planFlag,
+		Usage: "Scan and fix the repository, and print the branches, commit messages, pull request titles and file changes Frogbot would create, and the outdated pull requests it would close, without pushing, opening or closing pull requests",
+	}
+}
+
+func Exec(command FrogbotCommand, ctx *clitool.Context) (err error) {
+	commandName := ctx.Command.Name
+	// Command-line flags take precedence over the environment variables and the frogbot-config.yml file
+	flagsEnvs, err := utils.SetEnvsFromFlags(ctx)
+	if err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+	}
+	// The environment variables are cleared once the Frogbot details are read
+	runSummary := utils.NewRunSummary(commandName, os.Getenv(utils.RunSummaryFileEnv))
+	defer func() {
+		err = errors.Join(err, runSummary.Write(err))
+	}()
+
 	// Get frogbotDetails that contains the config, server, and VCS client
 	log.Info("Frogbot version:", utils.FrogbotVersion)
-	frogbotDetails, err := utils.GetFrogbotDetails(commandName)
+	frogbotDetails, err := utils.GetFrogbotDetails(commandName, flagsEnvs)
 	if err != nil {
-		return err
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+	}
+	for i := range frogbotDetails.Repositories {
+		frogbotDetails.Repositories[i].RunSummary = runSummary
 	}
 
 	// Build the server configuration file
 	originalJfrogHomeDir, tempJFrogHomeDir, err := utils.BuildServerConfigFile(frogbotDetails.ServerDetails)
 	if err != nil {
-		return err
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
 	}
 	defer func() {
 		err = errors.Join(err, os.Setenv(utils.JfrogHomeDirEnv, originalJfrogHomeDir), fileutils.RemoveTempDir(tempJFrogHomeDir))
@@ -103,3 +283,66 @@ func Exec(command FrogbotCommand, commandName string) (err error) {
 	}
 	return err
 }
+
+// Generates the frogbot-config.yml file and the CI pipeline files. Unlike the rest of the commands, no JFrog Platform or Git provider details are needed.
+func execInit(ctx *clitool.Context) error {
+	options := initconfig.Options{
+		GitProvider:    ctx.String(gitProviderFlag),
+		RepoName:       ctx.String(repoNameFlag),
+		Branch:         ctx.String(branchFlag),
+		CiTool:         ctx.String(ciFlag),
+		MinSeverity:    ctx.String(minSeverityFlag),
+		Watches:        ctx.StringSlice(watchesFlag),
+		JFrogProject:   ctx.String(jfrogProjectFlag),
+		NonInteractive: ctx.Bool(nonInteractiveFlag),
+		Force:          ctx.Bool(forceFlag),
+	}
+	// Boolean options that weren't provided are asked interactively
+	if ctx.IsSet(failOnSecurityIssuesFlag) {
+		failOnSecurityIssues := ctx.Bool(failOnSecurityIssuesFlag)
+		options.FailOnSecurityIssues = &failOnSecurityIssues
+	}
+	if ctx.IsSet(fixableOnlyFlag) {
+		fixableOnly := ctx.Bool(fixableOnlyFlag)
+		options.FixableOnly = &fixableOnly
+	}
+	return initconfig.NewInitCmd(utils.RootDir, options).Run()
+}
+
+// Validates the frogbot-config.yml file and prints the effective configuration, after applying the defaults from the environment variables.
+func execValidateConfig(ctx *clitool.Context) (err error) {
+	flagsEnvs, err := utils.SetEnvsFromFlags(ctx)
+	if err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+	}
+	runSummary := utils.NewRunSummary(utils.ValidateConfig, os.Getenv(utils.RunSummaryFileEnv))
+	defer func() {
+		err = errors.Join(err, runSummary.Write(err))
+	}()
+	configAggregator, err := utils.GetEffectiveConfig(ctx.String(configFlag), flagsEnvs)
+	if err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+	}
+	effectiveConfig := bytes.Buffer{}
+	encoder := yaml.NewEncoder(&effectiveConfig)
+	encoder.SetIndent(2)
+	if err = errors.Join(encoder.Encode(configAggregator), encoder.Close()); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The %s file is valid. The effective configuration of each repository:", utils.FrogbotConfigFile))
+	log.Output(effectiveConfig.String())
+	return nil
+}
+
+// Migrates the configuration from the environment variables and the frogbot-config.yml file to a normalized frogbot-config.yml file.
+func execMigrateConfig(ctx *clitool.Context) (err error) {
+	flagsEnvs, err := utils.SetEnvsFromFlags(ctx)
+	if err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+	}
+	runSummary := utils.NewRunSummary(utils.MigrateConfig, os.Getenv(utils.RunSummaryFileEnv))
+	defer func() {
+		err = errors.Join(err, runSummary.Write(err))
+	}()
+	return migrateconfig.NewMigrateConfigCmd(utils.RootDir, ctx.String(configFlag), ctx.String(outputFlag), flagsEnvs).Run()
+}
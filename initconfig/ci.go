@@ -0,0 +1,99 @@
+package initconfig
+
+import (
+	"bytes"
+	"embed"
+	"path"
+	"text/template"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+const (
+	gitLabCiFile = ".frogbot/frogbot.gitlab-ci.yml"
+	// The templates include the syntax of the CI tools, such as ${{ secrets.JF_URL }}, so different delimiters are used
+	leftDelimiter  = "[["
+	rightDelimiter = "]]"
+	setupTemplate  = "templates/github-actions/setup.tmpl"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// The CI pipeline files of each CI tool, mapped from the template path to the path of the created file in the repository
+var ciFiles = map[string]map[string]string{
+	GitHubActions: {
+		"templates/github-actions/frogbot-scan-pull-request.yml": ".github/workflows/frogbot-scan-pull-request.yml",
+		"templates/github-actions/frogbot-scan-repository.yml":   ".github/workflows/frogbot-scan-repository.yml",
+	},
+	GitLabCi: {
+		"templates/gitlab/frogbot.gitlab-ci.yml": gitLabCiFile,
+	},
+	AzurePipelines: {
+		"templates/azure-pipelines/frogbot-scan-pull-request.yml": ".azure-pipelines/frogbot-scan-pull-request.yml",
+		"templates/azure-pipelines/frogbot-scan-repository.yml":   ".azure-pipelines/frogbot-scan-repository.yml",
+	},
+	Jenkins: {
+		"templates/jenkins/scan-pull-request.jenkinsfile": ".frogbot/scan-pull-request.jenkinsfile",
+		"templates/jenkins/scan-repository.jenkinsfile":   ".frogbot/scan-repository.jenkinsfile",
+	},
+}
+
+// A GitHub Actions step that installs the package manager of the detected technologies
+type setupStep struct {
+	Action string
+	With   []setupInput
+}
+
+type setupInput struct {
+	Key   string
+	Value string
+}
+
+var setupSteps = []struct {
+	technologies []coreutils.Technology
+	step         setupStep
+}{
+	{[]coreutils.Technology{coreutils.Maven, coreutils.Gradle}, setupStep{"actions/setup-java@v3", []setupInput{{"distribution", "temurin"}, {"java-version", "17"}}}},
+	{[]coreutils.Technology{coreutils.Npm, coreutils.Yarn}, setupStep{"actions/setup-node@v3", []setupInput{{"node-version", "18"}}}},
+	{[]coreutils.Technology{coreutils.Go}, setupStep{"actions/setup-go@v4", []setupInput{{"go-version", "stable"}}}},
+	{[]coreutils.Technology{coreutils.Pip, coreutils.Pipenv, coreutils.Poetry}, setupStep{"actions/setup-python@v4", []setupInput{{"python-version", "3.x"}}}},
+	{[]coreutils.Technology{coreutils.Dotnet, coreutils.Nuget}, setupStep{"actions/setup-dotnet@v3", []setupInput{{"dotnet-version", "6.x"}}}},
+}
+
+type ciTemplateData struct {
+	GitProvider string
+	RepoName    string
+	Branch      string
+	SetupSteps  []setupStep
+}
+
+// Creates the CI pipeline files of the selected CI tool. Returns the content of each file, mapped from its path in the repository.
+func createCiFiles(options *Options, technologies map[coreutils.Technology]bool) (files map[string][]byte, err error) {
+	data := ciTemplateData{GitProvider: options.GitProvider, RepoName: options.RepoName, Branch: options.Branch, SetupSteps: getSetupSteps(technologies)}
+	files = map[string][]byte{}
+	for templatePath, filePath := range ciFiles[options.CiTool] {
+		var ciTemplate *template.Template
+		if ciTemplate, err = template.New(path.Base(templatePath)).Delims(leftDelimiter, rightDelimiter).ParseFS(templatesFS, templatePath, setupTemplate); err != nil {
+			return
+		}
+		content := bytes.Buffer{}
+		if err = ciTemplate.Execute(&content, data); err != nil {
+			return
+		}
+		files[filePath] = content.Bytes()
+	}
+	return
+}
+
+func getSetupSteps(technologies map[coreutils.Technology]bool) (steps []setupStep) {
+	for _, setup := range setupSteps {
+		for _, technology := range setup.technologies {
+			if technologies[technology] {
+				steps = append(steps, setup.step)
+				break
+			}
+		}
+	}
+	return
+}
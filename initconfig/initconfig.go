@@ -0,0 +1,458 @@
+package initconfig
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	GitHubActions  = "github-actions"
+	GitLabCi       = "gitlab-ci"
+	AzurePipelines = "azure-pipelines"
+	Jenkins        = "jenkins"
+
+	defaultBranch = "master"
+	configHeader  = "# Generated by 'frogbot init'. The full list of parameters is documented in:\n" +
+		"# https://github.com/jfrog/frogbot/blob/master/docs/templates/.frogbot/frogbot-config.yml\n"
+)
+
+// Directories that are not searched for projects to scan
+var skippedDirs = map[string]bool{"node_modules": true, "vendor": true, "target": true, "build": true, "bin": true, "obj": true, "venv": true, "testdata": true}
+
+// Options of the init command. Missing options are asked interactively, or set to their default values.
+type Options struct {
+	GitProvider          string
+	RepoName             string
+	Branch               string
+	CiTool               string
+	MinSeverity          string
+	Watches              []string
+	JFrogProject         string
+	FailOnSecurityIssues *bool
+	FixableOnly          *bool
+	// Use the default values of the missing options, instead of asking for them
+	NonInteractive bool
+	// Overwrite existing files
+	Force bool
+}
+
+// InitCmd generates the frogbot-config.yml file and a CI pipeline that runs Frogbot, according to the projects found in the checkout.
+type InitCmd struct {
+	// The root directory of the checkout
+	rootDir string
+	options Options
+	// The answers to the interactive questions are read from the input
+	input  *bufio.Reader
+	output io.Writer
+}
+
+// A directory with projects of technologies that weren't found in its parent directories
+type detectedWorkingDir struct {
+	path         string
+	technologies []coreutils.Technology
+}
+
+func NewInitCmd(rootDir string, options Options) *InitCmd {
+	if rootDir == "" {
+		rootDir = utils.RootDir
+	}
+	// Questions can't be answered if the input isn't a terminal, for example in a CI job
+	if stat, err := os.Stdin.Stat(); err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		options.NonInteractive = true
+	}
+	return &InitCmd{rootDir: rootDir, options: options, input: bufio.NewReader(os.Stdin), output: os.Stdout}
+}
+
+func (cmd *InitCmd) Run() (err error) {
+	workingDirs, err := detectWorkingDirs(cmd.rootDir)
+	if err != nil {
+		return
+	}
+	if err = cmd.completeOptions(); err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+	}
+	configContent, err := createConfig(cmd.rootDir, workingDirs, &cmd.options)
+	if err != nil {
+		return
+	}
+	files := map[string][]byte{utils.OsFrogbotConfigPath: configContent}
+	ciFiles, err := createCiFiles(&cmd.options, getTechnologies(workingDirs))
+	if err != nil {
+		return
+	}
+	for filePath, content := range ciFiles {
+		files[filePath] = content
+	}
+	if err = cmd.writeFiles(files); err != nil {
+		return
+	}
+	logNextSteps(&cmd.options)
+	return
+}
+
+// Searches the checkout for projects, the same way the audit detects the technologies of a working directory.
+// Subdirectories of a project are searched as well, but only technologies that weren't detected in their parent directories are added,
+// since multi-module projects, such as Maven and Gradle projects, are scanned from their root directory.
+func detectWorkingDirs(rootDir string) (workingDirs []detectedWorkingDir, err error) {
+	err = detectWorkingDirsRecursively(rootDir, utils.RootDir, map[coreutils.Technology]bool{}, &workingDirs)
+	if err == nil && len(workingDirs) == 0 {
+		log.Warn("No supported technologies were detected. The root directory of the repository is set as the working directory")
+		workingDirs = append(workingDirs, detectedWorkingDir{path: utils.RootDir})
+	}
+	return
+}
+
+func detectWorkingDirsRecursively(rootDir, relativeDir string, parentTechnologies map[coreutils.Technology]bool, workingDirs *[]detectedWorkingDir) error {
+	dir := filepath.Join(rootDir, relativeDir)
+	detected, err := coreutils.DetectTechnologies(dir, false, false)
+	if err != nil {
+		return err
+	}
+	var newTechnologies []coreutils.Technology
+	for technology := range detected {
+		if !parentTechnologies[technology] {
+			newTechnologies = append(newTechnologies, technology)
+		}
+	}
+	if len(newTechnologies) > 0 {
+		sort.Slice(newTechnologies, func(i, j int) bool { return newTechnologies[i] < newTechnologies[j] })
+		log.Info(fmt.Sprintf("Detected %s in %s", techsToString(newTechnologies), relativeDir))
+		*workingDirs = append(*workingDirs, detectedWorkingDir{path: filepath.ToSlash(relativeDir), technologies: newTechnologies})
+		technologies := map[coreutils.Technology]bool{}
+		for technology := range parentTechnologies {
+			technologies[technology] = true
+		}
+		for _, technology := range newTechnologies {
+			technologies[technology] = true
+		}
+		parentTechnologies = technologies
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || skippedDirs[entry.Name()] {
+			continue
+		}
+		if err = detectWorkingDirsRecursively(rootDir, path.Join(relativeDir, entry.Name()), parentTechnologies, workingDirs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fills the missing options with the answers to the interactive questions, or with their default values.
+// The default values are derived from the Git repository of the checkout, if exists.
+func (cmd *InitCmd) completeOptions() (err error) {
+	options := &cmd.options
+	remoteUrl, currentBranch := getGitDetails(cmd.rootDir)
+	if options.GitProvider == "" {
+		options.GitProvider = cmd.ask(fmt.Sprintf("Git provider (%s, %s, %s, %s)", utils.GitHub, utils.GitLab, utils.BitbucketServer, utils.AzureRepos), guessGitProvider(remoteUrl))
+	}
+	if err = validateGitProvider(options.GitProvider); err != nil {
+		return
+	}
+	if options.RepoName == "" {
+		options.RepoName = cmd.ask("Repository name", guessRepoName(cmd.rootDir, remoteUrl))
+	}
+	if options.Branch == "" {
+		if currentBranch == "" {
+			currentBranch = defaultBranch
+		}
+		options.Branch = cmd.ask("Branch to scan and open fix pull requests for", currentBranch)
+	}
+	if options.CiTool == "" {
+		options.CiTool = cmd.ask(fmt.Sprintf("CI pipeline (%s, %s, %s, %s)", GitHubActions, GitLabCi, AzurePipelines, Jenkins), getDefaultCiTool(options.GitProvider))
+	}
+	if err = validateCiTool(options.CiTool, options.GitProvider); err != nil {
+		return
+	}
+	if options.FailOnSecurityIssues == nil {
+		failOnSecurityIssues := cmd.askBool("Fail the scan if security issues are found", true)
+		options.FailOnSecurityIssues = &failOnSecurityIssues
+	}
+	if options.MinSeverity == "" {
+		options.MinSeverity = cmd.ask("Minimum severity of the issues to show (Low, Medium, High, Critical). Leave empty to show all severities", "")
+	}
+	if options.MinSeverity, err = xrutils.GetSeveritiesFormat(options.MinSeverity); err != nil {
+		return
+	}
+	if options.FixableOnly == nil {
+		fixableOnly := cmd.askBool("Show only issues with an available fix version", false)
+		options.FixableOnly = &fixableOnly
+	}
+	return
+}
+
+func (cmd *InitCmd) ask(question, defaultValue string) string {
+	if cmd.options.NonInteractive {
+		return defaultValue
+	}
+	if defaultValue != "" {
+		question += fmt.Sprintf(" [%s]", defaultValue)
+	}
+	fmt.Fprint(cmd.output, question+": ")
+	answer, err := cmd.input.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer == "" || (err != nil && !errors.Is(err, io.EOF)) {
+		return defaultValue
+	}
+	return answer
+}
+
+func (cmd *InitCmd) askBool(question string, defaultValue bool) bool {
+	defaultAnswer := "n"
+	if defaultValue {
+		defaultAnswer = "y"
+	}
+	switch strings.ToLower(cmd.ask(question+" (y/n)", defaultAnswer)) {
+	case "y", "yes", "true":
+		return true
+	case "n", "no", "false":
+		return false
+	default:
+		return defaultValue
+	}
+}
+
+// Returns the URL of the origin remote and the current branch of the Git repository of the checkout.
+// If the checkout isn't a Git repository, empty values are returned.
+func getGitDetails(rootDir string) (remoteUrl, currentBranch string) {
+	repository, err := git.PlainOpenWithOptions(rootDir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		log.Debug("Couldn't open the Git repository of the checkout:", err.Error())
+		return
+	}
+	if remote, e := repository.Remote(git.DefaultRemoteName); e == nil && len(remote.Config().URLs) > 0 {
+		remoteUrl = remote.Config().URLs[0]
+	}
+	// The symbolic HEAD reference is read, since it exists even before the first commit
+	if head, e := repository.Storer.Reference(plumbing.HEAD); e == nil && head.Type() == plumbing.SymbolicReference && head.Target().IsBranch() {
+		currentBranch = head.Target().Short()
+	}
+	return
+}
+
+func guessGitProvider(remoteUrl string) string {
+	switch {
+	case strings.Contains(remoteUrl, "gitlab"):
+		return string(utils.GitLab)
+	case strings.Contains(remoteUrl, "dev.azure.com"), strings.Contains(remoteUrl, "visualstudio.com"):
+		return string(utils.AzureRepos)
+	case strings.Contains(remoteUrl, "/scm/"), strings.Contains(remoteUrl, "bitbucket"):
+		return string(utils.BitbucketServer)
+	default:
+		return string(utils.GitHub)
+	}
+}
+
+func guessRepoName(rootDir, remoteUrl string) string {
+	if remoteUrl != "" {
+		return strings.TrimSuffix(path.Base(strings.TrimSuffix(remoteUrl, "/")), ".git")
+	}
+	if absRootDir, err := filepath.Abs(rootDir); err == nil {
+		return filepath.Base(absRootDir)
+	}
+	return ""
+}
+
+func validateGitProvider(gitProvider string) error {
+	switch gitProvider {
+	case string(utils.GitHub), string(utils.GitLab), string(utils.BitbucketServer), string(utils.AzureRepos):
+		return nil
+	}
+	return fmt.Errorf("the Git provider should be one of: '%s', '%s', '%s' or '%s'. received: '%s'", utils.GitHub, utils.GitLab, utils.BitbucketServer, utils.AzureRepos, gitProvider)
+}
+
+func getDefaultCiTool(gitProvider string) string {
+	switch gitProvider {
+	case string(utils.GitHub):
+		return GitHubActions
+	case string(utils.GitLab):
+		return GitLabCi
+	case string(utils.AzureRepos):
+		return AzurePipelines
+	default:
+		return Jenkins
+	}
+}
+
+// Jenkins can run Frogbot for all the Git providers, while the rest of the CI tools are specific to their Git provider.
+func validateCiTool(ciTool, gitProvider string) error {
+	switch ciTool {
+	case Jenkins:
+		return nil
+	case GitHubActions, GitLabCi, AzurePipelines:
+		if getDefaultCiTool(gitProvider) != ciTool {
+			return fmt.Errorf("the %s CI pipeline can't be used with the %s Git provider", ciTool, gitProvider)
+		}
+		return nil
+	}
+	return fmt.Errorf("the CI pipeline should be one of: '%s', '%s', '%s' or '%s'. received: '%s'", GitHubActions, GitLabCi, AzurePipelines, Jenkins, ciTool)
+}
+
+// Creates the content of the frogbot-config.yml file, and validates it against the Frogbot schema.
+func createConfig(rootDir string, workingDirs []detectedWorkingDir, options *Options) (configContent []byte, err error) {
+	repository := utils.Repository{}
+	repository.RepoName = options.RepoName
+	repository.Branches = []string{options.Branch}
+	repository.FailOnSecurityIssues = options.FailOnSecurityIssues
+	repository.FixableOnly = *options.FixableOnly
+	repository.MinSeverity = options.MinSeverity
+	repository.Watches = options.Watches
+	repository.JFrogProjectKey = options.JFrogProject
+	repository.Projects = createProjects(rootDir, workingDirs)
+
+	content := bytes.Buffer{}
+	content.WriteString(configHeader)
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err = errors.Join(encoder.Encode(utils.RepoAggregator{repository}), encoder.Close()); err != nil {
+		return
+	}
+	if err = utils.ValidateConfigSchema(content.Bytes()); err != nil {
+		return
+	}
+	return content.Bytes(), nil
+}
+
+// Working directories that are installed the same way are grouped into a single project.
+func createProjects(rootDir string, workingDirs []detectedWorkingDir) (projects []utils.Project) {
+	for _, workingDir := range workingDirs {
+		project := utils.Project{
+			InstallCommand:      getInstallCommand(rootDir, workingDir),
+			PipRequirementsFile: getPipRequirementsFile(rootDir, workingDir),
+		}
+		i := 0
+		for ; i < len(projects); i++ {
+			if projects[i].InstallCommand == project.InstallCommand && projects[i].PipRequirementsFile == project.PipRequirementsFile {
+				break
+			}
+		}
+		if i == len(projects) {
+			projects = append(projects, project)
+		}
+		projects[i].WorkingDirs = append(projects[i].WorkingDirs, workingDir.path)
+	}
+	return
+}
+
+// Yarn 2, NuGet and .NET projects require an install command.
+func getInstallCommand(rootDir string, workingDir detectedWorkingDir) string {
+	var installCommand string
+	for _, technology := range workingDir.technologies {
+		switch technology {
+		case coreutils.Dotnet:
+			return "dotnet restore"
+		case coreutils.Nuget:
+			installCommand = "nuget restore"
+		case coreutils.Yarn:
+			if fileExists(filepath.Join(rootDir, workingDir.path, ".yarnrc.yml")) {
+				installCommand = "yarn install"
+			}
+		}
+	}
+	return installCommand
+}
+
+// Pip projects without a setup.py file are installed with their requirements file.
+func getPipRequirementsFile(rootDir string, workingDir detectedWorkingDir) string {
+	for _, technology := range workingDir.technologies {
+		if technology != coreutils.Pip {
+			continue
+		}
+		if !fileExists(filepath.Join(rootDir, workingDir.path, "setup.py")) && fileExists(filepath.Join(rootDir, workingDir.path, "requirements.txt")) {
+			return "requirements.txt"
+		}
+	}
+	return ""
+}
+
+func fileExists(filePath string) bool {
+	_, err := os.Stat(filePath)
+	return err == nil
+}
+
+func getTechnologies(workingDirs []detectedWorkingDir) map[coreutils.Technology]bool {
+	technologies := map[coreutils.Technology]bool{}
+	for _, workingDir := range workingDirs {
+		for _, technology := range workingDir.technologies {
+			technologies[technology] = true
+		}
+	}
+	return technologies
+}
+
+func techsToString(technologies []coreutils.Technology) string {
+	var formalNames []string
+	for _, technology := range technologies {
+		formalNames = append(formalNames, technology.ToFormal())
+	}
+	return strings.Join(formalNames, ", ")
+}
+
+// Writes the files, relative to the root directory. Existing files are overwritten only if forced.
+func (cmd *InitCmd) writeFiles(files map[string][]byte) error {
+	filePaths := make([]string, 0, len(files))
+	for filePath := range files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+	if !cmd.options.Force {
+		var existingFiles []string
+		for _, filePath := range filePaths {
+			if fileExists(filepath.Join(cmd.rootDir, filePath)) {
+				existingFiles = append(existingFiles, filePath)
+			}
+		}
+		if len(existingFiles) > 0 {
+			return utils.WithExitCode(fmt.Errorf("the following files already exist: %s. use the --force flag to overwrite them", strings.Join(existingFiles, ", ")), utils.ExitCodeConfigurationError)
+		}
+	}
+	for _, filePath := range filePaths {
+		fullPath := filepath.Join(cmd.rootDir, filePath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, files[filePath], 0600); err != nil {
+			return err
+		}
+		log.Info("Created", filePath)
+	}
+	return nil
+}
+
+func logNextSteps(options *Options) {
+	nextSteps := []string{
+		"Next steps:",
+		"1. Commit the created files.",
+		"2. Store the JFrog Platform URL and access token as the JF_URL and JF_ACCESS_TOKEN secrets of the CI pipeline.",
+	}
+	switch options.CiTool {
+	case GitHubActions:
+		nextSteps = append(nextSteps, "3. Create a GitHub environment named 'frogbot', with the maintainers of the repository as its reviewers. Pull requests are scanned once approved by a reviewer.")
+	case GitLabCi:
+		nextSteps = append(nextSteps, "3. Include the "+gitLabCiFile+" file in the .gitlab-ci.yml file, and store a GitLab access token as the USER_TOKEN variable.")
+	case AzurePipelines:
+		nextSteps = append(nextSteps, "3. Create pipelines from the created files, and store an Azure Repos access token as the FROGBOT_GIT_TOKEN variable.")
+	case Jenkins:
+		nextSteps = append(nextSteps, "3. Create Jenkins pipelines from the created files, and store a Git access token as the JF_GIT_TOKEN credentials.")
+	}
+	log.Info(strings.Join(nextSteps, "\n"))
+}
@@ -0,0 +1,209 @@
+package initconfig
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func createTestCheckout(t *testing.T, files ...string) string {
+	rootDir := t.TempDir()
+	for _, file := range files {
+		filePath := filepath.Join(rootDir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		require.NoError(t, os.WriteFile(filePath, []byte{}, 0600))
+	}
+	return rootDir
+}
+
+func TestDetectWorkingDirs(t *testing.T) {
+	rootDir := createTestCheckout(t,
+		"pom.xml",
+		"module/pom.xml",
+		"frontend/package.json",
+		"frontend/node_modules/dep/package.json",
+		"backend/go.mod",
+		"backend/scripts/requirements.txt",
+		".github/package.json",
+		"testdata/projects/npm/package.json",
+	)
+	workingDirs, err := detectWorkingDirs(rootDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []detectedWorkingDir{
+		// The Maven module is scanned with its parent project
+		{path: ".", technologies: []coreutils.Technology{coreutils.Maven}},
+		{path: "backend", technologies: []coreutils.Technology{coreutils.Go}},
+		{path: "backend/scripts", technologies: []coreutils.Technology{coreutils.Pip}},
+		{path: "frontend", technologies: []coreutils.Technology{coreutils.Npm}},
+	}, workingDirs)
+
+	// No projects
+	workingDirs, err = detectWorkingDirs(createTestCheckout(t, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, []detectedWorkingDir{{path: "."}}, workingDirs)
+}
+
+func TestCreateConfig(t *testing.T) {
+	rootDir := createTestCheckout(t, "yarn-berry/.yarnrc.yml", "api/requirements.txt")
+	workingDirs := []detectedWorkingDir{
+		{path: "web", technologies: []coreutils.Technology{coreutils.Npm}},
+		{path: "yarn-berry", technologies: []coreutils.Technology{coreutils.Yarn}},
+		{path: "api", technologies: []coreutils.Technology{coreutils.Pip}},
+		{path: "service", technologies: []coreutils.Technology{coreutils.Dotnet, coreutils.Nuget}},
+		{path: "cli", technologies: []coreutils.Technology{coreutils.Go}},
+	}
+	failOnSecurityIssues := false
+	fixableOnly := true
+	options := &Options{
+		RepoName:             "frogbot",
+		Branch:               "main",
+		MinSeverity:          "High",
+		Watches:              []string{"watch-1", "watch-2"},
+		FailOnSecurityIssues: &failOnSecurityIssues,
+		FixableOnly:          &fixableOnly,
+	}
+	configContent, err := createConfig(rootDir, workingDirs, options)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(configContent), configHeader))
+
+	var config utils.RepoAggregator
+	require.NoError(t, yaml.Unmarshal(configContent, &config))
+	require.Len(t, config, 1)
+	assert.Equal(t, "frogbot", config[0].RepoName)
+	assert.Equal(t, []string{"main"}, config[0].Branches)
+	assert.Equal(t, "High", config[0].MinSeverity)
+	assert.Equal(t, []string{"watch-1", "watch-2"}, config[0].Watches)
+	assert.False(t, *config[0].FailOnSecurityIssues)
+	assert.True(t, config[0].FixableOnly)
+	// Working directories that are installed the same way are grouped into a single project
+	assert.Equal(t, []utils.Project{
+		{WorkingDirs: []string{"web", "cli"}},
+		{WorkingDirs: []string{"yarn-berry"}, InstallCommand: "yarn install"},
+		{WorkingDirs: []string{"api"}, PipRequirementsFile: "requirements.txt"},
+		{WorkingDirs: []string{"service"}, InstallCommand: "dotnet restore"},
+	}, config[0].Projects)
+}
+
+func TestCompleteOptions(t *testing.T) {
+	rootDir := createTestCheckout(t)
+	// Empty answers accept the default values
+	cmd := &InitCmd{
+		rootDir: rootDir,
+		input:   bufio.NewReader(strings.NewReader("gitlab\n\ndev\n\nn\nmedium\n\n")),
+		output:  &bytes.Buffer{},
+	}
+	require.NoError(t, cmd.completeOptions())
+	assert.Equal(t, string(utils.GitLab), cmd.options.GitProvider)
+	assert.Equal(t, filepath.Base(rootDir), cmd.options.RepoName)
+	assert.Equal(t, "dev", cmd.options.Branch)
+	assert.Equal(t, GitLabCi, cmd.options.CiTool)
+	assert.False(t, *cmd.options.FailOnSecurityIssues)
+	assert.Equal(t, "Medium", cmd.options.MinSeverity)
+	assert.False(t, *cmd.options.FixableOnly)
+
+	// Options provided by flags aren't asked
+	fixableOnly := true
+	cmd = &InitCmd{rootDir: rootDir, options: Options{GitProvider: string(utils.AzureRepos), FixableOnly: &fixableOnly, NonInteractive: true}}
+	require.NoError(t, cmd.completeOptions())
+	assert.Equal(t, AzurePipelines, cmd.options.CiTool)
+	assert.Equal(t, defaultBranch, cmd.options.Branch)
+	assert.True(t, *cmd.options.FailOnSecurityIssues)
+	assert.True(t, *cmd.options.FixableOnly)
+
+	cmd = &InitCmd{rootDir: rootDir, options: Options{GitProvider: "bitbucket", NonInteractive: true}}
+	assert.ErrorContains(t, cmd.completeOptions(), "the Git provider should be one of")
+}
+
+func TestValidateCiTool(t *testing.T) {
+	testCases := []struct {
+		ciTool      string
+		gitProvider string
+		valid       bool
+	}{
+		{ciTool: GitHubActions, gitProvider: string(utils.GitHub), valid: true},
+		{ciTool: GitHubActions, gitProvider: string(utils.GitLab), valid: false},
+		{ciTool: GitLabCi, gitProvider: string(utils.GitLab), valid: true},
+		{ciTool: AzurePipelines, gitProvider: string(utils.AzureRepos), valid: true},
+		{ciTool: AzurePipelines, gitProvider: string(utils.BitbucketServer), valid: false},
+		{ciTool: Jenkins, gitProvider: string(utils.BitbucketServer), valid: true},
+		{ciTool: Jenkins, gitProvider: string(utils.GitHub), valid: true},
+		{ciTool: "circleci", gitProvider: string(utils.GitHub), valid: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.ciTool+"-"+test.gitProvider, func(t *testing.T) {
+			err := validateCiTool(test.ciTool, test.gitProvider)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateCiFiles(t *testing.T) {
+	testCases := []struct {
+		ciTool        string
+		gitProvider   string
+		expectedFiles []string
+	}{
+		{ciTool: GitHubActions, gitProvider: string(utils.GitHub), expectedFiles: []string{".github/workflows/frogbot-scan-pull-request.yml", ".github/workflows/frogbot-scan-repository.yml"}},
+		{ciTool: GitLabCi, gitProvider: string(utils.GitLab), expectedFiles: []string{gitLabCiFile}},
+		{ciTool: AzurePipelines, gitProvider: string(utils.AzureRepos), expectedFiles: []string{".azure-pipelines/frogbot-scan-pull-request.yml", ".azure-pipelines/frogbot-scan-repository.yml"}},
+		{ciTool: Jenkins, gitProvider: string(utils.BitbucketServer), expectedFiles: []string{".frogbot/scan-pull-request.jenkinsfile", ".frogbot/scan-repository.jenkinsfile"}},
+	}
+	technologies := map[coreutils.Technology]bool{coreutils.Gradle: true, coreutils.Npm: true, coreutils.Yarn: true}
+	for _, test := range testCases {
+		t.Run(test.ciTool, func(t *testing.T) {
+			options := &Options{GitProvider: test.gitProvider, RepoName: "frogbot", Branch: "release", CiTool: test.ciTool}
+			files, err := createCiFiles(options, technologies)
+			require.NoError(t, err)
+			var filePaths []string
+			for filePath, content := range files {
+				filePaths = append(filePaths, filePath)
+				assert.Contains(t, string(content), "JF_ACCESS_TOKEN")
+				if filepath.Ext(filePath) == ".yml" {
+					var pipeline map[string]interface{}
+					assert.NoError(t, yaml.Unmarshal(content, &pipeline), filePath)
+				}
+			}
+			assert.ElementsMatch(t, test.expectedFiles, filePaths)
+		})
+	}
+
+	// The package managers of the detected technologies are installed in GitHub Actions
+	files, err := createCiFiles(&Options{GitProvider: string(utils.GitHub), Branch: "release", CiTool: GitHubActions}, technologies)
+	require.NoError(t, err)
+	workflow := string(files[".github/workflows/frogbot-scan-repository.yml"])
+	assert.Contains(t, workflow, `branch: [ "release" ]`)
+	assert.Contains(t, workflow, "uses: actions/setup-java@v3")
+	assert.Equal(t, 1, strings.Count(workflow, "uses: actions/setup-node@v3"))
+	assert.NotContains(t, workflow, "actions/setup-go")
+	assert.Contains(t, workflow, "JF_URL: ${{ secrets.JF_URL }}")
+}
+
+func TestRunInit(t *testing.T) {
+	rootDir := createTestCheckout(t, "package.json")
+	failOnSecurityIssues := true
+	fixableOnly := false
+	options := Options{GitProvider: string(utils.GitHub), RepoName: "frogbot", Branch: "main", MinSeverity: "Low", FailOnSecurityIssues: &failOnSecurityIssues, FixableOnly: &fixableOnly, NonInteractive: true}
+	require.NoError(t, NewInitCmd(rootDir, options).Run())
+	configContent, err := os.ReadFile(filepath.Join(rootDir, utils.OsFrogbotConfigPath))
+	require.NoError(t, err)
+	assert.NoError(t, utils.ValidateConfigSchema(configContent))
+	assert.FileExists(t, filepath.Join(rootDir, ".github", "workflows", "frogbot-scan-pull-request.yml"))
+
+	// Existing files are overwritten only if forced
+	assert.ErrorContains(t, NewInitCmd(rootDir, options).Run(), "already exist")
+	options.Force = true
+	assert.NoError(t, NewInitCmd(rootDir, options).Run())
+}
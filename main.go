@@ -11,7 +11,16 @@ import (
 
 func main() {
 	log.SetDefaultLogger()
-	coreutils.ExitOnErr(ExecMain())
+	utils.RunAuditSubprocessIfRequested()
+	coreutils.ExitOnErr(toCliError(ExecMain()))
+}
+
+// Converts the error to an error that makes the process exit with the exit code of the error.
+func toCliError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return coreutils.CliError{ExitCode: coreutils.ExitCode{Code: int(utils.GetExitCode(err))}, ErrorMsg: err.Error()}
 }
 
 func ExecMain() error {
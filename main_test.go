@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	clientTests "github.com/jfrog/jfrog-client-go/utils/tests"
 	"io"
@@ -11,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	clitool "github.com/urfave/cli/v2"
 )
 
 var IntegrationTestPackages = []string{
@@ -46,3 +49,30 @@ func TestVersion(t *testing.T) {
 	expectedVersion := fmt.Sprintf("Frogbot version %s", utils.FrogbotVersion)
 	assert.Equal(t, expectedVersion, strings.TrimSpace(string(out)))
 }
+
+func TestToCliError(t *testing.T) {
+	assert.NoError(t, toCliError(nil))
+	err := toCliError(utils.WithExitCode(errors.New("issues were detected"), utils.ExitCodeIssuesFound))
+	var cliError coreutils.CliError
+	assert.ErrorAs(t, err, &cliError)
+	assert.Equal(t, int(utils.ExitCodeIssuesFound), cliError.Code)
+	assert.Equal(t, "issues were detected", cliError.ErrorMsg)
+}
+
+func TestNoSecretFlags(t *testing.T) {
+	secretEnvs := []string{utils.JFrogPasswordEnv, utils.JFrogTokenEnv, utils.JFrogOidcTokenEnv, utils.GitTokenEnv, utils.SmtpPasswordEnv, utils.WebhookSecretEnv}
+	for _, command := range GetCommands() {
+		for _, flag := range command.Flags {
+			for _, name := range flag.Names() {
+				for _, secretWord := range []string{"secret", "password", "token"} {
+					assert.NotContains(t, name, secretWord, "the %s flag of the %s command exposes a secret in the command line", name, command.Name)
+				}
+			}
+			if envFlag, ok := flag.(clitool.DocGenerationFlag); ok {
+				for _, env := range envFlag.GetEnvVars() {
+					assert.NotContains(t, secretEnvs, env, "the %s flag of the %s command exposes a secret in the command line", flag.Names()[0], command.Name)
+				}
+			}
+		}
+	}
+}
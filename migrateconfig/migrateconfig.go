@@ -0,0 +1,252 @@
+package migrateconfig
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/frogbot/schema"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configHeader = "# Generated by 'frogbot migrate-config' from the previous configuration, including the environment variables.\n" +
+		"# The full list of parameters is documented in:\n" +
+		"# https://github.com/jfrog/frogbot/blob/master/docs/templates/.frogbot/frogbot-config.yml\n"
+	deprecatedProvider = "bitbucket server"
+)
+
+// The CI pipeline files that may run Frogbot, relative to the root directory of the repository
+var ciFilePatterns = []string{
+	".github/workflows/*.yml",
+	".github/workflows/*.yaml",
+	".gitlab-ci.yml",
+	".azure-pipelines/*.yml",
+	"azure-pipelines.yml",
+	"Jenkinsfile",
+	".frogbot/*.jenkinsfile",
+	".frogbot/*.gitlab-ci.yml",
+	".jfrog-pipelines/*.yml",
+}
+
+// Deprecated usages in the CI pipeline files, and the values that replace them
+var deprecatedCiUsages = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?:^|[\s"'])(create-fix-pull-requests)(?:$|[\s"'])`), utils.ScanRepository},
+	{regexp.MustCompile(`(?:^|[\s"'])(scan-pull-requests)(?:$|[\s"'])`), utils.ScanAllPullRequests},
+	{regexp.MustCompile(`(?:^|[\s"'])(scan-and-fix-repos)(?:$|[\s"'])`), utils.ScanMultipleRepositories},
+	{regexp.MustCompile(utils.GitProvider + `\W+(` + deprecatedProvider + `)`), string(utils.BitbucketServer)},
+}
+
+// A deprecated value that should be replaced, since it may not be supported in future versions
+type Deprecation struct {
+	// Where the value was found, such as a file and line, or an environment variable
+	Location    string
+	Value       string
+	Replacement string
+}
+
+func (d Deprecation) String() string {
+	return fmt.Sprintf("%s: '%s' is deprecated. Use '%s' instead", d.Location, d.Value, d.Replacement)
+}
+
+// MigrateConfigCmd writes the configuration resolved from the frogbot-config.yml file and the environment variables
+// as a normalized and commented frogbot-config.yml file, and reports the deprecated values of the previous configuration.
+type MigrateConfigCmd struct {
+	// The root directory of the repository, which includes the CI pipeline files
+	rootDir    string
+	configPath string
+	outputPath string
+	// The environment variables that were set by command-line flags
+	flagsEnvs []string
+}
+
+func NewMigrateConfigCmd(rootDir, configPath, outputPath string, flagsEnvs []string) *MigrateConfigCmd {
+	if rootDir == "" {
+		rootDir = utils.RootDir
+	}
+	return &MigrateConfigCmd{rootDir: rootDir, configPath: configPath, outputPath: outputPath, flagsEnvs: flagsEnvs}
+}
+
+func (cmd *MigrateConfigCmd) Run() (err error) {
+	// The environment variables are cleared once the configuration is resolved
+	deprecations := findDeprecatedEnvValues()
+	ciDeprecations, err := findDeprecatedCiUsages(cmd.rootDir)
+	if err != nil {
+		return
+	}
+	deprecations = append(deprecations, ciDeprecations...)
+	configAggregator, err := utils.GetMigratedConfig(cmd.configPath, cmd.flagsEnvs)
+	if err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+	}
+	configContent, err := createConfig(configAggregator, deprecations)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(cmd.outputPath), 0755); err != nil {
+		return
+	}
+	if err = os.WriteFile(cmd.outputPath, configContent, 0600); err != nil {
+		return
+	}
+	log.Info("Wrote the migrated configuration to", cmd.outputPath)
+	for _, deprecation := range deprecations {
+		log.Warn(deprecation.String())
+	}
+	return
+}
+
+func findDeprecatedEnvValues() (deprecations []Deprecation) {
+	if strings.TrimSpace(os.Getenv(utils.GitProvider)) == deprecatedProvider {
+		deprecations = append(deprecations, Deprecation{Location: "the " + utils.GitProvider + " environment variable", Value: deprecatedProvider, Replacement: string(utils.BitbucketServer)})
+	}
+	return
+}
+
+// Searches the CI pipeline files of the repository for deprecated command names and values
+func findDeprecatedCiUsages(rootDir string) (deprecations []Deprecation, err error) {
+	var ciFiles []string
+	for _, pattern := range ciFilePatterns {
+		var matches []string
+		if matches, err = filepath.Glob(filepath.Join(rootDir, filepath.FromSlash(pattern))); err != nil {
+			return
+		}
+		ciFiles = append(ciFiles, matches...)
+	}
+	sort.Strings(ciFiles)
+	for _, ciFile := range ciFiles {
+		var content []byte
+		if content, err = os.ReadFile(ciFile); err != nil {
+			return
+		}
+		relativePath, relErr := filepath.Rel(rootDir, ciFile)
+		if relErr != nil {
+			relativePath = ciFile
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for lineNumber := 1; scanner.Scan(); lineNumber++ {
+			for _, usage := range deprecatedCiUsages {
+				if match := usage.pattern.FindStringSubmatch(scanner.Text()); match != nil {
+					deprecations = append(deprecations, Deprecation{Location: fmt.Sprintf("%s:%d", filepath.ToSlash(relativePath), lineNumber), Value: match[1], Replacement: usage.replacement})
+				}
+			}
+		}
+		if err = scanner.Err(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Creates the content of the frogbot-config.yml file, in which each parameter is described by a comment, and validates it against the Frogbot schema.
+func createConfig(configAggregator utils.RepoAggregator, deprecations []Deprecation) (configContent []byte, err error) {
+	var frogbotSchema map[string]interface{}
+	if err = json.Unmarshal(schema.FrogbotSchema, &frogbotSchema); err != nil {
+		return
+	}
+	configNode := &yaml.Node{}
+	if err = configNode.Encode(configAggregator); err != nil {
+		return
+	}
+	addSchemaComments(configNode, frogbotSchema, frogbotSchema, "", map[string]bool{})
+
+	content := bytes.Buffer{}
+	content.WriteString(configHeader)
+	if len(deprecations) > 0 {
+		content.WriteString("#\n# The following deprecated values were found, and should be replaced:\n")
+		for _, deprecation := range deprecations {
+			content.WriteString("# - " + deprecation.String() + "\n")
+		}
+	}
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err = errors.Join(encoder.Encode(configNode), encoder.Close()); err != nil {
+		return
+	}
+	if err = utils.ValidateConfigSchema(content.Bytes()); err != nil {
+		return
+	}
+	return content.Bytes(), nil
+}
+
+// Adds the description of each parameter in the schema as a comment above the parameter.
+// Each parameter is described once, even if it appears in several repositories or projects.
+func addSchemaComments(node *yaml.Node, nodeSchema, rootSchema map[string]interface{}, path string, describedPaths map[string]bool) {
+	nodeSchema = resolveSchemaRef(nodeSchema, rootSchema)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		itemsSchema, _ := nodeSchema["items"].(map[string]interface{})
+		for _, item := range node.Content {
+			addSchemaComments(item, itemsSchema, rootSchema, path+"[]", describedPaths)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			propertyPath := path + "." + key.Value
+			propertySchema := getPropertySchema(nodeSchema, rootSchema, key.Value)
+			if !describedPaths[propertyPath] {
+				key.HeadComment = getSchemaDescription(propertySchema, rootSchema)
+				describedPaths[propertyPath] = true
+			}
+			addSchemaComments(value, propertySchema, rootSchema, propertyPath, describedPaths)
+		}
+	}
+}
+
+// Returns the schema of the property in the object schema, or nil if the property isn't defined.
+// The properties of the schemas combined by allOf, anyOf and oneOf are searched as well.
+func getPropertySchema(objectSchema, rootSchema map[string]interface{}, property string) map[string]interface{} {
+	objectSchema = resolveSchemaRef(objectSchema, rootSchema)
+	if properties, ok := objectSchema["properties"].(map[string]interface{}); ok {
+		if propertySchema, ok := properties[property].(map[string]interface{}); ok {
+			return propertySchema
+		}
+	}
+	for _, combination := range []string{"allOf", "anyOf", "oneOf"} {
+		subSchemas, _ := objectSchema[combination].([]interface{})
+		for _, subSchema := range subSchemas {
+			subSchemaMapping, ok := subSchema.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if propertySchema := getPropertySchema(subSchemaMapping, rootSchema, property); propertySchema != nil {
+				return propertySchema
+			}
+		}
+	}
+	return nil
+}
+
+// The references in the Frogbot schema point to the definitions at its root, for example "#/$git"
+func resolveSchemaRef(nodeSchema, rootSchema map[string]interface{}) map[string]interface{} {
+	if ref, ok := nodeSchema["$ref"].(string); ok {
+		if refSchema, ok := rootSchema[strings.TrimPrefix(ref, "#/")].(map[string]interface{}); ok {
+			return refSchema
+		}
+	}
+	return nodeSchema
+}
+
+func getSchemaDescription(propertySchema, rootSchema map[string]interface{}) string {
+	for _, field := range []string{"description", "title"} {
+		if description, ok := propertySchema[field].(string); ok && description != "" {
+			return description
+		}
+		if description, ok := resolveSchemaRef(propertySchema, rootSchema)[field].(string); ok && description != "" {
+			return description
+		}
+	}
+	return ""
+}
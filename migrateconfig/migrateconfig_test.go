@@ -0,0 +1,105 @@
+package migrateconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, rootDir, file, content string) {
+	filePath := filepath.Join(rootDir, filepath.FromSlash(file))
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0600))
+}
+
+func TestFindDeprecatedCiUsages(t *testing.T) {
+	rootDir := t.TempDir()
+	writeTestFile(t, rootDir, ".github/workflows/frogbot-scan-pull-requests.yml", "name: Frogbot\n# Bitbucket Server isn't used\nrun: ./frogbot scan-pull-requests\n")
+	writeTestFile(t, rootDir, "Jenkinsfile", "JF_GIT_PROVIDER= \"bitbucket server\"\nsh \"./frogbot create-fix-pull-requests\"\nsh './frogbot scan-repository'\n")
+	writeTestFile(t, rootDir, "README.md", "./frogbot create-fix-pull-requests\n")
+	deprecations, err := findDeprecatedCiUsages(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []Deprecation{
+		{Location: ".github/workflows/frogbot-scan-pull-requests.yml:3", Value: "scan-pull-requests", Replacement: utils.ScanAllPullRequests},
+		{Location: "Jenkinsfile:1", Value: "bitbucket server", Replacement: string(utils.BitbucketServer)},
+		{Location: "Jenkinsfile:2", Value: "create-fix-pull-requests", Replacement: utils.ScanRepository},
+	}, deprecations)
+}
+
+func TestMigrateConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configContent string
+		envs          map[string]string
+		expectedRepo  string
+		expectedError string
+	}{
+		{
+			name: "Environment variables only",
+			envs: map[string]string{
+				utils.GitProvider:         "bitbucket server",
+				utils.GitRepoEnv:          "frogbot",
+				utils.GitBaseBranchEnv:    "dev",
+				utils.MinSeverityEnv:      "high",
+				utils.WorkingDirectoryEnv: "frontend",
+				utils.FixableOnlyEnv:      "true",
+			},
+			expectedRepo: "frogbot",
+		},
+		{
+			name:          "Configuration file and environment variables",
+			configContent: "- params:\n    git:\n      repoName: config-repo\n      branches: [dev]\n    scan:\n      projects:\n        - workingDirs: [frontend]\n",
+			envs:          map[string]string{utils.MinSeverityEnv: "high", utils.FixableOnlyEnv: "true", utils.GitProvider: "bitbucket server"},
+			expectedRepo:  "config-repo",
+		},
+		{
+			name:          "Missing branches",
+			envs:          map[string]string{utils.GitRepoEnv: "frogbot"},
+			expectedError: "no branches were provided",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			rootDir := t.TempDir()
+			configPath := filepath.Join(rootDir, utils.OsFrogbotConfigPath)
+			if test.configContent != "" {
+				writeTestFile(t, rootDir, utils.OsFrogbotConfigPath, test.configContent)
+			}
+			writeTestFile(t, rootDir, ".gitlab-ci.yml", "script:\n  - ./frogbot create-fix-pull-requests\n")
+			utils.SetEnvAndAssert(t, test.envs)
+			defer func() {
+				assert.NoError(t, utils.SanitizeEnv())
+			}()
+			err := NewMigrateConfigCmd(rootDir, configPath, configPath, nil).Run()
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			configContent, err := os.ReadFile(configPath)
+			require.NoError(t, err)
+			content := string(configContent)
+			assert.Contains(t, content, "# - the JF_GIT_PROVIDER environment variable: 'bitbucket server' is deprecated. Use 'bitbucketServer' instead\n")
+			assert.Contains(t, content, "# - .gitlab-ci.yml:2: 'create-fix-pull-requests' is deprecated. Use 'scan-repository' instead\n")
+			// The parameters are described by the schema
+			assert.Contains(t, content, "# The name of the git repository to scan.\n")
+
+			// The migrated file is resolved to the same configuration, without the environment variables
+			repoAggregator, err := utils.BuildRepoAggregator(configContent, &utils.Git{}, &config.ServerDetails{}, utils.ScanRepository)
+			require.NoError(t, err)
+			require.Len(t, repoAggregator, 1)
+			repo := repoAggregator[0]
+			assert.Equal(t, test.expectedRepo, repo.RepoName)
+			assert.Equal(t, []string{"dev"}, repo.Branches)
+			assert.Equal(t, "High", repo.MinSeverity)
+			assert.True(t, repo.FixableOnly)
+			require.Len(t, repo.Projects, 1)
+			assert.Equal(t, []string{"frontend"}, repo.Projects[0].WorkingDirs)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package scanpullrequest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// GitHub accepts up to 50 annotations in a single check run request
+	maxAnnotationsPerRequest = 50
+
+	checkRunInProgress = "in_progress"
+	checkRunCompleted  = "completed"
+)
+
+// The checks API of GitHub, which the VcsClient of froggit-go doesn't support.
+// It's implemented by the checks service of go-github, and faked in the tests.
+type gitHubChecks interface {
+	CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+}
+
+// Returns the checks API of the repository, or nil if the Git provider isn't GitHub.
+func getGitHubChecks(repo *utils.Repository) gitHubChecks {
+	if repo.GitProvider != vcsutils.GitHub {
+		return nil
+	}
+	client, err := createGitHubClient(repo.VcsInfo)
+	if err != nil {
+		log.Warn("Couldn't create a GitHub client for the check run, publishing a commit status instead:", err.Error())
+		return nil
+	}
+	return client.Checks
+}
+
+// Creates a go-github client with the API endpoint and the token of the VcsClient, the same way froggit-go creates its GitHub client.
+func createGitHubClient(vcsInfo vcsclient.VcsInfo) (*github.Client, error) {
+	httpClient := &http.Client{}
+	if vcsInfo.Token != "" {
+		httpClient.Transport = &bearerTokenTransport{token: vcsInfo.Token}
+	}
+	client := github.NewClient(httpClient)
+	if vcsInfo.APIEndpoint != "" {
+		baseURL, err := url.Parse(strings.TrimSuffix(vcsInfo.APIEndpoint, "/") + "/")
+		if err != nil {
+			return nil, err
+		}
+		client.BaseURL = baseURL
+	}
+	return client, nil
+}
+
+// Authenticates the requests with the token of the Git provider.
+type bearerTokenTransport struct {
+	token string
+}
+
+func (btt *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+btt.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// A GitHub check run, published on the head commit of the pull request.
+// Unlike commit statuses, check runs can include annotations, displayed next to the relevant lines in the pull request files view.
+type gitHubCheckRun struct {
+	checks gitHubChecks
+	owner  string
+	repo   string
+	id     int64
+}
+
+func createGitHubCheckRun(checks gitHubChecks, repo *utils.Repository, headSha string) (checkRun *gitHubCheckRun, err error) {
+	created, _, err := checks.CreateCheckRun(context.Background(), repo.RepoOwner, repo.RepoName, github.CreateCheckRunOptions{
+		Name:       commitStatusTitle,
+		HeadSHA:    headSha,
+		DetailsURL: github.String(repo.PullRequestDetails.URL),
+		Status:     github.String(checkRunInProgress),
+		StartedAt:  &github.Timestamp{Time: time.Now()},
+	})
+	if err != nil {
+		return
+	}
+	return &gitHubCheckRun{checks: checks, owner: repo.RepoOwner, repo: repo.RepoName, id: created.GetID()}, nil
+}
+
+// Completes the check run with the result of the scan. Since the number of annotations per request is limited,
+// the annotations are sent in batches, and the last batch completes the check run.
+func (cr *gitHubCheckRun) complete(status vcsclient.CommitStatus, description string, reviewComments []utils.ReviewComment) error {
+	annotations := createCheckRunAnnotations(reviewComments)
+	for {
+		batchSize := len(annotations)
+		if batchSize > maxAnnotationsPerRequest {
+			batchSize = maxAnnotationsPerRequest
+		}
+		options := github.UpdateCheckRunOptions{
+			Name: commitStatusTitle,
+			Output: &github.CheckRunOutput{
+				Title:       github.String(description),
+				Summary:     github.String(description),
+				Annotations: annotations[:batchSize],
+			},
+		}
+		annotations = annotations[batchSize:]
+		if len(annotations) == 0 {
+			options.Status = github.String(checkRunCompleted)
+			options.Conclusion = github.String(getCheckRunConclusion(status))
+			options.CompletedAt = &github.Timestamp{Time: time.Now()}
+		}
+		if _, _, err := cr.checks.UpdateCheckRun(context.Background(), cr.owner, cr.repo, cr.id, options); err != nil {
+			return err
+		}
+		if len(annotations) == 0 {
+			return nil
+		}
+	}
+}
+
+func getCheckRunConclusion(status vcsclient.CommitStatus) string {
+	if status == vcsclient.Pass {
+		return "success"
+	}
+	return "failure"
+}
+
+// Creates an annotation in the location of each review comment.
+func createCheckRunAnnotations(reviewComments []utils.ReviewComment) (annotations []*github.CheckRunAnnotation) {
+	for _, comment := range reviewComments {
+		location := comment.Location
+		startLine := location.StartLine
+		if startLine < 1 {
+			startLine = 1
+		}
+		endLine := location.EndLine
+		if endLine < startLine {
+			endLine = startLine
+		}
+		title := strings.TrimSpace(comment.Severity + " " + string(comment.Type) + " issue")
+		message := comment.Summary
+		if message == "" {
+			message = title
+		}
+		annotation := &github.CheckRunAnnotation{
+			Path:            github.String(location.File),
+			StartLine:       github.Int(startLine),
+			EndLine:         github.Int(endLine),
+			AnnotationLevel: github.String(getAnnotationLevel(comment.Severity)),
+			Title:           github.String(title),
+			Message:         github.String(message),
+		}
+		if location.Snippet != "" {
+			annotation.RawDetails = github.String(location.Snippet)
+		}
+		// GitHub accepts columns only for annotations of a single line
+		if startLine == endLine && location.StartColumn > 0 && location.EndColumn >= location.StartColumn {
+			annotation.StartColumn = github.Int(location.StartColumn)
+			annotation.EndColumn = github.Int(location.EndColumn)
+		}
+		annotations = append(annotations, annotation)
+	}
+	return
+}
+
+func getAnnotationLevel(severity string) string {
+	switch severity {
+	case "Critical", "High":
+		return "failure"
+	case "Medium":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
@@ -0,0 +1,119 @@
+package scanpullrequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	commitStatusTitle = "JFrog Frogbot"
+	// GitHub rejects commit statuses with longer descriptions
+	maxCommitStatusDescriptionLength = 140
+
+	scanInProgressDescription = "Frogbot is scanning the pull request"
+	scanFailedDescription     = "Frogbot failed to scan the pull request"
+	noIssuesFoundDescription  = "No security issues were found"
+)
+
+// commitStatusPublisher publishes the result of the pull request scan on the head commit of the pull request.
+// This allows branch protection rules to require a successful Frogbot scan, without relying on the exit code of the CI job.
+// On GitHub, a check run with annotations for the issues found is published. If the check run can't be created,
+// for example when the token lacks the checks permission, a commit status is published instead.
+type commitStatusPublisher struct {
+	repo     *utils.Repository
+	client   vcsclient.VcsClient
+	headSha  string
+	checkRun *gitHubCheckRun
+}
+
+// Marks the head commit of the pull request as being scanned.
+// If the checks API of GitHub is given, a check run is created instead of the commit status.
+func startCommitStatus(repo *utils.Repository, client vcsclient.VcsClient, checks gitHubChecks) (publisher *commitStatusPublisher, err error) {
+	source := repo.PullRequestDetails.Source
+	latestCommit, err := client.GetLatestCommit(context.Background(), source.Owner, source.Repository, source.Name)
+	if err != nil {
+		err = utils.WithExitCode(fmt.Errorf("failed to get the head commit of the pull request: %w", err), utils.ExitCodeVcsApiError)
+		return
+	}
+	publisher = &commitStatusPublisher{repo: repo, client: client, headSha: latestCommit.Hash}
+	if checks != nil {
+		var e error
+		if publisher.checkRun, e = createGitHubCheckRun(checks, repo, publisher.headSha); e == nil {
+			return
+		}
+		log.Warn("Couldn't create a GitHub check run, publishing a commit status instead:", e.Error())
+	}
+	err = publisher.setCommitStatus(vcsclient.InProgress, scanInProgressDescription)
+	return
+}
+
+// Publishes the result of the scan. Nil issues mean that the scan failed.
+// The status fails if failTask is true, the same way the issues found fail the exit code according to the fail policy.
+func (csp *commitStatusPublisher) publishResult(issues *utils.IssuesCollection, failTask bool) error {
+	status := getCommitStatus(issues, failTask)
+	description := getCommitStatusDescription(issues)
+	if csp.checkRun != nil {
+		var annotations []utils.ReviewComment
+		if issues != nil {
+			annotations = utils.GetNewReviewComments(csp.repo, issues)
+		}
+		if err := csp.checkRun.complete(status, description, annotations); err != nil {
+			return utils.WithExitCode(fmt.Errorf("failed to complete the GitHub check run: %w", err), utils.ExitCodeVcsApiError)
+		}
+		return nil
+	}
+	return csp.setCommitStatus(status, description)
+}
+
+func (csp *commitStatusPublisher) setCommitStatus(status vcsclient.CommitStatus, description string) error {
+	log.Debug("Setting the commit status of", csp.headSha, "to:", description)
+	if err := csp.client.SetCommitStatus(context.Background(), status, csp.repo.RepoOwner, csp.repo.RepoName, csp.headSha, commitStatusTitle, description, csp.repo.PullRequestDetails.URL); err != nil {
+		return utils.WithExitCode(fmt.Errorf("failed to set the commit status: %w", err), utils.ExitCodeVcsApiError)
+	}
+	return nil
+}
+
+func getCommitStatus(issues *utils.IssuesCollection, failTask bool) vcsclient.CommitStatus {
+	switch {
+	case issues == nil:
+		return vcsclient.Error
+	case failTask:
+		return vcsclient.Fail
+	default:
+		return vcsclient.Pass
+	}
+}
+
+func getCommitStatusDescription(issues *utils.IssuesCollection) string {
+	if issues == nil {
+		return scanFailedDescription
+	}
+	if !issues.IssuesExists() {
+		return noIssuesFoundDescription
+	}
+	var found []string
+	for _, count := range []struct {
+		name  string
+		count int
+	}{
+		{"vulnerabilities", len(issues.Vulnerabilities)},
+		{"license violations", len(issues.Licenses)},
+		{"secrets", len(issues.Secrets)},
+		{"IaC issues", len(issues.Iacs)},
+		{"SAST issues", len(issues.Sast)},
+	} {
+		if count.count > 0 {
+			found = append(found, fmt.Sprintf("%d %s", count.count, count.name))
+		}
+	}
+	description := "Security issues found: " + strings.Join(found, ", ")
+	if len(description) > maxCommitStatusDescriptionLength {
+		description = description[:maxCommitStatusDescriptionLength-3] + "..."
+	}
+	return description
+}
@@ -0,0 +1,236 @@
+package scanpullrequest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-github/v45/github"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHeadSha = "8b1d8b2c4d1e5e4f9a0d3c2b1a0f9e8d7c6b5a49"
+
+func TestGetCommitStatusDescription(t *testing.T) {
+	testCases := []struct {
+		name                string
+		issues              *utils.IssuesCollection
+		failTask            bool
+		expectedStatus      vcsclient.CommitStatus
+		expectedDescription string
+	}{
+		{name: "Scan failed", issues: nil, expectedStatus: vcsclient.Error, expectedDescription: scanFailedDescription},
+		{name: "No issues", issues: &utils.IssuesCollection{}, expectedStatus: vcsclient.Pass, expectedDescription: noIssuesFoundDescription},
+		{
+			name: "Issues found",
+			issues: &utils.IssuesCollection{
+				Vulnerabilities: []formats.VulnerabilityOrViolationRow{{}, {}},
+				Sast:            []formats.SourceCodeRow{{}},
+			},
+			failTask:            true,
+			expectedStatus:      vcsclient.Fail,
+			expectedDescription: "Security issues found: 2 vulnerabilities, 1 SAST issues",
+		},
+		{
+			name:                "Issues that don't fail the scan",
+			issues:              &utils.IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{{}}},
+			expectedStatus:      vcsclient.Pass,
+			expectedDescription: "Security issues found: 1 vulnerabilities",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatus, getCommitStatus(test.issues, test.failTask))
+			description := getCommitStatusDescription(test.issues)
+			assert.Equal(t, test.expectedDescription, description)
+			assert.LessOrEqual(t, len(description), maxCommitStatusDescriptionLength)
+		})
+	}
+}
+
+func TestPublishCommitStatus(t *testing.T) {
+	repo := &utils.Repository{}
+	repo.GitProvider = vcsutils.GitLab
+	repo.RepoOwner = "jfrog"
+	repo.RepoName = "frogbot"
+	repo.PullRequestDetails = vcsclient.PullRequestInfo{
+		ID:     1,
+		URL:    "https://gitlab.com/jfrog/frogbot/-/merge_requests/1",
+		Source: vcsclient.BranchInfo{Name: "feature", Repository: "frogbot-fork", Owner: "contributor"},
+	}
+	client := CreateMockVcsClient(t)
+	gomock.InOrder(
+		client.EXPECT().GetLatestCommit(context.Background(), "contributor", "frogbot-fork", "feature").Return(vcsclient.CommitInfo{Hash: testHeadSha}, nil),
+		client.EXPECT().SetCommitStatus(context.Background(), vcsclient.InProgress, "jfrog", "frogbot", testHeadSha, commitStatusTitle, scanInProgressDescription, repo.PullRequestDetails.URL).Return(nil),
+		client.EXPECT().SetCommitStatus(context.Background(), vcsclient.Fail, "jfrog", "frogbot", testHeadSha, commitStatusTitle, "Security issues found: 1 IaC issues", repo.PullRequestDetails.URL).Return(errors.New("forbidden")),
+	)
+
+	publisher, err := startCommitStatus(repo, client, getGitHubChecks(repo))
+	require.NoError(t, err)
+	assert.Nil(t, publisher.checkRun)
+	err = publisher.publishResult(&utils.IssuesCollection{Iacs: []formats.SourceCodeRow{{}}}, true)
+	assert.ErrorContains(t, err, "forbidden")
+	assert.Equal(t, utils.ExitCodeVcsApiError, utils.GetExitCode(err))
+}
+
+// Records the check runs created and updated, instead of calling the GitHub API
+type fakeGitHubChecks struct {
+	createErr     error
+	createOptions []github.CreateCheckRunOptions
+	updateOptions []github.UpdateCheckRunOptions
+}
+
+func (fgc *fakeGitHubChecks) CreateCheckRun(_ context.Context, _, _ string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	if fgc.createErr != nil {
+		return nil, nil, fgc.createErr
+	}
+	fgc.createOptions = append(fgc.createOptions, opts)
+	return &github.CheckRun{ID: github.Int64(10)}, nil, nil
+}
+
+func (fgc *fakeGitHubChecks) UpdateCheckRun(_ context.Context, _, _ string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	if checkRunID != 10 {
+		return nil, nil, errors.New("check run not found")
+	}
+	fgc.updateOptions = append(fgc.updateOptions, opts)
+	return &github.CheckRun{ID: github.Int64(checkRunID)}, nil, nil
+}
+
+func createCheckRunTestRepo() *utils.Repository {
+	repo := &utils.Repository{}
+	repo.GitProvider = vcsutils.GitHub
+	repo.RepoOwner = "jfrog"
+	repo.RepoName = "frogbot"
+	repo.OutputWriter = &outputwriter.StandardOutput{}
+	repo.PullRequestDetails = vcsclient.PullRequestInfo{ID: 1, URL: "https://github.com/jfrog/frogbot/pull/1", Source: vcsclient.BranchInfo{Name: "feature", Repository: "frogbot", Owner: "jfrog"}}
+	return repo
+}
+
+func TestPublishGitHubCheckRun(t *testing.T) {
+	repo := createCheckRunTestRepo()
+	client := CreateMockVcsClient(t)
+	client.EXPECT().GetLatestCommit(context.Background(), "jfrog", "frogbot", "feature").Return(vcsclient.CommitInfo{Hash: testHeadSha}, nil)
+	checks := &fakeGitHubChecks{}
+
+	publisher, err := startCommitStatus(repo, client, checks)
+	require.NoError(t, err)
+	require.NotNil(t, publisher.checkRun)
+	require.Len(t, checks.createOptions, 1)
+	assert.Equal(t, testHeadSha, checks.createOptions[0].HeadSHA)
+	assert.Equal(t, checkRunInProgress, checks.createOptions[0].GetStatus())
+
+	// More annotations than allowed in a single request are sent in several requests
+	issues := &utils.IssuesCollection{}
+	for i := 0; i < maxAnnotationsPerRequest+1; i++ {
+		issues.Sast = append(issues.Sast, formats.SourceCodeRow{SeverityDetails: formats.SeverityDetails{Severity: "High"}, Finding: "Stack Trace Exposure", Location: formats.Location{File: "index.js", StartLine: i + 1}})
+	}
+	require.NoError(t, publisher.publishResult(issues, true))
+	require.Len(t, checks.updateOptions, 2)
+	assert.Len(t, checks.updateOptions[0].Output.Annotations, maxAnnotationsPerRequest)
+	assert.Nil(t, checks.updateOptions[0].Status)
+	assert.Len(t, checks.updateOptions[1].Output.Annotations, 1)
+	assert.Equal(t, checkRunCompleted, checks.updateOptions[1].GetStatus())
+	assert.Equal(t, "failure", checks.updateOptions[1].GetConclusion())
+	assert.Equal(t, "Security issues found: 51 SAST issues", checks.updateOptions[1].Output.GetTitle())
+}
+
+func TestGitHubCheckRunConclusion(t *testing.T) {
+	issues := &utils.IssuesCollection{Iacs: []formats.SourceCodeRow{{SeverityDetails: formats.SeverityDetails{Severity: "Low"}, Location: formats.Location{File: "main.tf", StartLine: 1}}}}
+	testCases := []struct {
+		name               string
+		issues             *utils.IssuesCollection
+		failTask           bool
+		expectedConclusion string
+	}{
+		{name: "Issues that fail the scan", issues: issues, failTask: true, expectedConclusion: "failure"},
+		{name: "Issues that don't fail the scan", issues: issues, expectedConclusion: "success"},
+		{name: "Scan failed", expectedConclusion: "failure"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			checks := &fakeGitHubChecks{}
+			checkRun, err := createGitHubCheckRun(checks, createCheckRunTestRepo(), testHeadSha)
+			require.NoError(t, err)
+			publisher := &commitStatusPublisher{repo: createCheckRunTestRepo(), headSha: testHeadSha, checkRun: checkRun}
+			require.NoError(t, publisher.publishResult(test.issues, test.failTask))
+			require.Len(t, checks.updateOptions, 1)
+			assert.Equal(t, test.expectedConclusion, checks.updateOptions[0].GetConclusion())
+		})
+	}
+}
+
+func TestGitHubCheckRunFallsBackToCommitStatus(t *testing.T) {
+	repo := createCheckRunTestRepo()
+	client := CreateMockVcsClient(t)
+	gomock.InOrder(
+		client.EXPECT().GetLatestCommit(context.Background(), "jfrog", "frogbot", "feature").Return(vcsclient.CommitInfo{Hash: testHeadSha}, nil),
+		client.EXPECT().SetCommitStatus(context.Background(), vcsclient.InProgress, "jfrog", "frogbot", testHeadSha, commitStatusTitle, scanInProgressDescription, repo.PullRequestDetails.URL).Return(nil),
+	)
+
+	publisher, err := startCommitStatus(repo, client, &fakeGitHubChecks{createErr: errors.New("resource not accessible by integration")})
+	require.NoError(t, err)
+	assert.Nil(t, publisher.checkRun)
+}
+
+func TestCreateGitHubClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/api/v3/repos/jfrog/frogbot/check-runs", r.URL.Path)
+		_, err := w.Write([]byte(`{"id": 10}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := createGitHubClient(vcsclient.VcsInfo{APIEndpoint: server.URL + "/api/v3", Token: "token"})
+	require.NoError(t, err)
+	created, _, err := client.Checks.CreateCheckRun(context.Background(), "jfrog", "frogbot", github.CreateCheckRunOptions{Name: commitStatusTitle, HeadSHA: testHeadSha})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), created.GetID())
+}
+
+func TestCreateCheckRunAnnotations(t *testing.T) {
+	reviewComments := []utils.ReviewComment{
+		{
+			Location: formats.Location{File: "index.js", StartLine: 5, StartColumn: 3, EndLine: 5, EndColumn: 20, Snippet: "res.send(err.stack)"},
+			Type:     utils.SastComment,
+			Severity: "High",
+			Summary:  "Stack Trace Exposure",
+		},
+		{
+			Location: formats.Location{File: "main.tf", StartLine: 2, StartColumn: 1, EndLine: 8, EndColumn: 2},
+			Type:     utils.IacComment,
+			Severity: "Medium",
+		},
+	}
+	assert.Equal(t, []*github.CheckRunAnnotation{
+		{
+			Path:            github.String("index.js"),
+			StartLine:       github.Int(5),
+			EndLine:         github.Int(5),
+			StartColumn:     github.Int(3),
+			EndColumn:       github.Int(20),
+			AnnotationLevel: github.String("failure"),
+			Title:           github.String("High Sast issue"),
+			Message:         github.String("Stack Trace Exposure"),
+			RawDetails:      github.String("res.send(err.stack)"),
+		},
+		{
+			// Columns are allowed only in annotations of a single line
+			Path:            github.String("main.tf"),
+			StartLine:       github.Int(2),
+			EndLine:         github.Int(8),
+			AnnotationLevel: github.String("warning"),
+			Title:           github.String("Medium Iac issue"),
+			Message:         github.String("Medium Iac issue"),
+		},
+	}, createCheckRunAnnotations(reviewComments))
+}
@@ -0,0 +1,78 @@
+package scanpullrequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/xanzy/go-gitlab"
+)
+
+// Replaces the content of an existing pull request comment, so that the comment keeps its position in the pull request conversation.
+// The VcsClient of froggit-go can't edit comments, so the editor is implemented with the API client of each Git provider that supports it, and faked in the tests.
+type pullRequestCommentEditor interface {
+	EditPullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int, content string) error
+}
+
+// Returns the comment editor of the Git provider, or nil if editing comments isn't supported.
+// Only GitHub and GitLab comments are edited. On Bitbucket Server, Bitbucket Cloud and Azure Repos, the comment is deleted and added again.
+func getPullRequestCommentEditor(repo *utils.Repository) pullRequestCommentEditor {
+	var editor pullRequestCommentEditor
+	var err error
+	switch repo.GitProvider {
+	case vcsutils.GitHub:
+		editor, err = newGitHubCommentEditor(repo.VcsInfo)
+	case vcsutils.GitLab:
+		editor, err = newGitLabCommentEditor(repo.VcsInfo)
+	default:
+		return nil
+	}
+	if err != nil {
+		log.Debug("Couldn't create a client for editing the pull request comment, replacing it with a new comment instead:", err.Error())
+		return nil
+	}
+	return editor
+}
+
+type gitHubCommentEditor struct {
+	issues *github.IssuesService
+}
+
+func newGitHubCommentEditor(vcsInfo vcsclient.VcsInfo) (*gitHubCommentEditor, error) {
+	client, err := createGitHubClient(vcsInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &gitHubCommentEditor{issues: client.Issues}, nil
+}
+
+func (ghe *gitHubCommentEditor) EditPullRequestComment(ctx context.Context, owner, repository string, _, commentID int, content string) error {
+	_, _, err := ghe.issues.EditComment(ctx, owner, repository, int64(commentID), &github.IssueComment{Body: github.String(content)})
+	return err
+}
+
+type gitLabCommentEditor struct {
+	notes *gitlab.NotesService
+}
+
+// Creates the GitLab client with the API endpoint and the token of the VcsClient, the same way froggit-go creates its GitLab client.
+func newGitLabCommentEditor(vcsInfo vcsclient.VcsInfo) (*gitLabCommentEditor, error) {
+	var options []gitlab.ClientOptionFunc
+	if vcsInfo.APIEndpoint != "" {
+		options = append(options, gitlab.WithBaseURL(vcsInfo.APIEndpoint))
+	}
+	client, err := gitlab.NewClient(vcsInfo.Token, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &gitLabCommentEditor{notes: client.Notes}, nil
+}
+
+func (gle *gitLabCommentEditor) EditPullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int, content string) error {
+	_, _, err := gle.notes.UpdateMergeRequestNote(fmt.Sprintf("%s/%s", owner, repository), pullRequestID, commentID, &gitlab.UpdateMergeRequestNoteOptions{Body: gitlab.String(content)}, gitlab.WithContext(ctx))
+	return err
+}
@@ -0,0 +1,79 @@
+package scanpullrequest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPullRequestCommentEditor(t *testing.T) {
+	testCases := []struct {
+		gitProvider    vcsutils.VcsProvider
+		expectedEditor pullRequestCommentEditor
+	}{
+		{gitProvider: vcsutils.GitHub, expectedEditor: &gitHubCommentEditor{}},
+		{gitProvider: vcsutils.GitLab, expectedEditor: &gitLabCommentEditor{}},
+		// Editing comments isn't supported, so the comment is replaced
+		{gitProvider: vcsutils.BitbucketServer},
+		{gitProvider: vcsutils.BitbucketCloud},
+		{gitProvider: vcsutils.AzureRepos},
+	}
+	for _, test := range testCases {
+		t.Run(test.gitProvider.String(), func(t *testing.T) {
+			repo := &utils.Repository{}
+			repo.GitProvider = test.gitProvider
+			editor := getPullRequestCommentEditor(repo)
+			if test.expectedEditor == nil {
+				assert.Nil(t, editor)
+				return
+			}
+			assert.IsType(t, test.expectedEditor, editor)
+		})
+	}
+}
+
+func TestEditPullRequestComment(t *testing.T) {
+	testCases := []struct {
+		name         string
+		gitProvider  vcsutils.VcsProvider
+		expectedPath string
+		authHeader   string
+		expectedAuth string
+	}{
+		{name: "GitHub", gitProvider: vcsutils.GitHub, expectedPath: "/repos/owner/repo/issues/comments/20", authHeader: "Authorization", expectedAuth: "Bearer token"},
+		{name: "GitLab", gitProvider: vcsutils.GitLab, expectedPath: "/api/v4/projects/owner/repo/merge_requests/17/notes/20", authHeader: "Private-Token", expectedAuth: "token"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			edited := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedAuth, r.Header.Get(test.authHeader))
+				assert.Contains(t, []string{http.MethodPatch, http.MethodPut}, r.Method)
+				assert.Equal(t, test.expectedPath, r.URL.Path)
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Contains(t, string(body), "new content")
+				edited = true
+				_, err = w.Write([]byte("{}"))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+
+			repo := &utils.Repository{}
+			repo.GitProvider = test.gitProvider
+			repo.VcsInfo = vcsclient.VcsInfo{APIEndpoint: server.URL, Token: "token"}
+			editor := getPullRequestCommentEditor(repo)
+			require.NotNil(t, editor)
+			assert.NoError(t, editor.EditPullRequestComment(context.Background(), "owner", "repo", 17, 20, "new content"))
+			assert.True(t, edited)
+		})
+	}
+}
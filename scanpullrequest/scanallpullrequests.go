@@ -36,7 +36,7 @@ func (cmd ScanAllPullRequestsCmd) Run(configAggregator utils.RepoAggregator, cli
 func scanAllPullRequests(repo utils.Repository, client vcsclient.VcsClient) (err error) {
 	openPullRequests, err := client.ListOpenPullRequests(context.Background(), repo.RepoOwner, repo.RepoName)
 	if err != nil {
-		return err
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
 	}
 	for _, pr := range openPullRequests {
 		shouldScan, e := shouldScanPullRequest(repo, client, int(pr.ID))
@@ -59,6 +59,7 @@ func scanAllPullRequests(repo utils.Repository, client vcsclient.VcsClient) (err
 func shouldScanPullRequest(repo utils.Repository, client vcsclient.VcsClient, prID int) (shouldScan bool, err error) {
 	pullRequestsComments, err := utils.GetSortedPullRequestComments(client, repo.RepoOwner, repo.RepoName, prID)
 	if err != nil {
+		err = utils.WithExitCode(err, utils.ExitCodeVcsApiError)
 		return
 	}
 
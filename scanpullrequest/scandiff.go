@@ -0,0 +1,80 @@
+package scanpullrequest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ScanDiffCmd audits two refs of the repository (branches, tags or commits), and reports the issues that exist in the head ref but not in the base ref.
+// The refs are compared the same way the source and target branches of a pull request are compared.
+type ScanDiffCmd struct {
+	// The ref to compare to, for example, the previous release tag
+	baseRef string
+	// The ref to report the newly added issues of, for example, the new release tag
+	headRef string
+	// The format of the report, either markdown or json
+	format string
+	// The path of the file to write the report to. If empty, the report is printed to the standard output.
+	outputFile string
+}
+
+func NewScanDiffCmd(baseRef, headRef, format, outputFile string) *ScanDiffCmd {
+	if format == "" {
+		format = MarkdownReportFormat
+	}
+	return &ScanDiffCmd{baseRef: baseRef, headRef: headRef, format: format, outputFile: outputFile}
+}
+
+func (cmd *ScanDiffCmd) Run(configAggregator utils.RepoAggregator, client vcsclient.VcsClient) (err error) {
+	if cmd.baseRef == "" || cmd.headRef == "" {
+		return utils.WithExitCode(errors.New("both the base and the head refs must be provided"), utils.ExitCodeConfigurationError)
+	}
+	// The format is validated before the branches are audited, which may take a while
+	if err = validateReportFormat(cmd.format); err != nil {
+		return
+	}
+	if err = utils.ValidateSingleRepoConfiguration(&configAggregator); err != nil {
+		return
+	}
+	repoConfig := &(configAggregator)[0]
+	repoConfig.PullRequestDetails = cmd.createPullRequestInfo(repoConfig)
+
+	log.Info(fmt.Sprintf("Scanning the issues added in <%s/%s/%s> compared to <%s/%s/%s>",
+		repoConfig.RepoOwner, repoConfig.RepoName, cmd.headRef, repoConfig.RepoOwner, repoConfig.RepoName, cmd.baseRef))
+	log.Info("-----------------------------------------------------------")
+	scanSummary := repoConfig.RunSummary.AddScan(repoConfig.RepoOwner, repoConfig.RepoName, cmd.headRef, 0)
+	defer func() {
+		scanSummary.SetError(err)
+	}()
+	issues, failTask, err := auditPullRequest(repoConfig, client)
+	if err != nil {
+		return
+	}
+	scanSummary.SetIssues(issues)
+
+	report, err := createReport(issues, repoConfig.OutputWriter, cmd.format)
+	if err != nil {
+		return
+	}
+	if err = writeReport(report, cmd.outputFile); err != nil {
+		return
+	}
+
+	// Fail the Frogbot task if a security issue is found and Frogbot isn't configured to avoid the failure.
+	if failTask {
+		err = utils.WithExitCode(errors.New(securityIssueFoundErr), utils.ExitCodeIssuesFound)
+	}
+	return
+}
+
+// Represents the comparison of the two refs as a pull request from the head ref to the base ref.
+func (cmd *ScanDiffCmd) createPullRequestInfo(repoConfig *utils.Repository) vcsclient.PullRequestInfo {
+	return vcsclient.PullRequestInfo{
+		Source: vcsclient.BranchInfo{Name: cmd.headRef, Repository: repoConfig.RepoName, Owner: repoConfig.RepoOwner},
+		Target: vcsclient.BranchInfo{Name: cmd.baseRef, Repository: repoConfig.RepoName, Owner: repoConfig.RepoOwner},
+	}
+}
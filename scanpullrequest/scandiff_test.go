@@ -0,0 +1,43 @@
+package scanpullrequest
+
+import (
+	"testing"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScanDiffCmd(t *testing.T) {
+	cmd := NewScanDiffCmd("v1.0.0", "v1.1.0", "", "")
+	assert.Equal(t, MarkdownReportFormat, cmd.format)
+	assert.Equal(t, "v1.0.0", cmd.baseRef)
+	assert.Equal(t, "v1.1.0", cmd.headRef)
+
+	cmd = NewScanDiffCmd("main", "dev", JsonReportFormat, "report.json")
+	assert.Equal(t, JsonReportFormat, cmd.format)
+	assert.Equal(t, "report.json", cmd.outputFile)
+}
+
+func TestScanDiffCmdCreatePullRequestInfo(t *testing.T) {
+	repoConfig := &utils.Repository{Params: utils.Params{Git: utils.Git{RepoOwner: "jfrog", RepoName: "frogbot"}}}
+	pullRequestInfo := NewScanDiffCmd("v1.0.0", "3f786850e387550fdab836ed7e6dc881de23001b", "", "").createPullRequestInfo(repoConfig)
+	assert.Equal(t, vcsclient.PullRequestInfo{
+		Source: vcsclient.BranchInfo{Name: "3f786850e387550fdab836ed7e6dc881de23001b", Repository: "frogbot", Owner: "jfrog"},
+		Target: vcsclient.BranchInfo{Name: "v1.0.0", Repository: "frogbot", Owner: "jfrog"},
+	}, pullRequestInfo)
+}
+
+func TestScanDiffCmdMissingRefs(t *testing.T) {
+	repoAggregator := utils.RepoAggregator{{Params: utils.Params{Git: utils.Git{RepoOwner: "jfrog", RepoName: "frogbot"}}}}
+	assert.EqualError(t, NewScanDiffCmd("", "v1.1.0", "", "").Run(repoAggregator, nil), "both the base and the head refs must be provided")
+	assert.EqualError(t, NewScanDiffCmd("v1.0.0", "", "", "").Run(repoAggregator, nil), "both the base and the head refs must be provided")
+}
+
+func TestScanDiffCmdInvalidFormat(t *testing.T) {
+	// The format is rejected before the branches are audited
+	repoAggregator := utils.RepoAggregator{{Params: utils.Params{Git: utils.Git{RepoOwner: "jfrog", RepoName: "frogbot"}}}}
+	err := NewScanDiffCmd("v1.0.0", "v1.1.0", "xml", "").Run(repoAggregator, nil)
+	assert.EqualError(t, err, "the report format should be one of: 'markdown' or 'json'. received: xml")
+	assert.Equal(t, utils.ExitCodeConfigurationError, utils.GetExitCode(err))
+}
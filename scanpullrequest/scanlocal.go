@@ -0,0 +1,144 @@
+package scanpullrequest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	MarkdownReportFormat = "markdown"
+	JsonReportFormat     = "json"
+)
+
+// ScanLocalCmd audits the current working tree, without interacting with a Git provider.
+// The report is identical to the one added as a pull request comment, and can be used to reproduce it before pushing.
+type ScanLocalCmd struct {
+	// The format of the report, either markdown or json
+	format string
+	// The path of the file to write the report to. If empty, the report is printed to the standard output.
+	outputFile string
+}
+
+func NewScanLocalCmd(format, outputFile string) *ScanLocalCmd {
+	if format == "" {
+		format = MarkdownReportFormat
+	}
+	return &ScanLocalCmd{format: format, outputFile: outputFile}
+}
+
+func (cmd *ScanLocalCmd) Run(configAggregator utils.RepoAggregator, _ vcsclient.VcsClient) (err error) {
+	// The format is validated before the audit, which may take a while
+	if err = validateReportFormat(cmd.format); err != nil {
+		return
+	}
+	if err = utils.ValidateSingleRepoConfiguration(&configAggregator); err != nil {
+		return
+	}
+	repoConfig := &(configAggregator)[0]
+	wd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+
+	log.Info(fmt.Sprintf("Scanning the local working tree at %s", wd))
+	scanSummary := repoConfig.RunSummary.AddScan(repoConfig.RepoOwner, repoConfig.RepoName, "", 0)
+	defer func() {
+		scanSummary.SetError(err)
+	}()
+	issues, failTask, err := auditLocalWorkingTree(repoConfig, wd)
+	if err != nil {
+		return
+	}
+	scanSummary.SetIssues(issues)
+
+	report, err := createReport(issues, repoConfig.OutputWriter, cmd.format)
+	if err != nil {
+		return
+	}
+	if err = writeReport(report, cmd.outputFile); err != nil {
+		return
+	}
+
+	// Fail the Frogbot task if a security issue is found and Frogbot isn't configured to avoid the failure.
+	if failTask {
+		err = utils.WithExitCode(errors.New(securityIssueFoundErr), utils.ExitCodeIssuesFound)
+	}
+	return
+}
+
+func auditLocalWorkingTree(repoConfig *utils.Repository, wd string) (issuesCollection *utils.IssuesCollection, failTask bool, err error) {
+	scanDetails := utils.NewScanDetails(nil, &repoConfig.Server, &repoConfig.Git).
+		SetXrayGraphScanParams(repoConfig.Watches, repoConfig.JFrogProjectKey, true)
+
+	// Exclude the findings ignored by the .frogbot/ignore.yml file of the working tree
+	ignoreFile, err := utils.ReadIgnoreFile(wd)
+	if err != nil {
+		err = utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+		return
+	}
+	issuesCollection = &utils.IssuesCollection{}
+	for i := range repoConfig.Projects {
+		scanPolicy := repoConfig.Projects[i].GetScanPolicy(&repoConfig.Scan)
+		scanDetails.SetProject(&repoConfig.Projects[i]).
+			SetFailOnInstallationErrors(*scanPolicy.FailOnSecurityIssues)
+		workingDirs := utils.GetFullPathWorkingDirs(scanDetails.Project.WorkingDirs, wd)
+		var auditResults *audit.Results
+		if auditResults, err = scanDetails.RunInstallAndAudit(workingDirs...); err != nil {
+			return
+		}
+		scanResults := auditResults.ExtendedScanResults
+		repoConfig.OutputWriter.SetJasOutputFlags(scanResults.EntitledForJas, len(scanResults.ApplicabilityScanResults) > 0)
+		ignoreFile.FilterScanResults(scanResults)
+
+		var projectIssues *utils.IssuesCollection
+		if projectIssues, err = getAllIssues(auditResults, scanPolicy.AllowedLicenses); err != nil {
+			return
+		}
+		utils.ConvertSarifPathsToRelative(projectIssues, wd)
+		ignoreFile.FilterIssues(projectIssues)
+		failTask = applyScanPolicy(&scanPolicy, projectIssues, !scanDetails.XrayGraphScanParams.IncludeVulnerabilities) || failTask
+		issuesCollection.Append(projectIssues)
+	}
+	return
+}
+
+// Creates the report in the requested format.
+// The markdown report is identical to the comment Frogbot adds to the pull request.
+func createReport(issues *utils.IssuesCollection, writer outputwriter.OutputWriter, format string) (string, error) {
+	switch format {
+	case MarkdownReportFormat:
+		return createPullRequestComment(issues, writer), nil
+	case JsonReportFormat:
+		content, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+	return "", validateReportFormat(format)
+}
+
+func validateReportFormat(format string) error {
+	if format == MarkdownReportFormat || format == JsonReportFormat {
+		return nil
+	}
+	return utils.WithExitCode(fmt.Errorf("the report format should be one of: '%s' or '%s'. received: %s", MarkdownReportFormat, JsonReportFormat, format), utils.ExitCodeConfigurationError)
+}
+
+// Writes the report to the output file if provided, otherwise prints it to the standard output.
+func writeReport(report, outputFile string) error {
+	if outputFile == "" {
+		log.Output(report)
+		return nil
+	}
+	log.Info("Writing the report to", outputFile)
+	return os.WriteFile(outputFile, []byte(report), 0600)
+}
@@ -0,0 +1,74 @@
+package scanpullrequest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReport(t *testing.T) {
+	issues := &utils.IssuesCollection{
+		Vulnerabilities: []formats.VulnerabilityOrViolationRow{
+			{
+				Summary: "Summary",
+				ImpactedDependencyDetails: formats.ImpactedDependencyDetails{
+					SeverityDetails:           formats.SeverityDetails{Severity: "High"},
+					ImpactedDependencyName:    "impacted",
+					ImpactedDependencyVersion: "1.0.0",
+				},
+				FixedVersions: []string{"2.0.0"},
+				Cves:          []formats.CveRow{{Id: "CVE-2023-1234"}},
+			},
+		},
+	}
+	writer := &outputwriter.StandardOutput{}
+
+	// Markdown report should be identical to the pull request comment
+	report, err := createReport(issues, writer, MarkdownReportFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, createPullRequestComment(issues, writer), report)
+
+	// JSON report should contain the issues
+	report, err = createReport(issues, writer, JsonReportFormat)
+	assert.NoError(t, err)
+	var actualIssues utils.IssuesCollection
+	assert.NoError(t, json.Unmarshal([]byte(report), &actualIssues))
+	assert.Equal(t, *issues, actualIssues)
+
+	// Unsupported format
+	_, err = createReport(issues, writer, "xml")
+	assert.Error(t, err)
+}
+
+func TestWriteReport(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.md")
+	assert.NoError(t, writeReport("report content", outputFile))
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "report content", string(content))
+
+	// Print to the standard output
+	assert.NoError(t, writeReport("report content", ""))
+}
+
+func TestNewScanLocalCmd(t *testing.T) {
+	cmd := NewScanLocalCmd("", "")
+	assert.Equal(t, MarkdownReportFormat, cmd.format)
+	cmd = NewScanLocalCmd(JsonReportFormat, "report.json")
+	assert.Equal(t, JsonReportFormat, cmd.format)
+	assert.Equal(t, "report.json", cmd.outputFile)
+}
+
+func TestScanLocalCmdInvalidFormat(t *testing.T) {
+	// The format is rejected before the working tree is audited
+	repoAggregator := utils.RepoAggregator{{Params: utils.Params{Git: utils.Git{RepoOwner: "jfrog", RepoName: "frogbot"}}}}
+	err := NewScanLocalCmd("xml", "").Run(repoAggregator, nil)
+	assert.EqualError(t, err, "the report format should be one of: 'markdown' or 'json'. received: xml")
+	assert.Equal(t, utils.ExitCodeConfigurationError, utils.GetExitCode(err))
+}
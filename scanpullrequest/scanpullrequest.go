@@ -2,6 +2,8 @@ package scanpullrequest
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"golang.org/x/exp/slices"
@@ -25,6 +27,19 @@ const (
 	noGitHubEnvErr          = "frogbot did not scan this PR, because a GitHub Environment named 'frogbot' does not exist. Please refer to the Frogbot documentation for instructions on how to create the Environment"
 	noGitHubEnvReviewersErr = "frogbot did not scan this PR, because the existing GitHub Environment named 'frogbot' doesn't have reviewers selected. Please refer to the Frogbot documentation for instructions on how to create the Environment"
 	frogbotCommentNotFound  = -1
+	// The source and target branches of the pull request
+	maxConcurrentBranchAudits = 2
+)
+
+// The types of the issues shown as fixed by the pull request
+const (
+	vulnerabilityIssueType     = "Vulnerability"
+	securityViolationIssueType = "Security Violation"
+	licenseViolationIssueType  = "License Violation"
+	licenseIssueType           = "License"
+	iacIssueType               = "IaC"
+	secretIssueType            = "Secret"
+	sastIssueType              = "SAST"
 )
 
 type ScanPullRequestCmd struct{}
@@ -43,7 +58,7 @@ func (cmd *ScanPullRequestCmd) Run(configAggregator utils.RepoAggregator, client
 	}
 
 	if repoConfig.PullRequestDetails, err = client.GetPullRequestByID(context.Background(), repoConfig.RepoOwner, repoConfig.RepoName, int(repoConfig.PullRequestDetails.ID)); err != nil {
-		return
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
 	}
 
 	return scanPullRequest(repoConfig, client)
@@ -63,7 +78,7 @@ func verifyGitHubFrogbotEnvironment(client vcsclient.VcsClient, repoConfig *util
 	// If the repository is not public, using 'frogbot' environment is not mandatory
 	repoInfo, err := client.GetRepositoryInfo(context.Background(), repoConfig.RepoOwner, repoConfig.RepoName)
 	if err != nil {
-		return err
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
 	}
 	if repoInfo.RepositoryVisibility != vcsclient.Public {
 		return nil
@@ -72,10 +87,10 @@ func verifyGitHubFrogbotEnvironment(client vcsclient.VcsClient, repoConfig *util
 	// Get the 'frogbot' environment info and make sure it exists and includes reviewers
 	repoEnvInfo, err := client.GetRepositoryEnvironmentInfo(context.Background(), repoConfig.RepoOwner, repoConfig.RepoName, "frogbot")
 	if err != nil {
-		return errors.New(err.Error() + "\n" + noGitHubEnvErr)
+		return utils.WithExitCode(errors.New(err.Error()+"\n"+noGitHubEnvErr), utils.ExitCodeConfigurationError)
 	}
 	if len(repoEnvInfo.Reviewers) == 0 {
-		return errors.New(noGitHubEnvReviewersErr)
+		return utils.WithExitCode(errors.New(noGitHubEnvReviewersErr), utils.ExitCodeConfigurationError)
 	}
 
 	return nil
@@ -92,12 +107,31 @@ func scanPullRequest(repo *utils.Repository, client vcsclient.VcsClient) (err er
 		pullRequestDetails.Source.Owner, pullRequestDetails.Source.Repository, pullRequestDetails.Source.Name,
 		pullRequestDetails.Target.Owner, pullRequestDetails.Target.Repository, pullRequestDetails.Target.Name))
 	log.Info("-----------------------------------------------------------")
+	scanSummary := repo.RunSummary.AddScan(repo.RepoOwner, repo.RepoName, pullRequestDetails.Source.Name, pullRequestDetails.ID)
+	defer func() {
+		scanSummary.SetError(err)
+	}()
+
+	// Publish the scan result on the head commit of the pull request, if needed
+	var issues *utils.IssuesCollection
+	var failTask bool
+	if repo.PublishCommitStatus {
+		var statusPublisher *commitStatusPublisher
+		if statusPublisher, err = startCommitStatus(repo, client, getGitHubChecks(repo)); err != nil {
+			return
+		}
+		defer func() {
+			err = errors.Join(err, statusPublisher.publishResult(issues, failTask))
+		}()
+	}
 
 	// Audit PR code
-	issues, err := auditPullRequest(repo, client)
-	if err != nil {
+	if issues, failTask, err = auditPullRequest(repo, client); err != nil {
+		// The issues are partial, therefore the scan result is a failure
+		issues = nil
 		return
 	}
+	scanSummary.SetIssues(issues)
 
 	shouldSendExposedSecretsEmail := issues.SecretsExists() && repo.SmtpServer != ""
 	if shouldSendExposedSecretsEmail {
@@ -107,121 +141,185 @@ func scanPullRequest(repo *utils.Repository, client vcsclient.VcsClient) (err er
 		}
 	}
 
-	// Delete previous Frogbot pull request message if exists
-	if err = deleteExistingPullRequestComment(repo, client); err != nil {
-		return
-	}
-
 	// Create a pull request message
 	message := createPullRequestComment(issues, repo.OutputWriter)
 
-	// Add SCA scan comment
-	if err = client.AddPullRequestComment(context.Background(), repo.RepoOwner, repo.RepoName, message, int(pullRequestDetails.ID)); err != nil {
-		err = errors.New("couldn't add pull request comment: " + err.Error())
-		return
+	// Update the previous Frogbot pull request message if exists, or add a new one
+	if err = addOrUpdatePullRequestComment(repo, client, getPullRequestCommentEditor(repo), message); err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
 	}
 
 	// Handle review comments at the pull request
 	if err = utils.AddReviewComments(repo, int(pullRequestDetails.ID), client, issues); err != nil {
-		err = errors.New("couldn't add review comments: " + err.Error())
+		err = utils.WithExitCode(errors.New("couldn't add review comments: "+err.Error()), utils.ExitCodeVcsApiError)
 		return
 	}
 
 	// Fail the Frogbot task if a security issue is found and Frogbot isn't configured to avoid the failure.
-	if toFailTaskStatus(repo, issues) {
-		err = errors.New(securityIssueFoundErr)
+	if failTask {
+		err = utils.WithExitCode(errors.New(securityIssueFoundErr), utils.ExitCodeIssuesFound)
 	}
 	return
 }
 
-func toFailTaskStatus(repo *utils.Repository, issues *utils.IssuesCollection) bool {
-	failFlagSet := repo.FailOnSecurityIssues != nil && *repo.FailOnSecurityIssues
-	return failFlagSet && issues.IssuesExists()
+// Returns true if the issues fail the task according to the fail policy, unless Frogbot is configured to avoid the failure.
+// When scanning against Xray watches or a JFrog project, the vulnerabilities are security violations and the licenses are license violations.
+func toFailTaskStatus(scanPolicy *utils.Scan, issues *utils.IssuesCollection, violations bool) bool {
+	failFlagSet := scanPolicy.FailOnSecurityIssues != nil && *scanPolicy.FailOnSecurityIssues
+	return failFlagSet && scanPolicy.GetFailPolicy().IsFailing(issues, violations)
+}
+
+// Returns true if the issues fail the task, and then keeps only the issues shown according to the minimum severity and the fixable only filters.
+// The issues are audited without these filters, so that the fail policy can fail the task on issues that aren't shown.
+func applyScanPolicy(scanPolicy *utils.Scan, issues *utils.IssuesCollection, violations bool) (failTask bool) {
+	failTask = toFailTaskStatus(scanPolicy, issues, violations)
+	issues.FilterVulnerabilities(scanPolicy.MinSeverity, scanPolicy.FixableOnly)
+	return
 }
 
-// Downloads Pull Requests branches code and audits them
-func auditPullRequest(repoConfig *utils.Repository, client vcsclient.VcsClient) (issuesCollection *utils.IssuesCollection, err error) {
+// Downloads Pull Requests branches code and audits them.
+// Each project is audited according to its own scan policy. failTask is true if security issues were found in a project that is configured to fail on them.
+func auditPullRequest(repoConfig *utils.Repository, client vcsclient.VcsClient) (issuesCollection *utils.IssuesCollection, failTask bool, err error) {
 	scanDetails := utils.NewScanDetails(client, &repoConfig.Server, &repoConfig.Git).
-		SetXrayGraphScanParams(repoConfig.Watches, repoConfig.JFrogProjectKey, true).
-		SetMinSeverity(repoConfig.MinSeverity).
-		SetFixableOnly(repoConfig.FixableOnly).
-		SetFailOnInstallationErrors(*repoConfig.FailOnSecurityIssues)
+		SetXrayGraphScanParams(repoConfig.Watches, repoConfig.JFrogProjectKey, true)
 
+	// Only the working directories affected by the files changed in the pull request are scanned
+	changedFiles := utils.GetPullRequestChangedFiles(client, &repoConfig.PullRequestDetails)
 	issuesCollection = &utils.IssuesCollection{}
 	for i := range repoConfig.Projects {
-		scanDetails.SetProject(&repoConfig.Projects[i])
+		scanPolicy := repoConfig.Projects[i].GetScanPolicy(&repoConfig.Scan)
+		project := repoConfig.Projects[i]
+		// All the issues of the source branch are shown when including all the vulnerabilities, whether or not they're affected by the pull request
+		if !scanPolicy.IncludeAllVulnerabilities {
+			if project.WorkingDirs = changedFiles.GetAffectedWorkingDirs(project.WorkingDirs); len(project.WorkingDirs) == 0 {
+				log.Info("Skipping the project in", strings.Join(repoConfig.Projects[i].WorkingDirs, ", "), "since it isn't affected by the files changed in the pull request")
+				continue
+			}
+		}
+		scanDetails.SetProject(&project).
+			SetFailOnInstallationErrors(*scanPolicy.FailOnSecurityIssues)
 		var projectIssues *utils.IssuesCollection
-		if projectIssues, err = auditPullRequestInProject(repoConfig, scanDetails); err != nil {
+		if projectIssues, err = auditPullRequestInProject(repoConfig, scanDetails, &scanPolicy, changedFiles); err != nil {
 			return
 		}
+		failTask = applyScanPolicy(&scanPolicy, projectIssues, !scanDetails.XrayGraphScanParams.IncludeVulnerabilities) || failTask
 		issuesCollection.Append(projectIssues)
 	}
 	return
 }
 
-func auditPullRequestInProject(repoConfig *utils.Repository, scanDetails *utils.ScanDetails) (auditIssues *utils.IssuesCollection, err error) {
-	// Download source branch
-	sourcePullRequestInfo := scanDetails.PullRequestDetails.Source
-	sourceBranchWd, cleanupSource, err := utils.DownloadRepoToTempDir(scanDetails.Client(), sourcePullRequestInfo.Owner, sourcePullRequestInfo.Repository, sourcePullRequestInfo.Name)
-	if err != nil {
-		return
+func auditPullRequestInProject(repoConfig *utils.Repository, scanDetails *utils.ScanDetails, scanPolicy *utils.Scan, changedFiles *utils.ChangedFiles) (auditIssues *utils.IssuesCollection, err error) {
+	branches := []vcsclient.BranchInfo{scanDetails.PullRequestDetails.Source}
+	var targetIssues *branchIssues
+	var targetCacheKey string
+	if !scanPolicy.IncludeAllVulnerabilities {
+		// The target branch is audited to exclude the issues that already exist in it, unless its issues are cached
+		targetCacheKey = getTargetBranchCacheKey(repoConfig.ResultsCache, scanDetails)
+		cachedIssues := &branchIssues{}
+		if targetCacheKey != "" && repoConfig.ResultsCache.Get(targetCacheKey, cachedIssues) {
+			log.Info("Using the cached audit results of the target branch")
+			targetIssues = cachedIssues
+		} else {
+			branches = append(branches, scanDetails.PullRequestDetails.Target)
+		}
 	}
+
+	// Download, install and audit the branches concurrently. jfrog-cli-core changes the working directory of the process while auditing,
+	// so when both branches are audited, each audit runs in a subprocess of its own
+	scanDetails.SetAuditInSubprocess(len(branches) > 1)
+	audits := make([]*branchAudit, len(branches))
 	defer func() {
-		err = errors.Join(err, cleanupSource())
+		for _, audited := range audits {
+			if audited != nil {
+				err = errors.Join(err, audited.cleanup())
+			}
+		}
 	}()
-
-	// Audit source branch
-	var sourceResults *audit.Results
-	workingDirs := utils.GetFullPathWorkingDirs(scanDetails.Project.WorkingDirs, sourceBranchWd)
-	sourceResults, err = scanDetails.RunInstallAndAudit(workingDirs...)
-	if err != nil {
+	auditTasks := make([]func() error, len(branches))
+	for i := range branches {
+		i := i
+		auditTasks[i] = func() (auditErr error) {
+			audits[i], auditErr = downloadAndAuditBranch(scanDetails, branches[i])
+			return
+		}
+	}
+	if err = utils.RunConcurrently(maxConcurrentBranchAudits, auditTasks...); err != nil {
 		return
 	}
+	source := audits[0]
 
 	// Set JAS output flags
-	sourceScanResults := sourceResults.ExtendedScanResults
+	sourceScanResults := source.results.ExtendedScanResults
 	repoConfig.OutputWriter.SetJasOutputFlags(sourceScanResults.EntitledForJas, len(sourceScanResults.ApplicabilityScanResults) > 0)
 
-	// Get all issues that exist in the source branch
-	if repoConfig.IncludeAllVulnerabilities {
-		if auditIssues, err = getAllIssues(sourceResults, repoConfig.AllowedLicenses); err != nil {
+	// The issues of the source branch are collected before they're filtered, so that only the issues removed by the pull request are shown as fixed
+	var sourceIssues *branchIssues
+	if !scanPolicy.IncludeAllVulnerabilities {
+		if sourceIssues, err = getBranchIssues(source.results); err != nil {
 			return
 		}
-		utils.ConvertSarifPathsToRelative(auditIssues, sourceBranchWd)
+	}
+
+	// Exclude the findings ignored by the .frogbot/ignore.yml file of the source branch
+	ignoreFile, err := utils.ReadIgnoreFile(source.wd)
+	if err != nil {
+		err = utils.WithExitCode(err, utils.ExitCodeConfigurationError)
 		return
 	}
+	ignoreFile.FilterScanResults(sourceScanResults)
 
-	var targetBranchWd string
-	if auditIssues, targetBranchWd, err = auditTargetBranch(repoConfig, scanDetails, sourceResults); err != nil {
+	// Get all issues that exist in the source branch
+	if scanPolicy.IncludeAllVulnerabilities {
+		if auditIssues, err = getAllIssues(source.results, scanPolicy.AllowedLicenses); err != nil {
+			return
+		}
+		utils.ConvertSarifPathsToRelative(auditIssues, source.wd)
+		ignoreFile.FilterIssues(auditIssues)
 		return
 	}
-	utils.ConvertSarifPathsToRelative(auditIssues, sourceBranchWd, targetBranchWd)
-	return
-}
 
-func auditTargetBranch(repoConfig *utils.Repository, scanDetails *utils.ScanDetails, sourceScanResults *audit.Results) (newIssues *utils.IssuesCollection, targetBranchWd string, err error) {
-	// Download target branch (if needed)
-	cleanupTarget := func() error { return nil }
-	if !repoConfig.IncludeAllVulnerabilities {
-		targetBranchInfo := repoConfig.PullRequestDetails.Target
-		if targetBranchWd, cleanupTarget, err = utils.DownloadRepoToTempDir(scanDetails.Client(), targetBranchInfo.Owner, targetBranchInfo.Repository, targetBranchInfo.Name); err != nil {
+	// Findings in files that weren't changed by the pull request aren't added by it. The whole working directories were scanned, so the results are filtered here.
+	changedFiles.FilterScanResults(sourceScanResults, source.wd)
+
+	if targetIssues == nil {
+		if targetIssues, err = getBranchIssues(audits[1].results); err != nil {
 			return
 		}
+		// The issues are cached only if the target branch wasn't updated while it was audited
+		if targetCacheKey != "" && targetCacheKey == getTargetBranchCacheKey(repoConfig.ResultsCache, scanDetails) {
+			repoConfig.ResultsCache.Put(targetCacheKey, targetIssues)
+		}
 	}
-	defer func() {
-		err = errors.Join(err, cleanupTarget())
-	}()
 
-	// Set target branch scan details
-	var targetResults *audit.Results
-	workingDirs := utils.GetFullPathWorkingDirs(scanDetails.Project.WorkingDirs, targetBranchWd)
-	targetResults, err = scanDetails.RunInstallAndAudit(workingDirs...)
-	if err != nil {
+	// Get newly added issues
+	if auditIssues, err = getNewlyAddedIssues(targetIssues, source.results, scanPolicy.AllowedLicenses); err != nil {
 		return
 	}
+	utils.ConvertSarifPathsToRelative(auditIssues, source.wd)
+	ignoreFile.FilterIssues(auditIssues)
+	auditIssues.FixedIssues = getFixedIssues(targetIssues, sourceIssues, scanPolicy)
+	return
+}
 
-	// Get newly added issues
-	newIssues, err = getNewlyAddedIssues(targetResults, sourceScanResults, repoConfig.AllowedLicenses)
+// The audit results of a branch, which is downloaded to a temporary directory
+type branchAudit struct {
+	wd      string
+	results *audit.Results
+	// Removes the temporary directory of the branch
+	cleanup func() error
+}
+
+// Downloads the branch and audits the working directories of the project in it.
+// If the returned branchAudit isn't nil, its cleanup function should be called, even if an error is returned.
+func downloadAndAuditBranch(scanDetails *utils.ScanDetails, branch vcsclient.BranchInfo) (audited *branchAudit, err error) {
+	wd, cleanup, err := utils.DownloadRepoToTempDir(scanDetails.Client(), branch.Owner, branch.Repository, branch.Name)
+	if cleanup != nil {
+		audited = &branchAudit{wd: wd, cleanup: cleanup}
+	}
+	if err != nil {
+		return
+	}
+	audited.results, err = scanDetails.RunInstallAndAudit(utils.GetFullPathWorkingDirs(scanDetails.Project.WorkingDirs, wd)...)
 	return
 }
 
@@ -241,36 +339,188 @@ func getAllIssues(results *audit.Results, allowedLicenses []string) (*utils.Issu
 	}, nil
 }
 
+// The keys of the issues found in a branch. An issue of the source branch is added by the pull request if its key isn't found in the target branch,
+// and an issue of the target branch is fixed by the pull request if its key isn't found in the source branch.
+// The issues of the target branch are cached by its commit, so that pull requests targeting the same commit don't audit it again.
+type branchIssues struct {
+	Vulnerabilities    []string `json:"vulnerabilities,omitempty"`
+	SecurityViolations []string `json:"securityViolations,omitempty"`
+	LicenseViolations  []string `json:"licenseViolations,omitempty"`
+	Licenses           []string `json:"licenses,omitempty"`
+	// The keys of the source code issues are hashed, to avoid caching the secrets found in the target branch
+	Iacs    []string `json:"iacs,omitempty"`
+	Secrets []string `json:"secrets,omitempty"`
+	Sast    []string `json:"sast,omitempty"`
+	// The issues of all the categories, with the details needed to show them as fixed. The snippets of the source code issues aren't kept.
+	Issues []branchIssue `json:"issues,omitempty"`
+}
+
+type branchIssue struct {
+	Key string `json:"key"`
+	// Whether the vulnerability or security violation has a fix version
+	Fixable bool `json:"fixable,omitempty"`
+	outputwriter.FixedIssue
+}
+
+func getBranchIssues(results *audit.Results) (issues *branchIssues, err error) {
+	scanResults := results.ExtendedScanResults
+	aggregatedScan := aggregateScanResults(scanResults.XrayResults)
+	vulnerabilitiesRows, err := xrayutils.PrepareVulnerabilities(aggregatedScan.Vulnerabilities, scanResults, results.IsMultipleRootProject, true)
+	if err != nil {
+		return
+	}
+	securityViolationsRows, licenseViolationsRows, _, err := xrayutils.PrepareViolations(aggregatedScan.Violations, scanResults, results.IsMultipleRootProject, true)
+	if err != nil {
+		return
+	}
+	licensesRows, err := xrayutils.PrepareLicenses(aggregatedScan.Licenses)
+	if err != nil {
+		return
+	}
+	iacRows := xrayutils.PrepareIacs(scanResults.IacScanResults)
+	secretsRows := xrayutils.PrepareIacs(scanResults.SecretsScanResults)
+	sastRows := xrayutils.PrepareSast(scanResults.SastScanResults)
+	issues = &branchIssues{
+		Vulnerabilities:    getVulnerabilityOrViolationRowsKeys(vulnerabilitiesRows),
+		SecurityViolations: getVulnerabilityOrViolationRowsKeys(securityViolationsRows),
+		LicenseViolations:  getLicenseRowsKeys(licenseViolationsRows),
+		Licenses:           getLicenseRowsKeys(licensesRows),
+		Iacs:               getSourceCodeRowsKeys(iacRows),
+		Secrets:            getSourceCodeRowsKeys(secretsRows),
+		Sast:               getSourceCodeRowsKeys(sastRows),
+	}
+	issues.addVulnerabilityOrViolationIssues(vulnerabilityIssueType, vulnerabilitiesRows)
+	issues.addVulnerabilityOrViolationIssues(securityViolationIssueType, securityViolationsRows)
+	issues.addLicenseIssues(licenseViolationIssueType, licenseViolationsRows)
+	issues.addLicenseIssues(licenseIssueType, licensesRows)
+	issues.addSourceCodeIssues(iacIssueType, iacRows)
+	issues.addSourceCodeIssues(secretIssueType, secretsRows)
+	issues.addSourceCodeIssues(sastIssueType, sastRows)
+	return
+}
+
+func (bi *branchIssues) addVulnerabilityOrViolationIssues(issueType string, rows []formats.VulnerabilityOrViolationRow) {
+	for _, row := range rows {
+		issue := row.IssueId
+		if cves := getCveIds(row.Cves); len(cves) > 0 {
+			issue = strings.Join(cves, ", ")
+		}
+		bi.Issues = append(bi.Issues, branchIssue{Key: utils.GetVulnerabiltiesUniqueID(row), Fixable: len(row.FixedVersions) > 0, FixedIssue: outputwriter.FixedIssue{
+			Severity: row.Severity,
+			Type:     issueType,
+			Issue:    issue,
+			Location: fmt.Sprintf("%s:%s", row.ImpactedDependencyName, row.ImpactedDependencyVersion),
+		}})
+	}
+}
+
+func (bi *branchIssues) addLicenseIssues(issueType string, rows []formats.LicenseRow) {
+	for _, row := range rows {
+		bi.Issues = append(bi.Issues, branchIssue{Key: getUniqueLicenseKey(row), FixedIssue: outputwriter.FixedIssue{
+			Severity: row.Severity,
+			Type:     issueType,
+			Issue:    row.LicenseKey,
+			Location: fmt.Sprintf("%s:%s", row.ImpactedDependencyName, row.ImpactedDependencyVersion),
+		}})
+	}
+}
+
+func (bi *branchIssues) addSourceCodeIssues(issueType string, rows []formats.SourceCodeRow) {
+	for _, row := range rows {
+		bi.Issues = append(bi.Issues, branchIssue{Key: getSourceCodeRowKey(row), FixedIssue: outputwriter.FixedIssue{
+			Severity: row.Severity,
+			Type:     issueType,
+			Issue:    row.Finding,
+			Location: fmt.Sprintf("%s:%d", row.File, row.StartLine),
+		}})
+	}
+}
+
+func (issue *branchIssue) meetsDisplayFilters(scanPolicy *utils.Scan) bool {
+	return utils.MeetsMinSeverity(issue.Severity, scanPolicy.MinSeverity) && (!scanPolicy.FixableOnly || issue.Fixable)
+}
+
+func getCveIds(cves []formats.CveRow) (ids []string) {
+	for _, cve := range cves {
+		if cve.Id != "" {
+			ids = append(ids, cve.Id)
+		}
+	}
+	return
+}
+
+// Returns the issues of the target branch that aren't found in the source branch.
+// Licenses that aren't violations are fixed only if they aren't allowed, and vulnerabilities and security violations only if they meet the display filters,
+// the same way they're added by the pull request.
+func getFixedIssues(targetIssues, sourceIssues *branchIssues, scanPolicy *utils.Scan) (fixedIssues []outputwriter.FixedIssue) {
+	sourceKeys := datastructures.MakeSet[string]()
+	for _, issue := range sourceIssues.Issues {
+		sourceKeys.Add(issue.Type + issue.Key)
+	}
+	// Issues with several locations, such as a secret repeated in the same file, are shown once
+	fixedKeys := datastructures.MakeSet[string]()
+	for _, issue := range targetIssues.Issues {
+		if issue.Type == licenseIssueType && (len(scanPolicy.AllowedLicenses) == 0 || slices.Contains(scanPolicy.AllowedLicenses, issue.Issue)) {
+			continue
+		}
+		if (issue.Type == vulnerabilityIssueType || issue.Type == securityViolationIssueType) && !issue.meetsDisplayFilters(scanPolicy) {
+			continue
+		}
+		if sourceKeys.Exists(issue.Type+issue.Key) || fixedKeys.Exists(issue.Type+issue.Key) {
+			continue
+		}
+		fixedKeys.Add(issue.Type + issue.Key)
+		fixedIssues = append(fixedIssues, issue.FixedIssue)
+	}
+	return
+}
+
+// Returns the key of the cached issues of the target branch at its latest commit, or an empty string if the issues can't be cached.
+func getTargetBranchCacheKey(resultsCache *utils.ResultsCache, scanDetails *utils.ScanDetails) string {
+	if resultsCache == nil {
+		return ""
+	}
+	target := scanDetails.PullRequestDetails.Target
+	commit, err := scanDetails.Client().GetLatestCommit(context.Background(), target.Owner, target.Repository, target.Name)
+	if err != nil || commit.Hash == "" {
+		log.Debug("Couldn't get the latest commit of the target branch, so its audit results aren't cached:", err)
+		return ""
+	}
+	cacheKey, err := scanDetails.GetResultsCacheKey(target, commit.Hash)
+	if err != nil {
+		log.Debug("Couldn't create the cache key of the target branch audit results:", err.Error())
+		return ""
+	}
+	return cacheKey
+}
+
 // Returns all the issues found in the source branch that didn't exist in the target branch.
-func getNewlyAddedIssues(targetResults, sourceResults *audit.Results, allowedLicenses []string) (*utils.IssuesCollection, error) {
+func getNewlyAddedIssues(targetIssues *branchIssues, sourceResults *audit.Results, allowedLicenses []string) (*utils.IssuesCollection, error) {
 	var newVulnerabilitiesOrViolations []formats.VulnerabilityOrViolationRow
 	var newLicenses []formats.LicenseRow
 	var err error
 	if len(sourceResults.ExtendedScanResults.XrayResults) > 0 {
-		if newVulnerabilitiesOrViolations, newLicenses, err = createNewVulnerabilitiesRows(targetResults, sourceResults, allowedLicenses); err != nil {
+		if newVulnerabilitiesOrViolations, newLicenses, err = getNewVulnerabilitiesRows(targetIssues, sourceResults, allowedLicenses); err != nil {
 			return nil, err
 		}
 	}
 
 	var newIacs []formats.SourceCodeRow
 	if len(sourceResults.ExtendedScanResults.IacScanResults) > 0 {
-		targetIacRows := xrayutils.PrepareIacs(targetResults.ExtendedScanResults.IacScanResults)
 		sourceIacRows := xrayutils.PrepareIacs(sourceResults.ExtendedScanResults.IacScanResults)
-		newIacs = createNewSourceCodeRows(targetIacRows, sourceIacRows)
+		newIacs = getNewSourceCodeRows(targetIssues.Iacs, sourceIacRows)
 	}
 
 	var newSecrets []formats.SourceCodeRow
 	if len(sourceResults.ExtendedScanResults.SecretsScanResults) > 0 {
-		targetSecretsRows := xrayutils.PrepareIacs(targetResults.ExtendedScanResults.SecretsScanResults)
 		sourceSecretsRows := xrayutils.PrepareIacs(sourceResults.ExtendedScanResults.SecretsScanResults)
-		newSecrets = createNewSourceCodeRows(targetSecretsRows, sourceSecretsRows)
+		newSecrets = getNewSourceCodeRows(targetIssues.Secrets, sourceSecretsRows)
 	}
 
 	var newSast []formats.SourceCodeRow
-	if len(targetResults.ExtendedScanResults.SastScanResults) > 0 {
-		targetSastRows := xrayutils.PrepareSast(targetResults.ExtendedScanResults.SastScanResults)
+	if len(sourceResults.ExtendedScanResults.SastScanResults) > 0 {
 		sourceSastRows := xrayutils.PrepareSast(sourceResults.ExtendedScanResults.SastScanResults)
-		newSast = createNewSourceCodeRows(targetSastRows, sourceSastRows)
+		newSast = getNewSourceCodeRows(targetIssues.Sast, sourceSastRows)
 	}
 
 	return &utils.IssuesCollection{
@@ -282,14 +532,11 @@ func getNewlyAddedIssues(targetResults, sourceResults *audit.Results, allowedLic
 	}, nil
 }
 
-func createNewSourceCodeRows(targetResults, sourceResults []formats.SourceCodeRow) []formats.SourceCodeRow {
-	targetSourceCodeVulnerabilitiesKeys := datastructures.MakeSet[string]()
-	for _, row := range targetResults {
-		targetSourceCodeVulnerabilitiesKeys.Add(row.File + row.Snippet)
-	}
+func getNewSourceCodeRows(targetKeys []string, sourceResults []formats.SourceCodeRow) []formats.SourceCodeRow {
+	targetSourceCodeVulnerabilitiesKeys := makeKeysSet(targetKeys)
 	var addedSourceCodeVulnerabilities []formats.SourceCodeRow
 	for _, row := range sourceResults {
-		if !targetSourceCodeVulnerabilitiesKeys.Exists(row.File + row.Snippet) {
+		if !targetSourceCodeVulnerabilitiesKeys.Exists(getSourceCodeRowKey(row)) {
 			addedSourceCodeVulnerabilities = append(addedSourceCodeVulnerabilities, row)
 		}
 	}
@@ -297,96 +544,111 @@ func createNewSourceCodeRows(targetResults, sourceResults []formats.SourceCodeRo
 }
 
 // Create vulnerabilities rows. The rows should contain only the new issues added by this PR
-func createNewVulnerabilitiesRows(targetResults, sourceResults *audit.Results, allowedLicenses []string) (vulnerabilityOrViolationRows []formats.VulnerabilityOrViolationRow, licenseRows []formats.LicenseRow, err error) {
-	targetScanAggregatedResults := aggregateScanResults(targetResults.ExtendedScanResults.XrayResults)
+func getNewVulnerabilitiesRows(targetIssues *branchIssues, sourceResults *audit.Results, allowedLicenses []string) (vulnerabilityOrViolationRows []formats.VulnerabilityOrViolationRow, licenseRows []formats.LicenseRow, err error) {
 	sourceScanAggregatedResults := aggregateScanResults(sourceResults.ExtendedScanResults.XrayResults)
 
 	if len(sourceScanAggregatedResults.Violations) > 0 {
-		return getNewViolations(&targetScanAggregatedResults, &sourceScanAggregatedResults, sourceResults)
+		return getNewViolations(targetIssues, &sourceScanAggregatedResults, sourceResults)
 	}
 	if len(sourceScanAggregatedResults.Vulnerabilities) > 0 {
-		if vulnerabilityOrViolationRows, err = getNewSecurityVulnerabilities(&targetScanAggregatedResults, &sourceScanAggregatedResults, sourceResults); err != nil {
+		if vulnerabilityOrViolationRows, err = getNewSecurityVulnerabilities(targetIssues, &sourceScanAggregatedResults, sourceResults); err != nil {
 			return
 		}
 	}
 	var newLicenses []formats.LicenseRow
-	if newLicenses, err = getNewLicenseRows(&targetScanAggregatedResults, &sourceScanAggregatedResults); err != nil {
+	if newLicenses, err = getNewLicenseRows(targetIssues, &sourceScanAggregatedResults); err != nil {
 		return
 	}
 	licenseRows = getViolatedLicenses(allowedLicenses, newLicenses)
 	return
 }
 
-func getNewSecurityVulnerabilities(targetScan, sourceScan *services.ScanResponse, auditResults *audit.Results) (newVulnerabilitiesRows []formats.VulnerabilityOrViolationRow, err error) {
-	targetVulnerabilitiesRows, err := xrayutils.PrepareVulnerabilities(targetScan.Vulnerabilities, auditResults.ExtendedScanResults, auditResults.IsMultipleRootProject, true)
-	if err != nil {
-		return newVulnerabilitiesRows, err
-	}
+func getNewSecurityVulnerabilities(targetIssues *branchIssues, sourceScan *services.ScanResponse, auditResults *audit.Results) (newVulnerabilitiesRows []formats.VulnerabilityOrViolationRow, err error) {
 	sourceVulnerabilitiesRows, err := xrayutils.PrepareVulnerabilities(sourceScan.Vulnerabilities, auditResults.ExtendedScanResults, auditResults.IsMultipleRootProject, true)
 	if err != nil {
 		return newVulnerabilitiesRows, err
 	}
-	newVulnerabilitiesRows = getUniqueVulnerabilityOrViolationRows(targetVulnerabilitiesRows, sourceVulnerabilitiesRows)
+	newVulnerabilitiesRows = getUniqueVulnerabilityOrViolationRows(targetIssues.Vulnerabilities, sourceVulnerabilitiesRows)
 	return
 }
 
-func getUniqueVulnerabilityOrViolationRows(targetRows, sourceRows []formats.VulnerabilityOrViolationRow) []formats.VulnerabilityOrViolationRow {
-	existingRows := make(map[string]formats.VulnerabilityOrViolationRow)
+func getUniqueVulnerabilityOrViolationRows(targetKeys []string, sourceRows []formats.VulnerabilityOrViolationRow) []formats.VulnerabilityOrViolationRow {
+	existingRows := makeKeysSet(targetKeys)
 	var newRows []formats.VulnerabilityOrViolationRow
-	for _, row := range targetRows {
-		existingRows[utils.GetVulnerabiltiesUniqueID(row)] = row
-	}
 	for _, row := range sourceRows {
-		if _, exists := existingRows[utils.GetVulnerabiltiesUniqueID(row)]; !exists {
+		if !existingRows.Exists(utils.GetVulnerabiltiesUniqueID(row)) {
 			newRows = append(newRows, row)
 		}
 	}
 	return newRows
 }
 
-func getNewViolations(targetScan, sourceScan *services.ScanResponse, auditResults *audit.Results) (newSecurityViolationsRows []formats.VulnerabilityOrViolationRow, newLicenseViolationsRows []formats.LicenseRow, err error) {
-	targetSecurityViolationsRows, targetLicenseViolationsRows, _, err := xrayutils.PrepareViolations(targetScan.Violations, auditResults.ExtendedScanResults, auditResults.IsMultipleRootProject, true)
-	if err != nil {
-		return
-	}
+func getNewViolations(targetIssues *branchIssues, sourceScan *services.ScanResponse, auditResults *audit.Results) (newSecurityViolationsRows []formats.VulnerabilityOrViolationRow, newLicenseViolationsRows []formats.LicenseRow, err error) {
 	sourceSecurityViolationsRows, sourceLicenseViolationsRows, _, err := xrayutils.PrepareViolations(sourceScan.Violations, auditResults.ExtendedScanResults, auditResults.IsMultipleRootProject, true)
 	if err != nil {
 		return
 	}
-	newSecurityViolationsRows = getUniqueVulnerabilityOrViolationRows(targetSecurityViolationsRows, sourceSecurityViolationsRows)
+	newSecurityViolationsRows = getUniqueVulnerabilityOrViolationRows(targetIssues.SecurityViolations, sourceSecurityViolationsRows)
 	if len(sourceLicenseViolationsRows) > 0 {
-		newLicenseViolationsRows = getUniqueLicenseRows(targetLicenseViolationsRows, sourceLicenseViolationsRows)
+		newLicenseViolationsRows = getUniqueLicenseRows(targetIssues.LicenseViolations, sourceLicenseViolationsRows)
 	}
 	return
 }
 
-func getNewLicenseRows(targetScan, sourceScan *services.ScanResponse) (newLicenses []formats.LicenseRow, err error) {
-	targetLicenses, err := xrayutils.PrepareLicenses(targetScan.Licenses)
-	if err != nil {
-		return
-	}
+func getNewLicenseRows(targetIssues *branchIssues, sourceScan *services.ScanResponse) (newLicenses []formats.LicenseRow, err error) {
 	sourceLicenses, err := xrayutils.PrepareLicenses(sourceScan.Licenses)
 	if err != nil {
 		return
 	}
-	newLicenses = getUniqueLicenseRows(targetLicenses, sourceLicenses)
+	newLicenses = getUniqueLicenseRows(targetIssues.Licenses, sourceLicenses)
 	return
 }
 
-func getUniqueLicenseRows(targetRows, sourceRows []formats.LicenseRow) []formats.LicenseRow {
-	existingLicenses := make(map[string]formats.LicenseRow)
+func getUniqueLicenseRows(targetKeys []string, sourceRows []formats.LicenseRow) []formats.LicenseRow {
+	existingLicenses := makeKeysSet(targetKeys)
 	var newLicenses []formats.LicenseRow
-	for _, row := range targetRows {
-		existingLicenses[getUniqueLicenseKey(row)] = row
-	}
 	for _, row := range sourceRows {
-		if _, exists := existingLicenses[getUniqueLicenseKey(row)]; !exists {
+		if !existingLicenses.Exists(getUniqueLicenseKey(row)) {
 			newLicenses = append(newLicenses, row)
 		}
 	}
 	return newLicenses
 }
 
+func getVulnerabilityOrViolationRowsKeys(rows []formats.VulnerabilityOrViolationRow) (keys []string) {
+	for _, row := range rows {
+		keys = append(keys, utils.GetVulnerabiltiesUniqueID(row))
+	}
+	return
+}
+
+func getLicenseRowsKeys(rows []formats.LicenseRow) (keys []string) {
+	for _, row := range rows {
+		keys = append(keys, getUniqueLicenseKey(row))
+	}
+	return
+}
+
+func getSourceCodeRowsKeys(rows []formats.SourceCodeRow) (keys []string) {
+	for _, row := range rows {
+		keys = append(keys, getSourceCodeRowKey(row))
+	}
+	return
+}
+
+func getSourceCodeRowKey(row formats.SourceCodeRow) string {
+	hash := sha256.Sum256([]byte(row.File + row.Snippet))
+	return hex.EncodeToString(hash[:])
+}
+
+func makeKeysSet(keys []string) *datastructures.Set[string] {
+	keysSet := datastructures.MakeSet[string]()
+	for _, key := range keys {
+		keysSet.Add(key)
+	}
+	return keysSet
+}
+
 func getUniqueLicenseKey(license formats.LicenseRow) string {
 	return license.LicenseKey + license.ImpactedDependencyName + license.ImpactedDependencyType
 }
@@ -399,6 +661,7 @@ func aggregateScanResults(scanResults []services.ScanResponse) services.ScanResp
 	for _, scanResult := range scanResults {
 		aggregateResults.Violations = append(aggregateResults.Violations, scanResult.Violations...)
 		aggregateResults.Vulnerabilities = append(aggregateResults.Vulnerabilities, scanResult.Vulnerabilities...)
+		aggregateResults.Licenses = append(aggregateResults.Licenses, scanResult.Licenses...)
 	}
 	return aggregateResults
 }
@@ -440,41 +703,77 @@ func getViolatedLicenses(allowedLicenses []string, licenses []formats.LicenseRow
 }
 
 func createPullRequestComment(issues *utils.IssuesCollection, writer outputwriter.OutputWriter) string {
+	expiredIgnoreRules := writer.ExpiredIgnoreRulesContent(toExpiredIgnoreRules(issues.ExpiredIgnoreRules))
+	// The issues fixed by the pull request are shown whether it adds issues or not
+	fixedIssues := writer.FixedIssuesContent(issues.FixedIssues)
 	if !issues.IssuesExists() {
-		return writer.NoVulnerabilitiesTitle() + writer.UntitledForJasMsg() + writer.Footer()
+		return writer.NoVulnerabilitiesTitle() + fixedIssues + expiredIgnoreRules + writer.UntitledForJasMsg() + writer.Footer()
 	}
 	comment := strings.Builder{}
 	comment.WriteString(writer.VulnerabilitiesTitle(true))
 	comment.WriteString(writer.VulnerabilitiesContent(issues.Vulnerabilities))
 	comment.WriteString(writer.LicensesContent(issues.Licenses))
+	// The source code issues are summarized in the comment as well, since their review comments may not be added or read
+	comment.WriteString(writer.SecretsTableContent(issues.Secrets))
+	comment.WriteString(writer.IacTableContent(issues.Iacs))
+	comment.WriteString(writer.SastTableContent(issues.Sast))
+	comment.WriteString(fixedIssues)
+	comment.WriteString(expiredIgnoreRules)
 	comment.WriteString(writer.UntitledForJasMsg())
 	comment.WriteString(writer.Footer())
 
 	return comment.String()
 }
 
-func deleteExistingPullRequestComment(repository *utils.Repository, client vcsclient.VcsClient) error {
-	log.Debug("Looking for an existing Frogbot pull request comment. Deleting it if it exists...")
+func toExpiredIgnoreRules(rules []utils.IgnoreRule) (expiredRules []outputwriter.ExpiredIgnoreRule) {
+	for i := range rules {
+		expiredRules = append(expiredRules, outputwriter.ExpiredIgnoreRule{Finding: rules[i].Finding(), Reason: rules[i].Reason, ExpiresOn: rules[i].ExpiresOn})
+	}
+	return
+}
+
+// Updates the content of the existing Frogbot pull request comment, so that the comment and its position in the conversation are kept between scans.
+// If the comment doesn't exist, or there is no editor for the Git provider, the previous comment is deleted and a new comment is added.
+func addOrUpdatePullRequestComment(repository *utils.Repository, client vcsclient.VcsClient, editor pullRequestCommentEditor, content string) error {
+	log.Debug("Looking for an existing Frogbot pull request comment. Updating it if it exists...")
+	commentID, err := getExistingPullRequestCommentID(repository, client)
+	if err != nil {
+		return err
+	}
+	prDetails := repository.PullRequestDetails
+	if commentID != frogbotCommentNotFound {
+		if editor != nil {
+			editErr := editor.EditPullRequestComment(context.Background(), prDetails.Target.Owner, prDetails.Target.Repository, int(prDetails.ID), commentID, content)
+			if editErr == nil {
+				log.Debug("Updated the Frogbot pull request comment with the id:", commentID)
+				return nil
+			}
+			log.Debug("Couldn't update the Frogbot pull request comment, replacing it with a new comment:", editErr.Error())
+		}
+		if err = client.DeletePullRequestComment(context.Background(), prDetails.Target.Owner, prDetails.Target.Repository, int(prDetails.ID), commentID); err != nil {
+			return err
+		}
+	}
+	if err = client.AddPullRequestComment(context.Background(), repository.RepoOwner, repository.RepoName, content, int(prDetails.ID)); err != nil {
+		return errors.New("couldn't add pull request comment: " + err.Error())
+	}
+	return nil
+}
+
+// Returns the id of the existing Frogbot pull request comment, or frogbotCommentNotFound if it doesn't exist
+func getExistingPullRequestCommentID(repository *utils.Repository, client vcsclient.VcsClient) (int, error) {
 	prDetails := repository.PullRequestDetails
 	comments, err := utils.GetSortedPullRequestComments(client, prDetails.Target.Owner, prDetails.Target.Repository, int(prDetails.ID))
 	if err != nil {
-		return fmt.Errorf(
+		return frogbotCommentNotFound, fmt.Errorf(
 			"failed to get comments. the following details were used in order to fetch the comments: <%s/%s> pull request #%d. the error received: %s",
 			repository.RepoOwner, repository.RepoName, int(repository.PullRequestDetails.ID), err.Error())
 	}
-
-	commentID := frogbotCommentNotFound
 	for _, comment := range comments {
 		if repository.OutputWriter.IsFrogbotResultComment(comment.Content) {
 			log.Debug("Found previous Frogbot comment with the id:", comment.ID)
-			commentID = int(comment.ID)
-			break
+			return int(comment.ID), nil
 		}
 	}
-
-	if commentID != frogbotCommentNotFound {
-		err = client.DeletePullRequestComment(context.Background(), prDetails.Target.Owner, prDetails.Target.Repository, int(prDetails.ID), commentID)
-	}
-
-	return err
+	return frogbotCommentNotFound, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/xray/services"
 	"github.com/owenrumney/go-sarif/v2/sarif"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -41,6 +42,12 @@ const (
 	testTargetBranchName             = "master"
 )
 
+func TestMain(m *testing.M) {
+	// The branches of the pull requests are audited in subprocesses of the test binary
+	utils.RunAuditSubprocessIfRequested()
+	os.Exit(m.Run())
+}
+
 func TestCreateVulnerabilitiesRows(t *testing.T) {
 	// Previous scan with only one violation - XRAY-1
 	previousScan := services.ScanResponse{
@@ -88,12 +95,10 @@ func TestCreateVulnerabilitiesRows(t *testing.T) {
 		},
 	}
 
-	// Run createNewIssuesRows and make sure that only the XRAY-2 violation exists in the results
-	securityViolationsRows, licenseViolations, err := createNewVulnerabilitiesRows(
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}},
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}},
-		nil,
-	)
+	// Run getNewVulnerabilitiesRows and make sure that only the XRAY-2 violation exists in the results
+	targetIssues, err := getBranchIssues(&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}})
+	require.NoError(t, err)
+	securityViolationsRows, licenseViolations, err := getNewVulnerabilitiesRows(targetIssues, &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, licenseViolations, 1)
 	assert.Len(t, securityViolationsRows, 2)
@@ -165,12 +170,10 @@ func TestCreateVulnerabilitiesRowsCaseNoPrevViolations(t *testing.T) {
 		},
 	}
 
-	// Run createNewIssuesRows and expect both XRAY-1 and XRAY-2 violation in the results
-	vulnerabilities, licenses, err := createNewVulnerabilitiesRows(
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}},
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}},
-		[]string{},
-	)
+	// Run getNewVulnerabilitiesRows and expect both XRAY-1 and XRAY-2 violation in the results
+	targetIssues, err := getBranchIssues(&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}})
+	require.NoError(t, err)
+	vulnerabilities, licenses, err := getNewVulnerabilitiesRows(targetIssues, &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}}, []string{})
 	assert.NoError(t, err)
 	assert.Len(t, licenses, 1)
 	assert.Len(t, vulnerabilities, 2)
@@ -210,12 +213,10 @@ func TestGetNewViolationsCaseNoNewViolations(t *testing.T) {
 		Violations: []services.Violation{},
 	}
 
-	// Run createNewIssuesRows and expect no violations in the results
-	securityViolations, licenseViolations, err := createNewVulnerabilitiesRows(
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}},
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}},
-		[]string{"MIT"},
-	)
+	// Run getNewVulnerabilitiesRows and expect no violations in the results
+	targetIssues, err := getBranchIssues(&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}})
+	require.NoError(t, err)
+	securityViolations, licenseViolations, err := getNewVulnerabilitiesRows(targetIssues, &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}}, []string{"MIT"})
 	assert.NoError(t, err)
 	assert.Len(t, securityViolations, 0)
 	assert.Len(t, licenseViolations, 0)
@@ -343,47 +344,45 @@ func TestGetNewVulnerabilities(t *testing.T) {
 		},
 	}
 
-	// Run createNewIssuesRows and make sure that only the XRAY-2 vulnerability exists in the results
-	vulnerabilities, licenses, err := createNewVulnerabilitiesRows(
-		&audit.Results{
-			ExtendedScanResults: &xrayutils.ExtendedScanResults{
-				XrayResults:    []services.ScanResponse{previousScan},
-				EntitledForJas: true,
-				ApplicabilityScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").
-					WithResults([]*sarif.Result{
-						sarif.NewRuleResult("applic_CVE-2023-4321").
-							WithLocations([]*sarif.Location{
-								sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().
-									WithArtifactLocation(sarif.NewArtifactLocation().
-										WithUri("file1")).
-									WithRegion(sarif.NewRegion().
-										WithStartLine(1).
-										WithStartColumn(10))),
-							}),
-					}),
-				},
-			},
-		},
-		&audit.Results{
-			ExtendedScanResults: &xrayutils.ExtendedScanResults{
-				XrayResults:    []services.ScanResponse{currentScan},
-				EntitledForJas: true,
-				ApplicabilityScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").
-					WithResults([]*sarif.Result{
-						sarif.NewRuleResult("applic_CVE-2023-4321").
-							WithLocations([]*sarif.Location{sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().
+	// Run getNewVulnerabilitiesRows and make sure that only the XRAY-2 vulnerability exists in the results
+	targetIssues, err := getBranchIssues(&audit.Results{
+		ExtendedScanResults: &xrayutils.ExtendedScanResults{
+			XrayResults:    []services.ScanResponse{previousScan},
+			EntitledForJas: true,
+			ApplicabilityScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").
+				WithResults([]*sarif.Result{
+					sarif.NewRuleResult("applic_CVE-2023-4321").
+						WithLocations([]*sarif.Location{
+							sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().
 								WithArtifactLocation(sarif.NewArtifactLocation().
 									WithUri("file1")).
 								WithRegion(sarif.NewRegion().
 									WithStartLine(1).
 									WithStartColumn(10))),
-							}),
-					}),
-				},
+						}),
+				}),
+			},
+		},
+	})
+	require.NoError(t, err)
+	vulnerabilities, licenses, err := getNewVulnerabilitiesRows(targetIssues, &audit.Results{
+		ExtendedScanResults: &xrayutils.ExtendedScanResults{
+			XrayResults:    []services.ScanResponse{currentScan},
+			EntitledForJas: true,
+			ApplicabilityScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").
+				WithResults([]*sarif.Result{
+					sarif.NewRuleResult("applic_CVE-2023-4321").
+						WithLocations([]*sarif.Location{sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().
+							WithArtifactLocation(sarif.NewArtifactLocation().
+								WithUri("file1")).
+							WithRegion(sarif.NewRegion().
+								WithStartLine(1).
+								WithStartColumn(10))),
+						}),
+				}),
 			},
 		},
-		nil,
-	)
+	}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, vulnerabilities, 2)
 	assert.Len(t, licenses, 0)
@@ -437,12 +436,10 @@ func TestGetNewVulnerabilitiesCaseNoPrevVulnerabilities(t *testing.T) {
 		},
 	}
 
-	// Run createNewIssuesRows and expect both XRAY-1 and XRAY-2 vulnerability in the results
-	vulnerabilities, licenses, err := createNewVulnerabilitiesRows(
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}},
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}},
-		nil,
-	)
+	// Run getNewVulnerabilitiesRows and expect both XRAY-1 and XRAY-2 vulnerability in the results
+	targetIssues, err := getBranchIssues(&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}})
+	require.NoError(t, err)
+	vulnerabilities, licenses, err := getNewVulnerabilitiesRows(targetIssues, &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, vulnerabilities, 2)
 	assert.Len(t, licenses, 0)
@@ -473,12 +470,10 @@ func TestGetNewVulnerabilitiesCaseNoNewVulnerabilities(t *testing.T) {
 		Vulnerabilities: []services.Vulnerability{},
 	}
 
-	// Run createNewIssuesRows and expect no vulnerability in the results
-	vulnerabilities, licenses, err := createNewVulnerabilitiesRows(
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}},
-		&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}},
-		nil,
-	)
+	// Run getNewVulnerabilitiesRows and expect no vulnerability in the results
+	targetIssues, err := getBranchIssues(&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{previousScan}}})
+	require.NoError(t, err)
+	vulnerabilities, licenses, err := getNewVulnerabilitiesRows(targetIssues, &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{currentScan}}}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, vulnerabilities, 0)
 	assert.Len(t, licenses, 0)
@@ -637,6 +632,58 @@ func TestCreatePullRequestComment(t *testing.T) {
 	assert.Equal(t, expectedMessage, message)
 }
 
+func TestCreatePullRequestCommentWithExpiredIgnoreRules(t *testing.T) {
+	expiredRules := []utils.IgnoreRule{{Cve: "CVE-2022-26652", Reason: "Accepted risk", ExpiresOn: "2024-01-01"}}
+	expiredRulesContent := "| CVE-2022-26652 | Accepted risk | 2024-01-01 |"
+	writerOutput := &outputwriter.StandardOutput{}
+	// The expired rules are called out whether the scan found issues or not
+	message := createPullRequestComment(&utils.IssuesCollection{ExpiredIgnoreRules: expiredRules}, writerOutput)
+	assert.Contains(t, message, expiredRulesContent)
+	vulnerabilities := []formats.VulnerabilityOrViolationRow{{Cves: []formats.CveRow{{Id: "CVE-2022-26652"}}}}
+	message = createPullRequestComment(&utils.IssuesCollection{Vulnerabilities: vulnerabilities, ExpiredIgnoreRules: expiredRules}, writerOutput)
+	assert.Contains(t, message, expiredRulesContent)
+	message = createPullRequestComment(&utils.IssuesCollection{Vulnerabilities: vulnerabilities}, writerOutput)
+	assert.NotContains(t, message, "Expired Ignore Rules")
+}
+
+func TestCreatePullRequestCommentWithFixedIssues(t *testing.T) {
+	fixedIssues := []outputwriter.FixedIssue{{Severity: "High", Type: vulnerabilityIssueType, Issue: "CVE-2022-26652", Location: "lodash:4.17.20"}}
+	fixedIssuesContent := "| Vulnerability | CVE-2022-26652 | lodash:4.17.20 |"
+	writerOutput := &outputwriter.StandardOutput{}
+	// The fixed issues are shown whether the pull request adds issues or not, and don't count as issues of the pull request
+	message := createPullRequestComment(&utils.IssuesCollection{FixedIssues: fixedIssues}, writerOutput)
+	assert.Contains(t, message, writerOutput.NoVulnerabilitiesTitle())
+	assert.Contains(t, message, fixedIssuesContent)
+	vulnerabilities := []formats.VulnerabilityOrViolationRow{{Cves: []formats.CveRow{{Id: "CVE-2023-0001"}}}}
+	message = createPullRequestComment(&utils.IssuesCollection{Vulnerabilities: vulnerabilities, FixedIssues: fixedIssues}, writerOutput)
+	assert.Contains(t, message, writerOutput.VulnerabilitiesTitle(true))
+	assert.Contains(t, message, fixedIssuesContent)
+	message = createPullRequestComment(&utils.IssuesCollection{Vulnerabilities: vulnerabilities}, writerOutput)
+	assert.NotContains(t, message, "Fixed by this PR")
+}
+
+func TestCreatePullRequestCommentWithSourceCodeIssues(t *testing.T) {
+	sourceCodeIssue := func(file, snippet, finding string) formats.SourceCodeRow {
+		return formats.SourceCodeRow{SeverityDetails: formats.SeverityDetails{Severity: "High"}, Location: formats.Location{File: file, StartLine: 1, StartColumn: 2, Snippet: snippet}, Finding: finding}
+	}
+	issues := &utils.IssuesCollection{
+		Secrets: []formats.SourceCodeRow{sourceCodeIssue("config.js", "tok************", "Hardcoded token")},
+		Iacs:    []formats.SourceCodeRow{sourceCodeIssue("main.tf", "Missing auth", "Missing auth")},
+		Sast:    []formats.SourceCodeRow{sourceCodeIssue("app.js", "eval(input)", "Code injection"), sourceCodeIssue("db.js", "query(input)", "SQL injection")},
+	}
+	for _, writer := range []outputwriter.OutputWriter{&outputwriter.StandardOutput{}, &outputwriter.SimplifiedOutput{}} {
+		// Source code issues alone are reported as issues
+		message := createPullRequestComment(issues, writer)
+		assert.True(t, writer.IsFrogbotResultComment(message))
+		assert.Contains(t, message, writer.VulnerabilitiesTitle(true))
+		assert.Contains(t, message, "## 🔑 Secrets (1)")
+		assert.Contains(t, message, "| config.js | 1:2 | `tok************` |")
+		assert.Contains(t, message, "## 🛠️ Infrastructure as Code (1)")
+		assert.Contains(t, message, "## 🎯 Static Application Security Testing (SAST) (2)")
+		assert.Contains(t, message, "| db.js | 1:2 | SQL injection |")
+	}
+}
+
 func TestScanPullRequest(t *testing.T) {
 	tests := []struct {
 		testName             string
@@ -966,10 +1013,15 @@ func TestCreateNewIacRows(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			targetIacRows := xrayutils.PrepareIacs([]*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.targetIacResults)})
-			sourceIacRows := xrayutils.PrepareIacs([]*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.sourceIacResults)})
-			addedIacVulnerabilities := createNewSourceCodeRows(targetIacRows, sourceIacRows)
-			assert.ElementsMatch(t, tc.expectedAddedIacVulnerabilities, addedIacVulnerabilities)
+			targetIssues, err := getBranchIssues(&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{
+				IacScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.targetIacResults)},
+			}})
+			require.NoError(t, err)
+			addedIssues, err := getNewlyAddedIssues(targetIssues, &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{
+				IacScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.sourceIacResults)},
+			}}, nil)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectedAddedIacVulnerabilities, addedIssues.Iacs)
 		})
 	}
 }
@@ -1059,45 +1111,305 @@ func TestCreateNewSecretRows(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			targetSecretsRows := xrayutils.PrepareSecrets([]*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.targetSecretsResults)})
-			sourceSecretsRows := xrayutils.PrepareSecrets([]*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.sourceSecretsResults)})
-			addedSecretsVulnerabilities := createNewSourceCodeRows(targetSecretsRows, sourceSecretsRows)
-			assert.ElementsMatch(t, tc.expectedAddedSecretsVulnerabilities, addedSecretsVulnerabilities)
+			targetIssues, err := getBranchIssues(&audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{
+				SecretsScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.targetSecretsResults)},
+			}})
+			require.NoError(t, err)
+			addedIssues, err := getNewlyAddedIssues(targetIssues, &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{
+				SecretsScanResults: []*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(tc.sourceSecretsResults)},
+			}}, nil)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectedAddedSecretsVulnerabilities, addedIssues.Secrets)
 		})
 	}
 }
 
-func TestDeletePreviousPullRequestMessages(t *testing.T) {
-	repository := &utils.Repository{
-		Params: utils.Params{
-			Git: utils.Git{
-				PullRequestDetails: vcsclient.PullRequestInfo{Target: vcsclient.BranchInfo{
-					Repository: "repo",
-					Owner:      "owner",
-				}, ID: 17},
-			},
+// Stores the cached results in memory
+type memoryResultsCacheStorage map[string][]byte
+
+func (s memoryResultsCacheStorage) Read(key string) ([]byte, bool, error) {
+	content, found := s[key]
+	return content, found, nil
+}
+
+func (s memoryResultsCacheStorage) Write(key string, content []byte) error {
+	s[key] = content
+	return nil
+}
+
+func TestGetNewlyAddedIssuesWithCachedTargetIssues(t *testing.T) {
+	secretsRun := func(files ...string) []*sarif.Run {
+		var results []*sarif.Result
+		for _, file := range files {
+			results = append(results, sarif.NewRuleResult("").WithMessage(sarif.NewTextMessage("Secret")).WithLocations([]*sarif.Location{
+				sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().
+					WithArtifactLocation(sarif.NewArtifactLocation().WithUri(file)).
+					WithRegion(sarif.NewRegion().WithStartLine(1).WithSnippet(sarif.NewArtifactContent().WithText("Sensitive information in " + file)))),
+			}))
+		}
+		return []*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(results)}
+	}
+	vulnerabilities := func(issueIds ...string) []services.ScanResponse {
+		var scanVulnerabilities []services.Vulnerability
+		for _, issueId := range issueIds {
+			scanVulnerabilities = append(scanVulnerabilities, services.Vulnerability{IssueId: issueId, Severity: "High", Components: map[string]services.Component{"component-A:1.0.0": {}}})
+		}
+		return []services.ScanResponse{{Vulnerabilities: scanVulnerabilities}}
+	}
+	targetResults := &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: vulnerabilities("XRAY-1"), SecretsScanResults: secretsRun("file1")}}
+	sourceResults := &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: vulnerabilities("XRAY-1", "XRAY-2"), SecretsScanResults: secretsRun("file1", "file2")}}
+
+	// The secrets of the target branch aren't cached
+	storage := memoryResultsCacheStorage{}
+	resultsCache := utils.NewResultsCache(storage)
+	targetIssues, err := getBranchIssues(targetResults)
+	require.NoError(t, err)
+	resultsCache.Put("key", targetIssues)
+	require.Contains(t, storage, "key")
+	assert.NotContains(t, string(storage["key"]), "Sensitive information")
+
+	cachedIssues := &branchIssues{}
+	require.True(t, resultsCache.Get("key", cachedIssues))
+	assert.Equal(t, targetIssues, cachedIssues)
+	newIssues, err := getNewlyAddedIssues(cachedIssues, sourceResults, nil)
+	require.NoError(t, err)
+	require.Len(t, newIssues.Vulnerabilities, 1)
+	assert.Equal(t, "XRAY-2", newIssues.Vulnerabilities[0].IssueId)
+	require.Len(t, newIssues.Secrets, 1)
+	assert.Equal(t, "file2", newIssues.Secrets[0].File)
+}
+
+func TestGetFixedIssues(t *testing.T) {
+	secretsRun := func(files ...string) []*sarif.Run {
+		var results []*sarif.Result
+		for _, file := range files {
+			results = append(results, sarif.NewRuleResult("").WithMessage(sarif.NewTextMessage("Secret")).WithLocations([]*sarif.Location{
+				sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().
+					WithArtifactLocation(sarif.NewArtifactLocation().WithUri(file)).
+					WithRegion(sarif.NewRegion().WithStartLine(1).WithSnippet(sarif.NewArtifactContent().WithText("Sensitive information in " + file)))),
+			}))
+		}
+		return []*sarif.Run{sarif.NewRunWithInformationURI("", "").WithResults(results)}
+	}
+	branchResults := func(issueIds, licenseKeys []string, secretFiles ...string) *audit.Results {
+		scanResponse := services.ScanResponse{}
+		for _, issueId := range issueIds {
+			scanResponse.Vulnerabilities = append(scanResponse.Vulnerabilities, services.Vulnerability{IssueId: issueId, Severity: "High", Components: map[string]services.Component{"npm://lodash:4.17.20": {}}})
+		}
+		for _, licenseKey := range licenseKeys {
+			scanResponse.Licenses = append(scanResponse.Licenses, services.License{Key: licenseKey, Components: map[string]services.Component{"npm://lodash:4.17.20": {}}})
+		}
+		return &audit.Results{ExtendedScanResults: &xrayutils.ExtendedScanResults{XrayResults: []services.ScanResponse{scanResponse}, SecretsScanResults: secretsRun(secretFiles...)}}
+	}
+	fixedVulnerability := outputwriter.FixedIssue{Severity: "High", Type: vulnerabilityIssueType, Issue: "XRAY-2", Location: "lodash:4.17.20"}
+	fixedLicense := outputwriter.FixedIssue{Type: licenseIssueType, Issue: "GPL-3.0", Location: "lodash:4.17.20"}
+	fixedSecret := outputwriter.FixedIssue{Severity: "Medium", Type: secretIssueType, Issue: "Secret", Location: "file2:1"}
+
+	testCases := []struct {
+		name          string
+		targetResults *audit.Results
+		sourceResults *audit.Results
+		scanPolicy    utils.Scan
+		expectedFixed []outputwriter.FixedIssue
+	}{
+		{
+			name:          "Issues removed by the pull request",
+			targetResults: branchResults([]string{"XRAY-1", "XRAY-2"}, []string{"GPL-3.0"}, "file1", "file2"),
+			sourceResults: branchResults([]string{"XRAY-1"}, nil, "file1"),
+			expectedFixed: []outputwriter.FixedIssue{fixedVulnerability, fixedSecret},
+		},
+		{
+			name:          "Licenses that aren't allowed",
+			targetResults: branchResults(nil, []string{"GPL-3.0", "MIT", "Apache-2.0"}),
+			sourceResults: branchResults(nil, []string{"Apache-2.0"}),
+			scanPolicy:    utils.Scan{AllowedLicenses: []string{"MIT", "Apache-2.0"}},
+			expectedFixed: []outputwriter.FixedIssue{fixedLicense},
 		},
-		OutputWriter: &outputwriter.StandardOutput{},
+		{
+			name:          "Vulnerabilities below the minimum severity aren't shown",
+			targetResults: branchResults([]string{"XRAY-1", "XRAY-2"}, nil, "file1", "file2"),
+			sourceResults: branchResults([]string{"XRAY-1"}, nil, "file1"),
+			scanPolicy:    utils.Scan{MinSeverity: "Critical"},
+			expectedFixed: []outputwriter.FixedIssue{fixedSecret},
+		},
+		{
+			name:          "Vulnerabilities without a fix version aren't shown",
+			targetResults: branchResults([]string{"XRAY-2"}, nil),
+			sourceResults: branchResults(nil, nil),
+			scanPolicy:    utils.Scan{FixableOnly: true},
+		},
+		{
+			name:          "Issues repeated in several locations are shown once",
+			targetResults: branchResults([]string{"XRAY-2"}, nil, "file2", "file2"),
+			sourceResults: branchResults(nil, nil),
+			expectedFixed: []outputwriter.FixedIssue{fixedVulnerability, fixedSecret},
+		},
+		{
+			name:          "No issues removed",
+			targetResults: branchResults([]string{"XRAY-1"}, nil, "file1"),
+			sourceResults: branchResults([]string{"XRAY-1", "XRAY-2"}, nil, "file1", "file2"),
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			targetIssues, err := getBranchIssues(test.targetResults)
+			require.NoError(t, err)
+			sourceIssues, err := getBranchIssues(test.sourceResults)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expectedFixed, getFixedIssues(targetIssues, sourceIssues, &test.scanPolicy))
+		})
+	}
+}
+
+func TestApplyScanPolicy(t *testing.T) {
+	vulnerability := func(severity string, fixedVersions ...string) formats.VulnerabilityOrViolationRow {
+		return formats.VulnerabilityOrViolationRow{
+			ImpactedDependencyDetails: formats.ImpactedDependencyDetails{SeverityDetails: formats.SeverityDetails{Severity: severity}},
+			FixedVersions:             fixedVersions,
+		}
 	}
+	failOnSecurityIssues, allIssues := true, false
+	testCases := []struct {
+		name              string
+		scanPolicy        utils.Scan
+		vulnerabilities   []formats.VulnerabilityOrViolationRow
+		expectedFailTask  bool
+		expectedDisplayed []formats.VulnerabilityOrViolationRow
+	}{
+		{
+			name:              "Fails only on the issues shown by default",
+			scanPolicy:        utils.Scan{MinSeverity: "High"},
+			vulnerabilities:   []formats.VulnerabilityOrViolationRow{vulnerability("Medium")},
+			expectedDisplayed: nil,
+		},
+		{
+			name:              "Fail severity lower than the display severity",
+			scanPolicy:        utils.Scan{MinSeverity: "High", FailPolicy: utils.FailPolicy{MinSeverity: "Low"}},
+			vulnerabilities:   []formats.VulnerabilityOrViolationRow{vulnerability("Medium"), vulnerability("Critical")},
+			expectedFailTask:  true,
+			expectedDisplayed: []formats.VulnerabilityOrViolationRow{vulnerability("Critical")},
+		},
+		{
+			name:             "Fails on issues without a fix version that aren't shown",
+			scanPolicy:       utils.Scan{FixableOnly: true, FailPolicy: utils.FailPolicy{FixableOnly: &allIssues}},
+			vulnerabilities:  []formats.VulnerabilityOrViolationRow{vulnerability("High")},
+			expectedFailTask: true,
+		},
+		{
+			name:              "Fail severity higher than the display severity",
+			scanPolicy:        utils.Scan{MinSeverity: "Low", FailPolicy: utils.FailPolicy{MinSeverity: "Critical"}},
+			vulnerabilities:   []formats.VulnerabilityOrViolationRow{vulnerability("Medium", "1.0.1")},
+			expectedDisplayed: []formats.VulnerabilityOrViolationRow{vulnerability("Medium", "1.0.1")},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			test.scanPolicy.FailOnSecurityIssues = &failOnSecurityIssues
+			issues := &utils.IssuesCollection{Vulnerabilities: test.vulnerabilities}
+			assert.Equal(t, test.expectedFailTask, applyScanPolicy(&test.scanPolicy, issues, false))
+			assert.Equal(t, test.expectedDisplayed, issues.Vulnerabilities)
+		})
+	}
+}
+
+func TestGetTargetBranchCacheKey(t *testing.T) {
 	client := CreateMockVcsClient(t)
+	target := vcsclient.BranchInfo{Name: "master", Repository: "frogbot", Owner: "jfrog"}
+	scanDetails := utils.NewScanDetails(client, &coreconfig.ServerDetails{}, &utils.Git{PullRequestDetails: vcsclient.PullRequestInfo{Target: target}}).
+		SetProject(&utils.Project{WorkingDirs: []string{utils.RootDir}})
+	resultsCache := utils.NewResultsCache(memoryResultsCacheStorage{})
+
+	client.EXPECT().GetLatestCommit(context.Background(), "jfrog", "frogbot", "master").Return(vcsclient.CommitInfo{Hash: "sha"}, nil)
+	expectedKey, err := scanDetails.GetResultsCacheKey(target, "sha")
+	require.NoError(t, err)
+	assert.Equal(t, expectedKey, getTargetBranchCacheKey(resultsCache, scanDetails))
+
+	// The results aren't cached if the commit is unknown
+	client.EXPECT().GetLatestCommit(context.Background(), "jfrog", "frogbot", "master").Return(vcsclient.CommitInfo{}, errors.New("not found"))
+	assert.Empty(t, getTargetBranchCacheKey(resultsCache, scanDetails))
+
+	// No commit is requested when caching is disabled
+	assert.Empty(t, getTargetBranchCacheKey(nil, scanDetails))
+}
 
-	// Test with comment returned
-	client.EXPECT().ListPullRequestComments(context.Background(), "owner", "repo", 17).Return([]vcsclient.CommentInfo{
-		{ID: 20, Content: outputwriter.GetBanner(outputwriter.NoVulnerabilityPrBannerSource) + "text \n table\n text text text", Created: time.Unix(3, 0)},
-	}, nil)
-	client.EXPECT().DeletePullRequestComment(context.Background(), "owner", "repo", 17, 20).Return(nil).AnyTimes()
-	err := deleteExistingPullRequestComment(repository, client)
-	assert.NoError(t, err)
+// Records the edited comments, instead of calling the API of the Git provider
+type fakeCommentEditor struct {
+	editErr error
+	edited  map[int]string
+}
 
-	// Test with no comment returned
-	client.EXPECT().ListPullRequestComments(context.Background(), "owner", "repo", 17).Return([]vcsclient.CommentInfo{}, nil)
-	err = deleteExistingPullRequestComment(repository, client)
-	assert.NoError(t, err)
+func (fce *fakeCommentEditor) EditPullRequestComment(_ context.Context, owner, repository string, pullRequestID, commentID int, content string) error {
+	if owner != "owner" || repository != "repo" || pullRequestID != 17 {
+		return errors.New("pull request not found")
+	}
+	if fce.editErr != nil {
+		return fce.editErr
+	}
+	fce.edited[commentID] = content
+	return nil
+}
 
-	// Test with error returned
-	client.EXPECT().ListPullRequestComments(context.Background(), "owner", "repo", 17).Return(nil, errors.New("error"))
-	err = deleteExistingPullRequestComment(repository, client)
-	assert.Error(t, err)
+func TestAddOrUpdatePullRequestComment(t *testing.T) {
+	existingComment := vcsclient.CommentInfo{ID: 20, Content: outputwriter.GetBanner(outputwriter.NoVulnerabilityPrBannerSource) + "text", Created: time.Unix(3, 0)}
+	otherComment := vcsclient.CommentInfo{ID: 21, Content: "LGTM", Created: time.Unix(4, 0)}
+	testCases := []struct {
+		name             string
+		editor           *fakeCommentEditor
+		existingComments []vcsclient.CommentInfo
+		listErr          error
+		expectEdit       bool
+		expectReplace    bool
+		expectAdd        bool
+		expectErr        bool
+	}{
+		{name: "Edit the comment", editor: &fakeCommentEditor{}, existingComments: []vcsclient.CommentInfo{otherComment, existingComment}, expectEdit: true},
+		{name: "Replace the comment if the edit fails", editor: &fakeCommentEditor{editErr: errors.New("forbidden")}, existingComments: []vcsclient.CommentInfo{existingComment}, expectReplace: true, expectAdd: true},
+		{name: "Replace the comment if editing isn't supported", existingComments: []vcsclient.CommentInfo{existingComment}, expectReplace: true, expectAdd: true},
+		{name: "Add a comment", editor: &fakeCommentEditor{}, existingComments: []vcsclient.CommentInfo{otherComment}, expectAdd: true},
+		{name: "Add a comment without an editor", expectAdd: true},
+		{name: "Failing to list the comments", editor: &fakeCommentEditor{}, listErr: errors.New("error"), expectErr: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			repository := &utils.Repository{
+				Params: utils.Params{
+					Git: utils.Git{
+						RepoOwner: "owner",
+						RepoName:  "repo",
+						PullRequestDetails: vcsclient.PullRequestInfo{Target: vcsclient.BranchInfo{
+							Repository: "repo",
+							Owner:      "owner",
+						}, ID: 17},
+					},
+				},
+				OutputWriter: &outputwriter.StandardOutput{},
+			}
+			client := CreateMockVcsClient(t)
+			client.EXPECT().ListPullRequestComments(context.Background(), "owner", "repo", 17).Return(test.existingComments, test.listErr)
+			if test.expectReplace {
+				client.EXPECT().DeletePullRequestComment(context.Background(), "owner", "repo", 17, 20).Return(nil)
+			}
+			if test.expectAdd {
+				client.EXPECT().AddPullRequestComment(context.Background(), "owner", "repo", "new content", 17).Return(nil)
+			}
+			var editor pullRequestCommentEditor
+			if test.editor != nil {
+				test.editor.edited = map[int]string{}
+				editor = test.editor
+			}
+			err := addOrUpdatePullRequestComment(repository, client, editor, "new content")
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if test.expectEdit {
+				assert.Equal(t, map[int]string{20: "new content"}, test.editor.edited)
+			} else if test.editor != nil {
+				assert.Empty(t, test.editor.edited)
+			}
+		})
+	}
 }
 
 // Set new logger with output redirection to a null logger. This is useful for negative tests.
@@ -0,0 +1,301 @@
+package scanpullrequest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	securityReportFileName = "frogbot-security-report"
+	securityReportTemplate = `<!DOCTYPE html>
+<html>
+<head>
+    <title>Frogbot Security Report</title>
+    <style>
+        {{ .Css }}
+    </style>
+</head>
+<body>
+    <h1>Frogbot Security Report</h1>
+    <h2>Overview</h2>
+    <table class="table-container">
+        <thead>
+            <tr>
+                <th>REPOSITORY</th><th>BRANCH</th><th>CRITICAL</th><th>HIGH</th><th>MEDIUM</th><th>LOW</th><th>UNKNOWN</th>
+                <th>APPLICABLE</th><th>LICENSES</th><th>SECRETS</th><th>IAC</th><th>SAST</th>
+            </tr>
+        </thead>
+        <tbody>
+            {{- range .Report.Repositories }}
+            <tr>
+                <td><a href="#{{ anchor . }}">{{ .Repository }}</a></td><td>{{ .Branch }}</td>
+                {{- if .Error }}
+                <td colspan="10">Scan failed: {{ .Error }}</td>
+                {{- else }}
+                {{- template "summaryCells" .Summary }}
+                {{- end }}
+            </tr>
+            {{- end }}
+            <tr>
+                <th colspan="2">TOTAL</th>
+                {{- template "summaryCells" .Report.Summary }}
+            </tr>
+        </tbody>
+    </table>
+    {{- range .Report.Repositories }}
+    {{- if and (not .Error) .Issues }}
+    <h2 id="{{ anchor . }}">{{ .Repository }} ({{ .Branch }})</h2>
+    {{- with .Issues.Vulnerabilities }}
+    <h3>Vulnerable Dependencies</h3>
+    <table class="table-container">
+        <thead><tr><th>SEVERITY</th><th>CONTEXTUAL ANALYSIS</th><th>DIRECT DEPENDENCIES</th><th>IMPACTED DEPENDENCY</th><th>FIXED VERSIONS</th><th>CVES</th></tr></thead>
+        <tbody>
+            {{- range . }}
+            <tr><td>{{ .Severity }}</td><td>{{ .Applicable }}</td><td>{{ components .Components }}</td><td>{{ .ImpactedDependencyName }}:{{ .ImpactedDependencyVersion }}</td><td>{{ join .FixedVersions }}</td><td>{{ cves .Cves }}</td></tr>
+            {{- end }}
+        </tbody>
+    </table>
+    {{- end }}
+    {{- with .Issues.Licenses }}
+    <h3>Violated Licenses</h3>
+    <table class="table-container">
+        <thead><tr><th>LICENSE</th><th>DIRECT DEPENDENCIES</th><th>IMPACTED DEPENDENCY</th></tr></thead>
+        <tbody>
+            {{- range . }}
+            <tr><td>{{ .LicenseKey }}</td><td>{{ components .Components }}</td><td>{{ .ImpactedDependencyName }}:{{ .ImpactedDependencyVersion }}</td></tr>
+            {{- end }}
+        </tbody>
+    </table>
+    {{- end }}
+    {{- with .Issues.Secrets }}
+    <h3>Secrets</h3>
+    {{- template "sourceCodeTable" . }}
+    {{- end }}
+    {{- with .Issues.Iacs }}
+    <h3>Infrastructure as Code</h3>
+    {{- template "sourceCodeTable" . }}
+    {{- end }}
+    {{- with .Issues.Sast }}
+    <h3>Static Application Security Testing (SAST)</h3>
+    {{- template "sourceCodeTable" . }}
+    {{- end }}
+    {{- end }}
+    {{- end }}
+</body>
+</html>
+{{- define "summaryCells" }}
+                <td>{{ .Critical }}</td><td>{{ .High }}</td><td>{{ .Medium }}</td><td>{{ .Low }}</td><td>{{ .Unknown }}</td>
+                <td>{{ .Applicable }}</td><td>{{ .Licenses }}</td><td>{{ .Secrets }}</td><td>{{ .Iac }}</td><td>{{ .Sast }}</td>
+{{- end }}
+{{- define "sourceCodeTable" }}
+    <table class="table-container">
+        <thead><tr><th>SEVERITY</th><th>FILE</th><th>LINE:COLUMN</th><th>FINDING</th></tr></thead>
+        <tbody>
+            {{- range . }}
+            <tr><td>{{ .Severity }}</td><td>{{ .File }}</td><td>{{ .StartLine }}:{{ .StartColumn }}</td><td>{{ if .Finding }}{{ .Finding }}{{ else }}{{ .Snippet }}{{ end }}</td></tr>
+            {{- end }}
+        </tbody>
+    </table>
+{{- end }}`
+)
+
+// ReportCmd scans every configured repository and branch, and writes a consolidated security report in both HTML and JSON formats.
+// Unlike scan-multiple-repositories, no pull requests are opened. The report tracks the security exposure across the repositories.
+type ReportCmd struct {
+	// The directory to write the report files to
+	outputDir string
+}
+
+func NewReportCmd(outputDir string) *ReportCmd {
+	if outputDir == "" {
+		outputDir = utils.RootDir
+	}
+	return &ReportCmd{outputDir: outputDir}
+}
+
+// The consolidated report of all the scanned repositories
+type securityReport struct {
+	Repositories []repositoryReport `json:"repositories"`
+	Summary      issuesSummary      `json:"summary"`
+}
+
+type repositoryReport struct {
+	Repository string                  `json:"repository"`
+	Branch     string                  `json:"branch"`
+	Summary    issuesSummary           `json:"summary"`
+	Issues     *utils.IssuesCollection `json:"issues,omitempty"`
+	// The error is set if the branch couldn't be scanned
+	Error string `json:"error,omitempty"`
+}
+
+type issuesSummary struct {
+	Critical   int `json:"critical"`
+	High       int `json:"high"`
+	Medium     int `json:"medium"`
+	Low        int `json:"low"`
+	Unknown    int `json:"unknown"`
+	Applicable int `json:"applicable"`
+	Licenses   int `json:"licenses"`
+	Secrets    int `json:"secrets"`
+	Iac        int `json:"iac"`
+	Sast       int `json:"sast"`
+}
+
+func (cmd *ReportCmd) Run(repoAggregator utils.RepoAggregator, client vcsclient.VcsClient) (err error) {
+	report := &securityReport{}
+	for repoNum := range repoAggregator {
+		repoConfig := &repoAggregator[repoNum]
+		for _, branch := range repoConfig.Branches {
+			repoReport, e := scanRepositoryBranch(repoConfig, client, branch)
+			if e != nil {
+				err = errors.Join(err, fmt.Errorf("failed to scan the %s branch of %s: %w", branch, repoConfig.RepoName, e))
+			}
+			report.add(repoReport)
+		}
+	}
+	// The report is written even if some of the repositories couldn't be scanned
+	return errors.Join(err, cmd.writeSecurityReport(report))
+}
+
+// Downloads the branch of the repository and audits it. If the scan fails, the returned report includes the error, so that the rest of the repositories are still reported.
+func scanRepositoryBranch(repoConfig *utils.Repository, client vcsclient.VcsClient, branch string) (repoReport repositoryReport, err error) {
+	repoReport = repositoryReport{Repository: repoConfig.RepoName, Branch: branch}
+	if repoConfig.RepoOwner != "" {
+		repoReport.Repository = repoConfig.RepoOwner + "/" + repoConfig.RepoName
+	}
+	log.Info(fmt.Sprintf("Scanning the %s branch of %s", branch, repoReport.Repository))
+	scanSummary := repoConfig.RunSummary.AddScan(repoConfig.RepoOwner, repoConfig.RepoName, branch, 0)
+	issues, err := auditBranch(repoConfig, client, branch)
+	if err != nil {
+		scanSummary.SetError(err)
+		repoReport.Error = err.Error()
+		return
+	}
+	scanSummary.SetIssues(issues)
+	repoReport.Issues = issues
+	repoReport.Summary = getIssuesSummary(issues)
+	return
+}
+
+func auditBranch(repoConfig *utils.Repository, client vcsclient.VcsClient, branch string) (issues *utils.IssuesCollection, err error) {
+	wd, cleanup, err := utils.DownloadRepoToTempDir(client, repoConfig.RepoOwner, repoConfig.RepoName, branch)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, cleanup())
+	}()
+	issues, _, err = auditLocalWorkingTree(repoConfig, wd)
+	return
+}
+
+func (report *securityReport) add(repoReport repositoryReport) {
+	report.Repositories = append(report.Repositories, repoReport)
+	report.Summary.add(repoReport.Summary)
+}
+
+func getIssuesSummary(issues *utils.IssuesCollection) (summary issuesSummary) {
+	for _, vulnerability := range issues.Vulnerabilities {
+		switch vulnerability.Severity {
+		case "Critical":
+			summary.Critical++
+		case "High":
+			summary.High++
+		case "Medium":
+			summary.Medium++
+		case "Low":
+			summary.Low++
+		default:
+			summary.Unknown++
+		}
+		if vulnerability.Applicable == string(xrayutils.Applicable) {
+			summary.Applicable++
+		}
+	}
+	summary.Licenses = len(issues.Licenses)
+	summary.Secrets = len(issues.Secrets)
+	summary.Iac = len(issues.Iacs)
+	summary.Sast = len(issues.Sast)
+	return
+}
+
+func (summary *issuesSummary) add(other issuesSummary) {
+	summary.Critical += other.Critical
+	summary.High += other.High
+	summary.Medium += other.Medium
+	summary.Low += other.Low
+	summary.Unknown += other.Unknown
+	summary.Applicable += other.Applicable
+	summary.Licenses += other.Licenses
+	summary.Secrets += other.Secrets
+	summary.Iac += other.Iac
+	summary.Sast += other.Sast
+}
+
+func (cmd *ReportCmd) writeSecurityReport(report *securityReport) (err error) {
+	jsonReport, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return
+	}
+	htmlReport, err := createHtmlSecurityReport(report)
+	if err != nil {
+		return
+	}
+	jsonReportPath := filepath.Join(cmd.outputDir, securityReportFileName+".json")
+	htmlReportPath := filepath.Join(cmd.outputDir, securityReportFileName+".html")
+	log.Info("Writing the security report to", jsonReportPath, "and", htmlReportPath)
+	return errors.Join(os.WriteFile(jsonReportPath, jsonReport, 0600), os.WriteFile(htmlReportPath, []byte(htmlReport), 0600))
+}
+
+// The columns of the HTML tables are identical to the columns of the tables in the pull request comment.
+func createHtmlSecurityReport(report *securityReport) (string, error) {
+	htmlTemplate, err := template.New(securityReportFileName).Funcs(template.FuncMap{
+		"anchor": func(repoReport repositoryReport) string {
+			return strings.NewReplacer("/", "-", " ", "-").Replace(repoReport.Repository + "-" + repoReport.Branch)
+		},
+		"components": func(components []formats.ComponentRow) string {
+			var directDependencies []string
+			for _, component := range components {
+				directDependencies = append(directDependencies, component.Name+":"+component.Version)
+			}
+			return strings.Join(directDependencies, ", ")
+		},
+		"cves": func(cves []formats.CveRow) string {
+			var cveIds []string
+			for _, cve := range cves {
+				if cve.Id != "" {
+					cveIds = append(cveIds, cve.Id)
+				}
+			}
+			return strings.Join(cveIds, ", ")
+		},
+		"join": func(values []string) string {
+			return strings.Join(values, ", ")
+		},
+	}).Parse(securityReportTemplate)
+	if err != nil {
+		return "", err
+	}
+	var content bytes.Buffer
+	// #nosec G203 -- the CSS is a constant
+	if err = htmlTemplate.Execute(&content, struct {
+		Css    template.CSS
+		Report *securityReport
+	}{Css: template.CSS(outputwriter.SecretsEmailCSS), Report: report}); err != nil {
+		return "", err
+	}
+	return content.String(), nil
+}
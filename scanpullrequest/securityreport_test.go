@@ -0,0 +1,89 @@
+package scanpullrequest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestIssuesCollection() *utils.IssuesCollection {
+	return &utils.IssuesCollection{
+		Vulnerabilities: []formats.VulnerabilityOrViolationRow{
+			{
+				ImpactedDependencyDetails: formats.ImpactedDependencyDetails{
+					SeverityDetails:           formats.SeverityDetails{Severity: "Critical"},
+					ImpactedDependencyName:    "minimist",
+					ImpactedDependencyVersion: "1.2.5",
+					Components:                []formats.ComponentRow{{Name: "minimist", Version: "1.2.5"}},
+				},
+				Applicable:    "Applicable",
+				FixedVersions: []string{"[1.2.6]"},
+				Cves:          []formats.CveRow{{Id: "CVE-2021-44906"}},
+			},
+			{
+				ImpactedDependencyDetails: formats.ImpactedDependencyDetails{SeverityDetails: formats.SeverityDetails{Severity: "High"}},
+				Applicable:                "Not Applicable",
+			},
+			{
+				ImpactedDependencyDetails: formats.ImpactedDependencyDetails{SeverityDetails: formats.SeverityDetails{Severity: "Low"}},
+			},
+		},
+		Secrets:  []formats.SourceCodeRow{{Location: formats.Location{File: "config.js", StartLine: 1, StartColumn: 5, Snippet: "<script>***</script>"}}},
+		Iacs:     []formats.SourceCodeRow{{Finding: "Public access"}, {Finding: "Missing encryption"}},
+		Licenses: []formats.LicenseRow{{LicenseKey: "GPL-3.0"}},
+	}
+}
+
+func TestGetIssuesSummary(t *testing.T) {
+	assert.Equal(t, issuesSummary{Critical: 1, High: 1, Low: 1, Applicable: 1, Licenses: 1, Secrets: 1, Iac: 2}, getIssuesSummary(createTestIssuesCollection()))
+	assert.Equal(t, issuesSummary{}, getIssuesSummary(&utils.IssuesCollection{}))
+}
+
+func TestSecurityReportAdd(t *testing.T) {
+	report := &securityReport{}
+	report.add(repositoryReport{Repository: "jfrog/frogbot", Branch: "master", Summary: issuesSummary{Critical: 1, Secrets: 2}})
+	report.add(repositoryReport{Repository: "jfrog/frogbot", Branch: "dev", Summary: issuesSummary{Critical: 2, Sast: 1}})
+	report.add(repositoryReport{Repository: "jfrog/jfrog-cli", Branch: "master", Error: "repository not found"})
+	assert.Len(t, report.Repositories, 3)
+	assert.Equal(t, issuesSummary{Critical: 3, Secrets: 2, Sast: 1}, report.Summary)
+}
+
+func TestWriteSecurityReport(t *testing.T) {
+	outputDir := t.TempDir()
+	issues := createTestIssuesCollection()
+	report := &securityReport{}
+	report.add(repositoryReport{Repository: "jfrog/frogbot", Branch: "master", Issues: issues, Summary: getIssuesSummary(issues)})
+	report.add(repositoryReport{Repository: "jfrog/jfrog-cli", Branch: "v2", Error: "repository not found"})
+	require.NoError(t, NewReportCmd(outputDir).writeSecurityReport(report))
+
+	// Check the JSON report
+	content, err := os.ReadFile(filepath.Join(outputDir, securityReportFileName+".json"))
+	require.NoError(t, err)
+	var jsonReport securityReport
+	require.NoError(t, json.Unmarshal(content, &jsonReport))
+	assert.Equal(t, *report, jsonReport)
+
+	// Check the HTML report
+	content, err = os.ReadFile(filepath.Join(outputDir, securityReportFileName+".html"))
+	require.NoError(t, err)
+	htmlReport := string(content)
+	assert.Contains(t, htmlReport, `<h2 id="jfrog-frogbot-master">jfrog/frogbot (master)</h2>`)
+	assert.Contains(t, htmlReport, "<td>Critical</td><td>Applicable</td><td>minimist:1.2.5</td><td>minimist:1.2.5</td><td>[1.2.6]</td><td>CVE-2021-44906</td>")
+	assert.Contains(t, htmlReport, `<td colspan="10">Scan failed: repository not found</td>`)
+	assert.Contains(t, htmlReport, "<td>GPL-3.0</td>")
+	assert.Contains(t, htmlReport, "<td>Missing encryption</td>")
+	// The findings must be escaped
+	assert.Contains(t, htmlReport, "&lt;script&gt;***&lt;/script&gt;")
+	assert.NotContains(t, htmlReport, "<script>")
+}
+
+func TestNewReportCmd(t *testing.T) {
+	assert.Equal(t, utils.RootDir, NewReportCmd("").outputDir)
+	assert.Equal(t, "reports", NewReportCmd("reports").outputDir)
+}
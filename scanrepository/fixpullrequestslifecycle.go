@@ -0,0 +1,122 @@
+package scanrepository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	fixedDependencyMarker  = "Fixed dependency:"
+	stalePullRequestTitle  = outputwriter.FrogbotTitlePrefix + " Outdated vulnerability fix"
+	stalePullRequestReason = "Frogbot closed this pull request, because the latest scan of the base branch no longer requires it. " +
+		"Either the vulnerable dependency was updated or removed, or a different fix version is now suggested in a new pull request."
+)
+
+var fixedDependencyRegex = regexp.MustCompile(fixedDependencyMarker + ` (\S+) (\S+)\)`)
+
+// Returns a hidden marker of the fixed dependency, to be added to the body of the fix pull request.
+// The marker allows restoring the pull request title when the pull request is closed.
+func getFixedDependencyMarker(impactedPackage, fixVersion string) string {
+	return outputwriter.MarkdownComment(fmt.Sprintf("%s %s %s", fixedDependencyMarker, impactedPackage, fixVersion))
+}
+
+// Lists the open pull requests created by Frogbot to fix vulnerabilities in the current base branch, by their source branch.
+// The pull requests are identified by the branch name template.
+func (cfp *ScanRepositoryCmd) loadOpenFixPullRequests() (err error) {
+	cfp.requiredFixBranches = map[string]bool{}
+	cfp.openFixPullRequests = map[string]vcsclient.PullRequestInfo{}
+	fixBranchNameRegex, err := cfp.gitManager.GenerateFixBranchNameRegex()
+	if err != nil {
+		return
+	}
+	openPullRequests, err := cfp.scanDetails.Client().ListOpenPullRequestsWithBody(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName)
+	if err != nil {
+		err = utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+		return
+	}
+	for _, pullRequest := range openPullRequests {
+		if pullRequest.Target.Name == cfp.scanDetails.BaseBranch() && fixBranchNameRegex.MatchString(pullRequest.Source.Name) {
+			cfp.openFixPullRequests[pullRequest.Source.Name] = pullRequest
+		}
+	}
+	log.Debug(fmt.Sprintf("Found %d open Frogbot pull requests to %s", len(cfp.openFixPullRequests), cfp.scanDetails.BaseBranch()))
+	return
+}
+
+// Returns the open pull request of the fix branch if the base branch has changed since the fix branch was created, otherwise returns nil.
+func (cfp *ScanRepositoryCmd) getOutdatedFixPullRequest(fixBranchName string) (*vcsclient.PullRequestInfo, error) {
+	pullRequest, isOpen := cfp.openFixPullRequests[fixBranchName]
+	if !isOpen {
+		return nil, nil
+	}
+	client := cfp.scanDetails.Client()
+	baseCommit, err := client.GetLatestCommit(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, cfp.scanDetails.BaseBranch())
+	if err != nil {
+		return nil, utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+	}
+	fixCommit, err := client.GetLatestCommit(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, fixBranchName)
+	if err != nil {
+		return nil, utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+	}
+	if slices.Contains(fixCommit.ParentHashes, baseCommit.Hash) {
+		return nil, nil
+	}
+	return &pullRequest, nil
+}
+
+// Closes the open Frogbot pull requests that aren't required according to the latest scan of the base branch.
+// A pull request isn't required if its vulnerable dependency no longer appears, or if a different fix version is suggested.
+// In plan mode, the pull requests are added to the plan instead of being closed.
+func (cfp *ScanRepositoryCmd) closeStaleFixPullRequests() error {
+	// The pull requests are closed in a stable order, so that the plan is the same between runs
+	fixBranchNames := maps.Keys(cfp.openFixPullRequests)
+	slices.Sort(fixBranchNames)
+	for _, fixBranchName := range fixBranchNames {
+		if cfp.requiredFixBranches[fixBranchName] {
+			continue
+		}
+		pullRequest := cfp.openFixPullRequests[fixBranchName]
+		if cfp.planMode {
+			log.Info(fmt.Sprintf("Plan mode is enabled. Skipping closing the outdated pull request #%d from %s", pullRequest.ID, fixBranchName))
+			cfp.plannedPullRequests = append(cfp.plannedPullRequests, plannedPullRequest{
+				action:     planClose,
+				baseBranch: pullRequest.Target.Name,
+				fixBranch:  fixBranchName,
+				title:      cfp.getStalePullRequestTitle(pullRequest.Body),
+				id:         pullRequest.ID,
+			})
+			continue
+		}
+		log.Info(fmt.Sprintf("Closing the outdated pull request #%d from %s", pullRequest.ID, fixBranchName))
+		if err := cfp.closePullRequest(pullRequest); err != nil {
+			return utils.WithExitCode(fmt.Errorf("failed to close the outdated pull request #%d:\n%s", pullRequest.ID, err.Error()), utils.ExitCodeVcsApiError)
+		}
+	}
+	return nil
+}
+
+func (cfp *ScanRepositoryCmd) closePullRequest(pullRequest vcsclient.PullRequestInfo) error {
+	client := cfp.scanDetails.Client()
+	if err := client.AddPullRequestComment(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, stalePullRequestReason, int(pullRequest.ID)); err != nil {
+		return err
+	}
+	return client.UpdatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, cfp.getStalePullRequestTitle(pullRequest.Body), pullRequest.Body, pullRequest.Target.Name, int(pullRequest.ID), vcsutils.Closed)
+}
+
+// The Git providers don't return the title of the listed pull requests. The title is restored from the fixed dependency marker, if it exists.
+func (cfp *ScanRepositoryCmd) getStalePullRequestTitle(pullRequestBody string) string {
+	match := fixedDependencyRegex.FindStringSubmatch(pullRequestBody)
+	if len(match) != 3 {
+		return stalePullRequestTitle
+	}
+	return cfp.gitManager.GeneratePullRequestTitle(match[1], match[2])
+}
@@ -0,0 +1,103 @@
+package scanrepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jfrog/frogbot/testdata"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	requiredFixBranch = "frogbot-minimist-258ad6a538b5ba800f18ae4f6d660302"
+	staleFixBranch    = "frogbot-mpath-41b1f45136b25e3624b15999bd57a476"
+)
+
+func createLifecycleTestCmd(client vcsclient.VcsClient) *ScanRepositoryCmd {
+	git := &utils.Git{RepoOwner: "jfrog", RepoName: "frogbot"}
+	return &ScanRepositoryCmd{
+		scanDetails: utils.NewScanDetails(client, &config.ServerDetails{}, git).SetBaseBranch("main"),
+		gitManager:  &utils.GitManager{},
+	}
+}
+
+func TestLoadOpenFixPullRequests(t *testing.T) {
+	client := testdata.NewMockVcsClient(gomock.NewController(t))
+	client.EXPECT().ListOpenPullRequestsWithBody(context.Background(), "jfrog", "frogbot").Return([]vcsclient.PullRequestInfo{
+		{ID: 1, Source: vcsclient.BranchInfo{Name: requiredFixBranch}, Target: vcsclient.BranchInfo{Name: "main"}},
+		// Fix pull request to another base branch
+		{ID: 2, Source: vcsclient.BranchInfo{Name: staleFixBranch}, Target: vcsclient.BranchInfo{Name: "dev"}},
+		// Aggregated fix pull request
+		{ID: 3, Source: vcsclient.BranchInfo{Name: "frogbot-update-npm-dependencies-main"}, Target: vcsclient.BranchInfo{Name: "main"}},
+		// A pull request that wasn't created by Frogbot
+		{ID: 4, Source: vcsclient.BranchInfo{Name: "feature"}, Target: vcsclient.BranchInfo{Name: "main"}},
+	}, nil)
+	cmd := createLifecycleTestCmd(client)
+	assert.NoError(t, cmd.loadOpenFixPullRequests())
+	assert.Len(t, cmd.openFixPullRequests, 1)
+	assert.Equal(t, int64(1), cmd.openFixPullRequests[requiredFixBranch].ID)
+	assert.Empty(t, cmd.requiredFixBranches)
+}
+
+func TestCloseStaleFixPullRequests(t *testing.T) {
+	client := testdata.NewMockVcsClient(gomock.NewController(t))
+	staleBody := "pr body" + getFixedDependencyMarker("mpath", "0.8.4")
+	client.EXPECT().AddPullRequestComment(context.Background(), "jfrog", "frogbot", stalePullRequestReason, 2).Return(nil)
+	client.EXPECT().UpdatePullRequest(context.Background(), "jfrog", "frogbot", "[🐸 Frogbot] Update version of mpath to 0.8.4", staleBody, "main", 2, vcsutils.Closed).Return(nil)
+	cmd := createLifecycleTestCmd(client)
+	cmd.openFixPullRequests = map[string]vcsclient.PullRequestInfo{
+		requiredFixBranch: {ID: 1, Body: "pr body", Target: vcsclient.BranchInfo{Name: "main"}},
+		staleFixBranch:    {ID: 2, Body: staleBody, Target: vcsclient.BranchInfo{Name: "main"}},
+	}
+	cmd.requiredFixBranches = map[string]bool{requiredFixBranch: true}
+	assert.NoError(t, cmd.closeStaleFixPullRequests())
+
+	// In plan mode, the pull requests aren't closed, and are added to the plan instead
+	cmd.planMode = true
+	assert.NoError(t, cmd.closeStaleFixPullRequests())
+	assert.Equal(t, []plannedPullRequest{{action: planClose, baseBranch: "main", fixBranch: staleFixBranch, title: "[🐸 Frogbot] Update version of mpath to 0.8.4", id: 2}}, cmd.plannedPullRequests)
+}
+
+func TestGetOutdatedFixPullRequest(t *testing.T) {
+	testCases := []struct {
+		name             string
+		fixParents       []string
+		expectedOutdated bool
+	}{
+		{name: "Up to date", fixParents: []string{"base-hash"}},
+		{name: "Base branch changed", fixParents: []string{"old-base-hash"}, expectedOutdated: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			client := testdata.NewMockVcsClient(gomock.NewController(t))
+			client.EXPECT().GetLatestCommit(context.Background(), "jfrog", "frogbot", "main").Return(vcsclient.CommitInfo{Hash: "base-hash"}, nil)
+			client.EXPECT().GetLatestCommit(context.Background(), "jfrog", "frogbot", requiredFixBranch).Return(vcsclient.CommitInfo{Hash: "fix-hash", ParentHashes: test.fixParents}, nil)
+			cmd := createLifecycleTestCmd(client)
+			cmd.openFixPullRequests = map[string]vcsclient.PullRequestInfo{requiredFixBranch: {ID: 1}}
+			pullRequest, err := cmd.getOutdatedFixPullRequest(requiredFixBranch)
+			assert.NoError(t, err)
+			if !test.expectedOutdated {
+				assert.Nil(t, pullRequest)
+				return
+			}
+			assert.Equal(t, int64(1), pullRequest.ID)
+		})
+	}
+
+	// A branch without an open pull request isn't updated
+	cmd := createLifecycleTestCmd(nil)
+	pullRequest, err := cmd.getOutdatedFixPullRequest(staleFixBranch)
+	assert.NoError(t, err)
+	assert.Nil(t, pullRequest)
+}
+
+func TestGetStalePullRequestTitle(t *testing.T) {
+	cmd := createLifecycleTestCmd(nil)
+	assert.Equal(t, "[🐸 Frogbot] Update version of org.apache:log4j to 2.17.1", cmd.getStalePullRequestTitle("body"+getFixedDependencyMarker("org.apache:log4j", "2.17.1")))
+	assert.Equal(t, stalePullRequestTitle, cmd.getStalePullRequestTitle("body without marker"))
+}
@@ -0,0 +1,68 @@
+package scanrepository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const planSeparator = "-----------------------------------------------------------------"
+
+// The action Frogbot would have taken on a pull request, if the plan mode wasn't enabled
+type planAction string
+
+const (
+	planCreate planAction = "Create"
+	planUpdate planAction = "Update"
+	planClose  planAction = "Close"
+)
+
+// A pull request Frogbot would have created, updated or closed, if the plan mode wasn't enabled.
+type plannedPullRequest struct {
+	action        planAction
+	baseBranch    string
+	fixBranch     string
+	commitMessage string
+	title         string
+	// The ID of the existing pull request that would have been closed
+	id int64
+	// The changes made by the fix commit, in the unified diff format
+	diff string
+}
+
+func (ppr *plannedPullRequest) String() string {
+	var sb strings.Builder
+	if ppr.action == planClose {
+		sb.WriteString(fmt.Sprintf("%s pull request #%d: %s\n", ppr.action, ppr.id, ppr.title))
+		sb.WriteString(fmt.Sprintf("Branch: %s -> %s\n", ppr.fixBranch, ppr.baseBranch))
+		sb.WriteString(fmt.Sprintf("Reason: %s\n", stalePullRequestReason))
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("%s pull request: %s\n", ppr.action, ppr.title))
+	sb.WriteString(fmt.Sprintf("Branch: %s -> %s\n", ppr.fixBranch, ppr.baseBranch))
+	sb.WriteString(fmt.Sprintf("Commit message: %s\n", ppr.commitMessage))
+	sb.WriteString("Changes:\n")
+	sb.WriteString(ppr.diff)
+	return sb.String()
+}
+
+// Prints the pull requests Frogbot would have created, updated or closed for the given repository.
+func printPlan(repoName string, plannedPullRequests []plannedPullRequest) {
+	if len(plannedPullRequests) == 0 {
+		log.Output(fmt.Sprintf("Plan for %s: no pull requests would be created, updated or closed", repoName))
+		return
+	}
+	log.Output(getPlanContent(repoName, plannedPullRequests))
+}
+
+func getPlanContent(repoName string, plannedPullRequests []plannedPullRequest) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Plan for %s: %d pull requests would be created, updated or closed\n", repoName, len(plannedPullRequests)))
+	for i := range plannedPullRequests {
+		sb.WriteString(planSeparator + "\n")
+		sb.WriteString(plannedPullRequests[i].String())
+	}
+	sb.WriteString(planSeparator)
+	return sb.String()
+}
@@ -0,0 +1,74 @@
+package scanrepository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlannedPullRequestString(t *testing.T) {
+	testCases := []struct {
+		name               string
+		plannedPullRequest plannedPullRequest
+		expected           string
+	}{
+		{
+			name: "New pull request",
+			plannedPullRequest: plannedPullRequest{
+				action:        planCreate,
+				baseBranch:    "main",
+				fixBranch:     "frogbot-minimist-abc",
+				commitMessage: "Upgrade minimist to 1.2.6",
+				title:         "[🐸 Frogbot] Update version of minimist to 1.2.6",
+				diff:          "diff --git a/package.json b/package.json\n",
+			},
+			expected: "Create pull request: [🐸 Frogbot] Update version of minimist to 1.2.6\n" +
+				"Branch: frogbot-minimist-abc -> main\n" +
+				"Commit message: Upgrade minimist to 1.2.6\n" +
+				"Changes:\n" +
+				"diff --git a/package.json b/package.json\n",
+		},
+		{
+			name: "Existing aggregated pull request",
+			plannedPullRequest: plannedPullRequest{
+				baseBranch:    "dev",
+				fixBranch:     "frogbot-update-npm-dependencies",
+				commitMessage: "[🐸 Frogbot] Update npm dependencies",
+				title:         "[🐸 Frogbot] Update npm dependencies",
+				action:        planUpdate,
+			},
+			expected: "Update pull request: [🐸 Frogbot] Update npm dependencies\n" +
+				"Branch: frogbot-update-npm-dependencies -> dev\n" +
+				"Commit message: [🐸 Frogbot] Update npm dependencies\n" +
+				"Changes:\n",
+		},
+		{
+			name: "Outdated pull request",
+			plannedPullRequest: plannedPullRequest{
+				action:     planClose,
+				baseBranch: "main",
+				fixBranch:  "frogbot-mpath-abc",
+				title:      "[🐸 Frogbot] Update version of mpath to 0.8.4",
+				id:         2,
+			},
+			expected: "Close pull request #2: [🐸 Frogbot] Update version of mpath to 0.8.4\n" +
+				"Branch: frogbot-mpath-abc -> main\n" +
+				"Reason: " + stalePullRequestReason + "\n",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.plannedPullRequest.String())
+		})
+	}
+}
+
+func TestGetPlanContent(t *testing.T) {
+	content := getPlanContent("frogbot", []plannedPullRequest{
+		{action: planCreate, baseBranch: "main", fixBranch: "frogbot-minimist-abc", title: "Create title"},
+		{action: planClose, baseBranch: "main", fixBranch: "frogbot-mpath-abc", title: "Close title", id: 2},
+	})
+	assert.Contains(t, content, "Plan for frogbot: 2 pull requests would be created, updated or closed")
+	assert.Contains(t, content, "Create pull request: Create title")
+	assert.Contains(t, content, "Close pull request #2: Close title")
+}
@@ -11,10 +11,17 @@ type ScanMultipleRepositories struct {
 	dryRun bool
 	// When dryRun is enabled, dryRunRepoPath specifies the repository local path to clone
 	dryRunRepoPath string
+	// In plan mode, the fixes are printed without pushing or creating pull requests
+	planMode bool
+}
+
+func (saf *ScanMultipleRepositories) SetPlanMode(planMode bool) *ScanMultipleRepositories {
+	saf.planMode = planMode
+	return saf
 }
 
 func (saf *ScanMultipleRepositories) Run(repoAggregator utils.RepoAggregator, client vcsclient.VcsClient) (err error) {
-	scanRepositoryCmd := &ScanRepositoryCmd{dryRun: saf.dryRun, dryRunRepoPath: saf.dryRunRepoPath, baseWd: saf.dryRunRepoPath}
+	scanRepositoryCmd := &ScanRepositoryCmd{dryRun: saf.dryRun, dryRunRepoPath: saf.dryRunRepoPath, baseWd: saf.dryRunRepoPath, planMode: saf.planMode}
 	for repoNum := range repoAggregator {
 		if e := scanRepositoryCmd.scanAndFixRepository(&repoAggregator[repoNum], client); e != nil {
 			err = errors.Join(err, e)
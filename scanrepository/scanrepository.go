@@ -39,10 +39,29 @@ type ScanRepositoryCmd struct {
 	gitManager *utils.GitManager
 	// Determines whether to open a pull request for each vulnerability fix or to aggregate all fixes into one pull request
 	aggregateFixes bool
+	// Limits the fix versions the vulnerable dependencies are upgraded to
+	fixStrategy string
 	// The current project technology
 	projectTech []coreutils.Technology
 	// Stores all package manager handlers for detected issues
 	handlers map[coreutils.Technology]packagehandlers.PackageHandler
+	// In plan mode, the fixes are committed locally and printed, without pushing or creating pull requests
+	planMode bool
+	// The pull requests that would have been created or updated in plan mode
+	plannedPullRequests []plannedPullRequest
+	// The open fix pull requests to the current base branch, by their source branch
+	openFixPullRequests map[string]vcsclient.PullRequestInfo
+	// The fix branches of the vulnerabilities found in the current base branch
+	requiredFixBranches map[string]bool
+	// The run summary of the current base branch scan
+	scanSummary *utils.ScanSummary
+	// The .frogbot/ignore.yml file of the current base branch
+	ignoreFile *utils.IgnoreFile
+}
+
+func (cfp *ScanRepositoryCmd) SetPlanMode(planMode bool) *ScanRepositoryCmd {
+	cfp.planMode = planMode
+	return cfp
 }
 
 func (cfp *ScanRepositoryCmd) Run(repoAggregator utils.RepoAggregator, client vcsclient.VcsClient) (err error) {
@@ -54,12 +73,23 @@ func (cfp *ScanRepositoryCmd) Run(repoAggregator utils.RepoAggregator, client vc
 }
 
 func (cfp *ScanRepositoryCmd) scanAndFixRepository(repository *utils.Repository, client vcsclient.VcsClient) (err error) {
+	if cfp.planMode {
+		defer func() {
+			printPlan(repository.RepoName, cfp.plannedPullRequests)
+			cfp.plannedPullRequests = nil
+		}()
+	}
 	for _, branch := range repository.Branches {
-		if err = cfp.setCommandPrerequisites(repository, branch, client); err != nil {
+		// Apply the parameters overridden by the current branch
+		branchRepository := repository.GetBranchRepository(branch)
+		cfp.scanSummary = branchRepository.RunSummary.AddScan(branchRepository.RepoOwner, branchRepository.RepoName, branch, 0)
+		if err = cfp.setCommandPrerequisites(branchRepository, branch, client); err != nil {
+			cfp.scanSummary.SetError(err)
 			return
 		}
-		cfp.scanDetails.SetXscGitInfoContext(branch, repository.Project, client)
-		if err = cfp.scanAndFixBranch(repository); err != nil {
+		cfp.scanDetails.SetXscGitInfoContext(branch, branchRepository.Project, client)
+		if err = cfp.scanAndFixBranch(branchRepository); err != nil {
+			cfp.scanSummary.SetError(err)
 			return
 		}
 	}
@@ -79,6 +109,15 @@ func (cfp *ScanRepositoryCmd) scanAndFixBranch(repository *utils.Repository) (er
 		}
 		err = errors.Join(err, restoreBaseDir(), fileutils.RemoveTempDir(clonedRepoDir))
 	}()
+	if cfp.ignoreFile, err = utils.ReadIgnoreFile(clonedRepoDir); err != nil {
+		err = utils.WithExitCode(err, utils.ExitCodeConfigurationError)
+		return
+	}
+	if !cfp.aggregateFixes {
+		if err = cfp.loadOpenFixPullRequests(); err != nil {
+			return
+		}
+	}
 	for i := range repository.Projects {
 		cfp.scanDetails.Project = &repository.Projects[i]
 		cfp.projectTech = []coreutils.Technology{}
@@ -86,18 +125,19 @@ func (cfp *ScanRepositoryCmd) scanAndFixBranch(repository *utils.Repository) (er
 			return
 		}
 	}
+	if !cfp.aggregateFixes {
+		err = cfp.closeStaleFixPullRequests()
+	}
 	return
 }
 
 func (cfp *ScanRepositoryCmd) setCommandPrerequisites(repository *utils.Repository, branch string, client vcsclient.VcsClient) (err error) {
 	cfp.scanDetails = utils.NewScanDetails(client, &repository.Server, &repository.Git).
 		SetXrayGraphScanParams(repository.Watches, repository.JFrogProjectKey, false).
-		SetFailOnInstallationErrors(*repository.FailOnSecurityIssues).
-		SetBaseBranch(branch).
-		SetFixableOnly(repository.FixableOnly).
-		SetMinSeverity(repository.MinSeverity)
+		SetBaseBranch(branch)
 
 	cfp.aggregateFixes = repository.Git.AggregateFixes
+	cfp.fixStrategy = repository.Git.FixStrategy
 	cfp.OutputWriter = outputwriter.GetCompatibleOutputWriter(repository.GitProvider)
 	repositoryInfo, err := client.GetRepositoryInfo(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName)
 	if err != nil {
@@ -116,6 +156,12 @@ func (cfp *ScanRepositoryCmd) setCommandPrerequisites(repository *utils.Reposito
 }
 
 func (cfp *ScanRepositoryCmd) scanAndFixProject(repository *utils.Repository) error {
+	// Apply the scan policy of the current project
+	scanPolicy := cfp.scanDetails.Project.GetScanPolicy(&repository.Scan)
+	cfp.scanDetails.SetMinSeverity(scanPolicy.MinSeverity).
+		SetFixableOnly(scanPolicy.FixableOnly).
+		SetFailOnInstallationErrors(*scanPolicy.FailOnSecurityIssues)
+
 	var fixNeeded bool
 	// A map that contains the full project paths as a keys
 	// The value is a map of vulnerable package names -> the scanDetails of the vulnerable packages.
@@ -128,7 +174,7 @@ func (cfp *ScanRepositoryCmd) scanAndFixProject(repository *utils.Repository) er
 			return err
 		}
 
-		if repository.GitProvider.String() == vcsutils.GitHub.String() {
+		if repository.GitProvider.String() == vcsutils.GitHub.String() && !cfp.planMode {
 			// Uploads Sarif results to GitHub in order to view the scan in the code scanning UI
 			// Currently available on GitHub only
 			if err = utils.UploadSarifResultsToGithubSecurityTab(scanResults, repository, cfp.scanDetails.BaseBranch(), cfp.scanDetails.Client()); err != nil {
@@ -144,6 +190,7 @@ func (cfp *ScanRepositoryCmd) scanAndFixProject(repository *utils.Repository) er
 		if len(currPathVulnerabilities) > 0 {
 			fixNeeded = true
 		}
+		cfp.scanSummary.AddVulnerabilities(len(currPathVulnerabilities))
 		vulnerabilitiesByPathMap[fullPathWd] = currPathVulnerabilities
 	}
 	if fixNeeded {
@@ -160,6 +207,7 @@ func (cfp *ScanRepositoryCmd) scan(currentWorkingDir string) (*audit.Results, er
 		return nil, err
 	}
 	log.Info("Xray scan completed")
+	cfp.ignoreFile.FilterScanResults(auditResults.ExtendedScanResults)
 	contextualAnalysisResultsExists := len(auditResults.ExtendedScanResults.ApplicabilityScanResults) > 0
 	entitledForJas := auditResults.ExtendedScanResults.EntitledForJas
 	cfp.OutputWriter.SetJasOutputFlags(entitledForJas, contextualAnalysisResultsExists)
@@ -191,10 +239,10 @@ func (cfp *ScanRepositoryCmd) fixIssuesSeparatePRs(vulnerabilitiesMap map[string
 	var err error
 	for fullPath, vulnerabilities := range vulnerabilitiesMap {
 		if e := cfp.fixProjectVulnerabilities(fullPath, vulnerabilities); e != nil {
-			err = errors.Join(err, fmt.Errorf("the following errors occured while fixing vulnerabilities in %s:\n%s", fullPath, e))
+			err = errors.Join(err, fmt.Errorf("the following errors occured while fixing vulnerabilities in %s:\n%w", fullPath, e))
 		}
 	}
-	return err
+	return utils.WithExitCode(err, utils.ExitCodePartialFixFailure)
 }
 
 func (cfp *ScanRepositoryCmd) fixProjectVulnerabilities(fullProjectPath string, vulnerabilities map[string]*utils.VulnerabilityDetails) (err error) {
@@ -281,7 +329,7 @@ func (cfp *ScanRepositoryCmd) handleUpdatePackageErrors(err error) error {
 }
 
 // Creates a branch for the fixed package and open pull request against the target branch.
-// In case a branch already exists on remote, we skip it.
+// In case a branch already exists on remote, we skip it, unless its pull request is open and the base branch has changed since it was created.
 func (cfp *ScanRepositoryCmd) fixSinglePackageAndCreatePR(vulnDetails *utils.VulnerabilityDetails) (err error) {
 	fixVersion := vulnDetails.SuggestedFixedVersion
 	log.Debug("Attempting to fix", vulnDetails.ImpactedDependencyName, "with", fixVersion)
@@ -289,13 +337,22 @@ func (cfp *ScanRepositoryCmd) fixSinglePackageAndCreatePR(vulnDetails *utils.Vul
 	if err != nil {
 		return
 	}
+	// The pull request of this branch is still required, and shouldn't be closed as outdated
+	cfp.requiredFixBranches[fixBranchName] = true
 	existsInRemote, err := cfp.gitManager.BranchExistsInRemote(fixBranchName)
 	if err != nil {
 		return
 	}
+	var outdatedPullRequest *vcsclient.PullRequestInfo
 	if existsInRemote {
-		log.Info(fmt.Sprintf("A pull request updating the dependency '%s' to version '%s' already exists. Skipping...", vulnDetails.ImpactedDependencyName, vulnDetails.SuggestedFixedVersion))
-		return
+		if outdatedPullRequest, err = cfp.getOutdatedFixPullRequest(fixBranchName); err != nil {
+			return
+		}
+		if outdatedPullRequest == nil {
+			log.Info(fmt.Sprintf("A pull request updating the dependency '%s' to version '%s' already exists. Skipping...", vulnDetails.ImpactedDependencyName, vulnDetails.SuggestedFixedVersion))
+			return
+		}
+		log.Info(fmt.Sprintf("The base branch has changed since pull request #%d was created. Recreating the fix of the dependency '%s'...", outdatedPullRequest.ID, vulnDetails.ImpactedDependencyName))
 	}
 	if err = cfp.gitManager.CreateBranchAndCheckout(fixBranchName); err != nil {
 		return fmt.Errorf("failed while creating new branch: \n%s", err.Error())
@@ -303,15 +360,22 @@ func (cfp *ScanRepositoryCmd) fixSinglePackageAndCreatePR(vulnDetails *utils.Vul
 	if err = cfp.updatePackageToFixedVersion(vulnDetails); err != nil {
 		return
 	}
-	if err = cfp.openFixingPullRequest(fixBranchName, vulnDetails); err != nil {
-		return fmt.Errorf("failed while creating a fixing pull request for: %s with version: %s with error: \n%s",
-			vulnDetails.ImpactedDependencyName, fixVersion, err.Error())
+	if err = cfp.openFixingPullRequest(fixBranchName, vulnDetails, outdatedPullRequest); err != nil {
+		return fmt.Errorf("failed while creating a fixing pull request for: %s with version: %s with error: \n%w",
+			vulnDetails.ImpactedDependencyName, fixVersion, err)
+	}
+	if !cfp.planMode {
+		action := "Created"
+		if outdatedPullRequest != nil {
+			action = "Updated"
+		}
+		log.Info(fmt.Sprintf("%s Pull Request updating dependency '%s' to version '%s'", action, vulnDetails.ImpactedDependencyName, vulnDetails.SuggestedFixedVersion))
 	}
-	log.Info(fmt.Sprintf("Created Pull Request updating dependency '%s' to version '%s'", vulnDetails.ImpactedDependencyName, vulnDetails.SuggestedFixedVersion))
 	return
 }
 
-func (cfp *ScanRepositoryCmd) openFixingPullRequest(fixBranchName string, vulnDetails *utils.VulnerabilityDetails) (err error) {
+// Pushes the fix branch and opens a pull request. If the pull request of the fix branch is outdated, the branch is force pushed and the pull request is updated instead.
+func (cfp *ScanRepositoryCmd) openFixingPullRequest(fixBranchName string, vulnDetails *utils.VulnerabilityDetails, outdatedPullRequest *vcsclient.PullRequestInfo) (err error) {
 	log.Debug("Checking if there are changes to commit")
 	isClean, err := cfp.gitManager.IsClean()
 	if err != nil {
@@ -324,15 +388,30 @@ func (cfp *ScanRepositoryCmd) openFixingPullRequest(fixBranchName string, vulnDe
 	if err = cfp.gitManager.AddAllAndCommit(commitMessage); err != nil {
 		return
 	}
-	if err = cfp.gitManager.Push(false, fixBranchName); err != nil {
-		return
-	}
 	pullRequestTitle, prBody, err := cfp.preparePullRequestDetails(vulnDetails)
 	if err != nil {
 		return
 	}
+	if cfp.planMode {
+		return cfp.addPlannedPullRequest(fixBranchName, commitMessage, pullRequestTitle, outdatedPullRequest != nil)
+	}
+	if err = cfp.gitManager.Push(outdatedPullRequest != nil, fixBranchName); err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+	}
+	if outdatedPullRequest != nil {
+		log.Debug("Updating Pull Request from:", fixBranchName, " to:", cfp.scanDetails.BaseBranch())
+		if err = cfp.scanDetails.Client().UpdatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, pullRequestTitle, prBody, outdatedPullRequest.Target.Name, int(outdatedPullRequest.ID), vcsutils.Open); err != nil {
+			return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+		}
+		cfp.addPullRequestToSummary(utils.PullRequestUpdated, fixBranchName, outdatedPullRequest)
+		return
+	}
 	log.Debug("Creating Pull Request form:", fixBranchName, " to:", cfp.scanDetails.BaseBranch())
-	return cfp.scanDetails.Client().CreatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, fixBranchName, cfp.scanDetails.BaseBranch(), pullRequestTitle, prBody)
+	if err = cfp.scanDetails.Client().CreatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, fixBranchName, cfp.scanDetails.BaseBranch(), pullRequestTitle, prBody); err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+	}
+	cfp.addPullRequestToSummary(utils.PullRequestCreated, fixBranchName, nil)
+	return
 }
 
 // openAggregatedPullRequest handles the opening or updating of a pull request when the aggregate mode is active.
@@ -342,19 +421,71 @@ func (cfp *ScanRepositoryCmd) openAggregatedPullRequest(fixBranchName string, pu
 	if err = cfp.gitManager.AddAllAndCommit(commitMessage); err != nil {
 		return
 	}
-	if err = cfp.gitManager.Push(true, fixBranchName); err != nil {
-		return
-	}
 	pullRequestTitle, prBody, err := cfp.preparePullRequestDetails(vulnerabilities...)
 	if err != nil {
 		return
 	}
+	if cfp.planMode {
+		return cfp.addPlannedPullRequest(fixBranchName, commitMessage, pullRequestTitle, pullRequestInfo != nil)
+	}
+	if err = cfp.gitManager.Push(true, fixBranchName); err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+	}
 	if pullRequestInfo == nil {
 		log.Info("Creating Pull Request from:", fixBranchName, "to:", cfp.scanDetails.BaseBranch())
-		return cfp.scanDetails.Client().CreatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, fixBranchName, cfp.scanDetails.BaseBranch(), pullRequestTitle, prBody)
+		if err = cfp.scanDetails.Client().CreatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, fixBranchName, cfp.scanDetails.BaseBranch(), pullRequestTitle, prBody); err != nil {
+			return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+		}
+		cfp.addPullRequestToSummary(utils.PullRequestCreated, fixBranchName, nil)
+		return
 	}
 	log.Info("Updating Pull Request from:", fixBranchName, "to:", cfp.scanDetails.BaseBranch())
-	return cfp.scanDetails.Client().UpdatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, pullRequestTitle, prBody, pullRequestInfo.Target.Name, int(pullRequestInfo.ID), vcsutils.Open)
+	if err = cfp.scanDetails.Client().UpdatePullRequest(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName, pullRequestTitle, prBody, pullRequestInfo.Target.Name, int(pullRequestInfo.ID), vcsutils.Open); err != nil {
+		return utils.WithExitCode(err, utils.ExitCodeVcsApiError)
+	}
+	cfp.addPullRequestToSummary(utils.PullRequestUpdated, fixBranchName, pullRequestInfo)
+	return
+}
+
+// Records the created or updated pull request in the run summary.
+// The Git providers don't return the created pull request, therefore its URL is retrieved by listing the open pull requests.
+func (cfp *ScanRepositoryCmd) addPullRequestToSummary(action, fixBranchName string, pullRequestInfo *vcsclient.PullRequestInfo) {
+	if cfp.scanSummary == nil {
+		return
+	}
+	if pullRequestInfo == nil {
+		var err error
+		if pullRequestInfo, err = cfp.getOpenPullRequestBySourceBranch(fixBranchName); err != nil {
+			log.Debug("Couldn't get the URL of the pull request from", fixBranchName+":", err.Error())
+		}
+	}
+	var url string
+	if pullRequestInfo != nil {
+		url = pullRequestInfo.URL
+	}
+	cfp.scanSummary.AddPullRequest(action, fixBranchName, url)
+}
+
+// Records the pull request that would have been created from the fix commit, instead of pushing it.
+func (cfp *ScanRepositoryCmd) addPlannedPullRequest(fixBranchName, commitMessage, pullRequestTitle string, update bool) error {
+	diff, err := cfp.gitManager.GetHeadCommitDiff()
+	if err != nil {
+		return err
+	}
+	log.Info("Plan mode is enabled. Skipping the push of branch", fixBranchName, "and the pull request creation")
+	action := planCreate
+	if update {
+		action = planUpdate
+	}
+	cfp.plannedPullRequests = append(cfp.plannedPullRequests, plannedPullRequest{
+		action:        action,
+		baseBranch:    cfp.scanDetails.BaseBranch(),
+		fixBranch:     fixBranchName,
+		commitMessage: commitMessage,
+		title:         pullRequestTitle,
+		diff:          diff,
+	})
+	return nil
 }
 
 func (cfp *ScanRepositoryCmd) preparePullRequestDetails(vulnerabilitiesDetails ...*utils.VulnerabilityDetails) (prTitle string, prBody string, err error) {
@@ -374,7 +505,7 @@ func (cfp *ScanRepositoryCmd) preparePullRequestDetails(vulnerabilitiesDetails .
 	// In separate pull requests there is only one vulnerability
 	vulnDetails := vulnerabilitiesDetails[0]
 	pullRequestTitle := cfp.gitManager.GeneratePullRequestTitle(vulnDetails.ImpactedDependencyName, vulnDetails.SuggestedFixedVersion)
-	return pullRequestTitle, prBody, nil
+	return pullRequestTitle, prBody + getFixedDependencyMarker(vulnDetails.ImpactedDependencyName, vulnDetails.SuggestedFixedVersion), nil
 }
 
 func (cfp *ScanRepositoryCmd) cloneRepositoryAndCheckoutToBranch() (tempWd string, restoreDir func() error, err error) {
@@ -390,6 +521,7 @@ func (cfp *ScanRepositoryCmd) cloneRepositoryAndCheckoutToBranch() (tempWd strin
 
 	// Clone the content of the repo to the new working directory
 	if err = cfp.gitManager.Clone(tempWd, cfp.scanDetails.BaseBranch()); err != nil {
+		err = utils.WithExitCode(err, utils.ExitCodeVcsApiError)
 		return
 	}
 
@@ -431,13 +563,13 @@ func (cfp *ScanRepositoryCmd) createVulnerabilitiesMap(scanResults *xrayutils.Ex
 }
 
 func (cfp *ScanRepositoryCmd) addVulnerabilityToFixVersionsMap(vulnerability *formats.VulnerabilityOrViolationRow, vulnerabilitiesMap map[string]*utils.VulnerabilityDetails) error {
-	if len(vulnerability.FixedVersions) == 0 {
+	if len(vulnerability.FixedVersions) == 0 || cfp.ignoreFile.IgnoresVulnerability(vulnerability) {
 		return nil
 	}
 	if len(cfp.projectTech) == 0 {
 		cfp.projectTech = []coreutils.Technology{vulnerability.Technology}
 	}
-	vulnFixVersion := getMinimalFixVersion(vulnerability.ImpactedDependencyVersion, vulnerability.FixedVersions)
+	vulnFixVersion := getMinimalFixVersion(vulnerability.ImpactedDependencyVersion, vulnerability.FixedVersions, cfp.fixStrategy)
 	if vulnFixVersion == "" {
 		return nil
 	}
@@ -500,6 +632,7 @@ func (cfp *ScanRepositoryCmd) getRemoteBranchScanHash(prBody string) string {
 func (cfp *ScanRepositoryCmd) getOpenPullRequestBySourceBranch(branchName string) (prInfo *vcsclient.PullRequestInfo, err error) {
 	list, err := cfp.scanDetails.Client().ListOpenPullRequestsWithBody(context.Background(), cfp.scanDetails.RepoOwner, cfp.scanDetails.RepoName)
 	if err != nil {
+		err = utils.WithExitCode(err, utils.ExitCodeVcsApiError)
 		return
 	}
 	for _, pr := range list {
@@ -523,7 +656,7 @@ func (cfp *ScanRepositoryCmd) aggregateFixAndOpenPullRequest(vulnerabilitiesMap
 	for fullPath, vulnerabilities := range vulnerabilitiesMap {
 		currentFixes, e := cfp.fixMultiplePackages(fullPath, vulnerabilities)
 		if e != nil {
-			err = errors.Join(err, fmt.Errorf("the following errors occured while fixing vulnerabilities in %s:\n%s", fullPath, e))
+			err = errors.Join(err, utils.WithExitCode(fmt.Errorf("the following errors occured while fixing vulnerabilities in %s:\n%w", fullPath, e), utils.ExitCodePartialFixFailure))
 			continue
 		}
 		fixedVulnerabilities = append(fixedVulnerabilities, currentFixes...)
@@ -539,7 +672,7 @@ func (cfp *ScanRepositoryCmd) aggregateFixAndOpenPullRequest(vulnerabilitiesMap
 	}
 	if len(fixedVulnerabilities) > 0 {
 		if e = cfp.openAggregatedPullRequest(aggregatedFixBranchName, existingPullRequestInfo, fixedVulnerabilities); e != nil {
-			err = errors.Join(err, fmt.Errorf("failed while creating aggreagted pull request. Error: \n%s", e.Error()))
+			err = errors.Join(err, fmt.Errorf("failed while creating aggreagted pull request. Error: \n%w", e))
 		}
 	}
 	log.Info("-----------------------------------------------------------------")
@@ -570,20 +703,48 @@ func (cfp *ScanRepositoryCmd) isUpdateRequired(fixedVulnerabilities []*utils.Vul
 }
 
 // getMinimalFixVersion find the minimal version that fixes the current impactedPackage;
-// fixVersions is a sorted array. The function returns the first version in the array, that is larger than impactedPackageVersion.
-func getMinimalFixVersion(impactedPackageVersion string, fixVersions []string) string {
+// fixVersions is a sorted array. The function returns the first version in the array, that is larger than impactedPackageVersion and allowed by the fix strategy.
+func getMinimalFixVersion(impactedPackageVersion string, fixVersions []string, fixStrategy string) string {
 	// Trim 'v' prefix in case of Go package
 	currVersionStr := strings.TrimPrefix(impactedPackageVersion, "v")
 	currVersion := version.NewVersion(currVersionStr)
 	for _, fixVersion := range fixVersions {
 		fixVersionCandidate := parseVersionChangeString(fixVersion)
-		if currVersion.Compare(fixVersionCandidate) > 0 {
+		if currVersion.Compare(fixVersionCandidate) > 0 && isAllowedByFixStrategy(currVersionStr, strings.TrimPrefix(fixVersionCandidate, "v"), fixStrategy) {
 			return fixVersionCandidate
 		}
 	}
 	return ""
 }
 
+// A minor fix strategy allows fix versions with the same major version, and a patch fix strategy allows fix versions with the same major and minor versions.
+func isAllowedByFixStrategy(currVersion, fixVersion, fixStrategy string) bool {
+	var sameSegments int
+	switch fixStrategy {
+	case utils.FixStrategyMinor:
+		sameSegments = 1
+	case utils.FixStrategyPatch:
+		sameSegments = 2
+	default:
+		return true
+	}
+	currSegments := strings.Split(currVersion, ".")
+	fixSegments := strings.Split(fixVersion, ".")
+	for i := 0; i < sameSegments; i++ {
+		currSegment, fixSegment := "0", "0"
+		if i < len(currSegments) {
+			currSegment = currSegments[i]
+		}
+		if i < len(fixSegments) {
+			fixSegment = fixSegments[i]
+		}
+		if currSegment != fixSegment {
+			return false
+		}
+	}
+	return true
+}
+
 // 1.0         --> 1.0 ≤ x
 // (,1.0]      --> x ≤ 1.0
 // (,1.0)      --> x < 1.0
@@ -406,6 +406,7 @@ func TestGetMinimalFixVersion(t *testing.T) {
 	tests := []struct {
 		impactedVersionPackage string
 		fixVersions            []string
+		fixStrategy            string
 		expected               string
 	}{
 		{impactedVersionPackage: "1.6.2", fixVersions: []string{"1.5.3", "1.6.1", "1.6.22", "1.7.0"}, expected: "1.6.22"},
@@ -413,10 +414,16 @@ func TestGetMinimalFixVersion(t *testing.T) {
 		{impactedVersionPackage: "1.7.1", fixVersions: []string{"1.5.3", "1.6.1", "1.6.22", "1.7.0"}, expected: ""},
 		{impactedVersionPackage: "1.7.1", fixVersions: []string{"2.5.3"}, expected: "2.5.3"},
 		{impactedVersionPackage: "v1.7.1", fixVersions: []string{"0.5.3", "0.9.9"}, expected: ""},
+		{impactedVersionPackage: "1.7.1", fixVersions: []string{"2.5.3"}, fixStrategy: utils.FixStrategyAny, expected: "2.5.3"},
+		{impactedVersionPackage: "1.7.1", fixVersions: []string{"1.8.0", "2.5.3"}, fixStrategy: utils.FixStrategyMinor, expected: "1.8.0"},
+		{impactedVersionPackage: "1.7.1", fixVersions: []string{"2.5.3"}, fixStrategy: utils.FixStrategyMinor, expected: ""},
+		{impactedVersionPackage: "v1.7.1", fixVersions: []string{"[1.7.4]", "1.8.0"}, fixStrategy: utils.FixStrategyPatch, expected: "1.7.4"},
+		{impactedVersionPackage: "1.7", fixVersions: []string{"1.7.4"}, fixStrategy: utils.FixStrategyPatch, expected: "1.7.4"},
+		{impactedVersionPackage: "1.7.1", fixVersions: []string{"1.8.0"}, fixStrategy: utils.FixStrategyPatch, expected: ""},
 	}
 	for _, test := range tests {
 		t.Run(test.expected, func(t *testing.T) {
-			expected := getMinimalFixVersion(test.impactedVersionPackage, test.fixVersions)
+			expected := getMinimalFixVersion(test.impactedVersionPackage, test.fixVersions, test.fixStrategy)
 			assert.Equal(t, test.expected, expected)
 		})
 	}
@@ -679,7 +686,7 @@ func TestPreparePullRequestDetails(t *testing.T) {
 			SuggestedFixedVersion: "1.0.0",
 		},
 	}
-	expectedPrBody := "<div align='center'>\n\n[![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/vulnerabilitiesFixBannerPR.png)](https://github.com/jfrog/frogbot#readme)\n\n</div>\n\n\n\n## 📦 Vulnerable Dependencies\n\n### ✍️ Summary\n\n<div align=\"center\">\n\n\n| SEVERITY                | DIRECT DEPENDENCIES                  | IMPACTED DEPENDENCY                   | FIXED VERSIONS                       | CVES                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High |  | package1:1.0.0 | 1.0.0<br>2.0.0 | CVE-2022-1234 |\n\n</div>\n\n## 🔬 Research Details\n\n\n**Description:**\nsummary\n\n\n---\n<div align=\"center\">\n\n[🐸 JFrog Frogbot](https://github.com/jfrog/frogbot#readme)\n\n</div>"
+	expectedPrBody := "<div align='center'>\n\n[![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/vulnerabilitiesFixBannerPR.png)](https://github.com/jfrog/frogbot#readme)\n\n</div>\n\n\n\n## 📦 Vulnerable Dependencies\n\n### ✍️ Summary\n\n<div align=\"center\">\n\n\n| SEVERITY                | DIRECT DEPENDENCIES                  | IMPACTED DEPENDENCY                   | FIXED VERSIONS                       | CVES                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High |  | package1:1.0.0 | 1.0.0<br>2.0.0 | CVE-2022-1234 |\n\n</div>\n\n## 🔬 Research Details\n\n\n**Description:**\nsummary\n\n\n---\n<div align=\"center\">\n\n[🐸 JFrog Frogbot](https://github.com/jfrog/frogbot#readme)\n\n</div>\n\n[comment]: <> (Fixed dependency: package1 1.0.0)\n"
 	prTitle, prBody, err := cfp.preparePullRequestDetails(vulnerabilities...)
 	assert.NoError(t, err)
 	assert.Equal(t, "[🐸 Frogbot] Update version of package1 to 1.0.0", prTitle)
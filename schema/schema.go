@@ -0,0 +1,10 @@
+package schema
+
+import (
+	_ "embed"
+)
+
+// FrogbotSchema is the JSON schema of the frogbot-config.yml file
+//
+//go:embed frogbot-schema.json
+var FrogbotSchema []byte
@@ -39,10 +39,12 @@ func TestBadFrogbotSchemas(t *testing.T) {
 		errorString string
 	}{
 		{"additional-prop", "Additional property additionalProp is not allowed"},
-		{"no-array", "Expected: array, given: object"},
+		{"no-array", "Additional property params is not allowed"},
 		{"no-git", "git is required"},
 		{"no-repo", "repoName is required"},
 		{"empty-repo", "Expected: string, given: null"},
+		{"defaults-no-repo", "repoName is required"},
+		{"defaults-additional-prop", "Additional property additionalProp is not allowed"},
 	}
 	for _, testCase := range testCases {
 		validateYamlSchema(t, schemaLoader, filepath.Join("testdata", testCase.testName+".yml"), testCase.errorString)
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// The audit of jfrog-cli-core changes the working directory of the process, so audits that run concurrently run in subprocesses of Frogbot.
+// The subprocess is the Frogbot executable with this environment variable set to the path of the file the audit results are written to.
+// The audit request is read from the standard input of the subprocess, so that the credentials aren't exposed in the process list.
+const auditSubprocessEnv = "JF_FROGBOT_AUDIT_RESULTS_FILE"
+
+// Replaced in the tests, to audit without Xray
+var runAudit = audit.RunAudit
+
+// The inputs of the audit, which are sent to the subprocess
+type auditRequest struct {
+	WorkingDirs         []string                      `json:"workingDirs"`
+	ServerDetails       *config.ServerDetails         `json:"serverDetails,omitempty"`
+	InsecureTls         bool                          `json:"insecureTls,omitempty"`
+	XrayGraphScanParams *services.XrayGraphScanParams `json:"xrayGraphScanParams,omitempty"`
+	PipRequirementsFile string                        `json:"pipRequirementsFile,omitempty"`
+	UseWrapper          bool                          `json:"useWrapper,omitempty"`
+	DepsRepo            string                        `json:"depsRepo,omitempty"`
+	MinSeverityFilter   string                        `json:"minSeverityFilter,omitempty"`
+	FixableOnly         bool                          `json:"fixableOnly,omitempty"`
+}
+
+func (ar *auditRequest) auditParams() *audit.AuditParams {
+	auditBasicParams := (&xrayutils.AuditBasicParams{}).
+		SetPipRequirementsFile(ar.PipRequirementsFile).
+		SetUseWrapper(ar.UseWrapper).
+		SetDepsRepo(ar.DepsRepo).
+		SetIgnoreConfigFile(true).
+		SetServerDetails(ar.ServerDetails)
+
+	return audit.NewAuditParams().
+		SetXrayGraphScanParams(ar.XrayGraphScanParams).
+		SetWorkingDirs(ar.WorkingDirs).
+		SetMinSeverityFilter(ar.MinSeverityFilter).
+		SetFixableOnly(ar.FixableOnly).
+		SetGraphBasicParams(auditBasicParams)
+}
+
+// The results of the audit, which are sent back from the subprocess. The errors are sent as their messages.
+type auditResponse struct {
+	IsMultipleRootProject bool                           `json:"isMultipleRootProject,omitempty"`
+	ScaError              string                         `json:"scaError,omitempty"`
+	JasError              string                         `json:"jasError,omitempty"`
+	ExtendedScanResults   *xrayutils.ExtendedScanResults `json:"extendedScanResults,omitempty"`
+	Error                 string                         `json:"error,omitempty"`
+}
+
+// RunAuditSubprocessIfRequested runs the requested audit and exits, if the process is an audit subprocess of Frogbot.
+// It's called at the beginning of main, and of TestMain in the packages whose tests audit in subprocesses.
+func RunAuditSubprocessIfRequested() {
+	resultsFile := os.Getenv(auditSubprocessEnv)
+	if resultsFile == "" {
+		return
+	}
+	if err := runRequestedAudit(os.Stdin, resultsFile); err != nil {
+		log.Error(err.Error())
+		os.Exit(int(ExitCodeAuditError))
+	}
+	os.Exit(0)
+}
+
+// Runs the audit read from the request, and writes its results to the results file
+func runRequestedAudit(request io.Reader, resultsFile string) error {
+	auditReq := &auditRequest{}
+	if err := json.NewDecoder(request).Decode(auditReq); err != nil {
+		return fmt.Errorf("failed to read the audit request: %w", err)
+	}
+	if auditReq.ServerDetails != nil {
+		auditReq.ServerDetails.InsecureTls = auditReq.InsecureTls
+	}
+	auditResults, err := runAudit(auditReq.auditParams())
+	response := &auditResponse{Error: errorMessage(err)}
+	if auditResults != nil {
+		response.IsMultipleRootProject = auditResults.IsMultipleRootProject
+		response.ScaError = errorMessage(auditResults.ScaError)
+		response.JasError = errorMessage(auditResults.JasError)
+		response.ExtendedScanResults = auditResults.ExtendedScanResults
+	}
+	content, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(resultsFile, content, 0600)
+}
+
+// Runs the audit in a subprocess of Frogbot, which has its own working directory, so that several audits can run at the same time
+func runAuditInSubprocess(auditReq *auditRequest) (auditResults *audit.Results, err error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return
+	}
+	request, err := json.Marshal(auditReq)
+	if err != nil {
+		return
+	}
+	resultsDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(resultsDir))
+	}()
+	resultsFile := filepath.Join(resultsDir, "results.json")
+
+	//#nosec G204 -- The subprocess is the running Frogbot executable.
+	auditCmd := exec.Command(executable)
+	auditCmd.Env = append(os.Environ(), auditSubprocessEnv+"="+resultsFile)
+	auditCmd.Stdin = bytes.NewReader(request)
+	auditCmd.Stdout = os.Stdout
+	auditCmd.Stderr = os.Stderr
+	if err = auditCmd.Run(); err != nil {
+		return nil, fmt.Errorf("the audit subprocess of %s failed: %w", auditReq.WorkingDirs, err)
+	}
+
+	content, err := os.ReadFile(resultsFile)
+	if err != nil {
+		return
+	}
+	response := &auditResponse{}
+	if err = json.Unmarshal(content, response); err != nil {
+		return
+	}
+	auditResults = &audit.Results{
+		IsMultipleRootProject: response.IsMultipleRootProject,
+		ScaError:              messageError(response.ScaError),
+		JasError:              messageError(response.JasError),
+		ExtendedScanResults:   response.ExtendedScanResults,
+	}
+	if auditResults.ExtendedScanResults == nil {
+		auditResults.ExtendedScanResults = &xrayutils.ExtendedScanResults{}
+	}
+	return auditResults, messageError(response.Error)
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func messageError(message string) error {
+	if message == "" {
+		return nil
+	}
+	return errors.New(message)
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// The audits of TestRunInstallAndAuditInSubprocessesConcurrently run in subprocesses of the test binary, without Xray
+	if os.Getenv(auditSubprocessEnv) != "" {
+		runAudit = auditWithOtherBranch
+	}
+	RunAuditSubprocessIfRequested()
+	os.Exit(m.Run())
+}
+
+// Changes the working directory of the process to the branch, like the audit of jfrog-cli-core, and waits for the audit of the other branch to start.
+// It fails if the branches are audited one at a time, or if the working directory is changed by the other audit.
+func auditWithOtherBranch(params *audit.AuditParams) (*audit.Results, error) {
+	workingDir := params.WorkingDirs()[0]
+	if err := os.Chdir(workingDir); err != nil {
+		return nil, err
+	}
+	branchesDir := filepath.Dir(workingDir)
+	if err := os.WriteFile(filepath.Join(branchesDir, filepath.Base(workingDir)+".started"), nil, 0600); err != nil {
+		return nil, err
+	}
+	for i := 0; !fileExists(filepath.Join(branchesDir, "source.started")) || !fileExists(filepath.Join(branchesDir, "target.started")); i++ {
+		if i == 50 {
+			return nil, errors.New("the audit of the other branch didn't start")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	currentWd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if currentWd != workingDir {
+		return nil, errors.New("the working directory was changed to " + currentWd)
+	}
+	results := audit.NewAuditResults()
+	results.ExtendedScanResults.SastScanResults = []*sarif.Run{sarif.NewRunWithInformationURI(filepath.Base(workingDir), "")}
+	return results, nil
+}
+
+func fileExists(filePath string) bool {
+	_, err := os.Stat(filePath)
+	return err == nil
+}
+
+func TestRunInstallAndAuditInSubprocessesConcurrently(t *testing.T) {
+	branchesDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	branches := []string{"source", "target"}
+	results := make([]*audit.Results, len(branches))
+	var auditTasks []func() error
+	for i, branch := range branches {
+		i := i
+		workDir := filepath.Join(branchesDir, branch)
+		require.NoError(t, os.Mkdir(workDir, 0755))
+		useWrapper := false
+		scanDetails := NewScanDetails(nil, &config.ServerDetails{Url: "https://acme.jfrog.io/", AccessToken: "token"}, nil).
+			SetProject(&Project{UseWrapper: &useWrapper}).
+			SetAuditInSubprocess(true)
+		auditTasks = append(auditTasks, func() (auditErr error) {
+			results[i], auditErr = scanDetails.RunInstallAndAudit(workDir)
+			return
+		})
+	}
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, RunConcurrently(len(branches), auditTasks...))
+
+	// The results of each branch are read back from its own subprocess
+	for i, branch := range branches {
+		require.Len(t, results[i].ExtendedScanResults.SastScanResults, 1)
+		assert.Equal(t, branch, results[i].ExtendedScanResults.SastScanResults[0].Tool.Driver.Name)
+	}
+	// The audits change the working directories of the subprocesses only
+	currentWd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, wd, currentWd)
+}
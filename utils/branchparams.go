@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"fmt"
+
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// The fix strategies limit the fix versions Frogbot upgrades the vulnerable dependencies to
+const (
+	// Upgrade to the minimal fix version, regardless of its major version
+	FixStrategyAny = "any"
+	// Upgrade only to fix versions with the same major version
+	FixStrategyMinor = "minor"
+	// Upgrade only to fix versions with the same major and minor versions
+	FixStrategyPatch = "patch"
+)
+
+var fixStrategies = []string{FixStrategyAny, FixStrategyMinor, FixStrategyPatch}
+
+// An entry of the branches list.
+// The entry is either the name of the branch, or an object with the name of the branch and the parameters it overrides.
+type Branch struct {
+	Name         string `yaml:"name"`
+	BranchParams `yaml:",inline"`
+}
+
+// The parameters of the repository that a branch can override.
+// For example, release branches may accept patch-level fixes only, without aggregating the fixes.
+type BranchParams struct {
+	AggregateFixes           *bool  `yaml:"aggregateFixes,omitempty"`
+	BranchNameTemplate       string `yaml:"branchNameTemplate,omitempty"`
+	CommitMessageTemplate    string `yaml:"commitMessageTemplate,omitempty"`
+	PullRequestTitleTemplate string `yaml:"pullRequestTitleTemplate,omitempty"`
+	MinSeverity              string `yaml:"minSeverity,omitempty"`
+	FixStrategy              string `yaml:"fixStrategy,omitempty"`
+}
+
+func (b *Branch) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		b.Name = value.Value
+		return nil
+	}
+	type branchFields Branch
+	return value.Decode((*branchFields)(b))
+}
+
+// Has the fields of Repository, without its YAML methods
+type repositoryFields Repository
+
+// UnmarshalYAML reads the branches list of the repository, in which each entry is either the name of a branch or a Branch object.
+// The names of the branches are set in Branches, and the parameters overridden by the branches in BranchOverrides.
+func (r *Repository) UnmarshalYAML(value *yaml.Node) error {
+	paramsNode := getMappingValue(value, "params")
+	gitNode := getMappingValue(paramsNode, "git")
+	branchesNode := getMappingValue(gitNode, "branches")
+	if branchesNode == nil || branchesNode.Kind != yaml.SequenceNode {
+		return value.Decode((*repositoryFields)(r))
+	}
+	var branches []Branch
+	if err := branchesNode.Decode(&branches); err != nil {
+		return err
+	}
+	branchNames := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	branchOverrides := map[string]BranchParams{}
+	for _, branch := range branches {
+		branchNames.Content = append(branchNames.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: branch.Name})
+		if branch.BranchParams != (BranchParams{}) {
+			branchOverrides[branch.Name] = branch.BranchParams
+		}
+	}
+	// The document isn't modified, the repository is decoded from a copy in which the branches list includes the names only
+	repositoryNode := withMappingValue(value, "params", withMappingValue(paramsNode, "git", withMappingValue(gitNode, "branches", branchNames)))
+	if err := repositoryNode.Decode((*repositoryFields)(r)); err != nil {
+		return err
+	}
+	if len(branchOverrides) > 0 {
+		r.BranchOverrides = branchOverrides
+	}
+	return nil
+}
+
+// MarshalYAML writes the branches that override parameters of the repository as Branch objects.
+func (r Repository) MarshalYAML() (interface{}, error) {
+	if len(r.BranchOverrides) == 0 {
+		return repositoryFields(r), nil
+	}
+	repositoryNode := &yaml.Node{}
+	if err := repositoryNode.Encode(repositoryFields(r)); err != nil {
+		return nil, err
+	}
+	branches := make([]interface{}, 0, len(r.Branches))
+	for _, branchName := range r.Branches {
+		if overrides, exists := r.BranchOverrides[branchName]; exists {
+			branches = append(branches, Branch{Name: branchName, BranchParams: overrides})
+			continue
+		}
+		branches = append(branches, branchName)
+	}
+	if branchesNode := getMappingValue(getMappingValue(getMappingValue(repositoryNode, "params"), "git"), "branches"); branchesNode != nil {
+		if err := branchesNode.Encode(branches); err != nil {
+			return nil, err
+		}
+	}
+	return repositoryNode, nil
+}
+
+// Returns the value of the key in the mapping node, or nil if the node isn't a mapping or doesn't include the key
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	// The content of a mapping node is a list of keys, each followed by its value
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Returns a copy of the mapping node, in which the value of the key is replaced
+func withMappingValue(node *yaml.Node, key string, value *yaml.Node) *yaml.Node {
+	nodeCopy := *node
+	nodeCopy.Content = slices.Clone(node.Content)
+	for i := 0; i+1 < len(nodeCopy.Content); i += 2 {
+		if nodeCopy.Content[i].Value == key {
+			nodeCopy.Content[i+1] = value
+		}
+	}
+	return &nodeCopy
+}
+
+func (g *Git) validateBranchOverrides() (err error) {
+	for branchName, overrides := range g.BranchOverrides {
+		if err = validateHashPlaceHolder(overrides.BranchNameTemplate); err != nil {
+			return fmt.Errorf("invalid parameters of the %s branch: %w", branchName, err)
+		}
+		if err = validateFixStrategy(overrides.FixStrategy); err != nil {
+			return fmt.Errorf("invalid parameters of the %s branch: %w", branchName, err)
+		}
+		if overrides.MinSeverity != "" {
+			if overrides.MinSeverity, err = xrutils.GetSeveritiesFormat(overrides.MinSeverity); err != nil {
+				return fmt.Errorf("invalid parameters of the %s branch: %w", branchName, err)
+			}
+			g.BranchOverrides[branchName] = overrides
+		}
+	}
+	return
+}
+
+func validateFixStrategy(fixStrategy string) error {
+	if fixStrategy != "" && !slices.Contains(fixStrategies, fixStrategy) {
+		return fmt.Errorf("the fix strategy should be one of: %v. received: %s", fixStrategies, fixStrategy)
+	}
+	return nil
+}
+
+// GetBranchRepository returns a copy of the repository, with the parameters overridden by the branch applied.
+// The scan parameters overridden by a project take precedence over the parameters overridden by the branch.
+func (r *Repository) GetBranchRepository(branch string) *Repository {
+	branchRepository := *r
+	overrides, exists := r.BranchOverrides[branch]
+	if !exists {
+		return &branchRepository
+	}
+	if overrides.AggregateFixes != nil {
+		branchRepository.AggregateFixes = *overrides.AggregateFixes
+	}
+	if overrides.BranchNameTemplate != "" {
+		branchRepository.BranchNameTemplate = overrides.BranchNameTemplate
+	}
+	if overrides.CommitMessageTemplate != "" {
+		branchRepository.CommitMessageTemplate = overrides.CommitMessageTemplate
+	}
+	if overrides.PullRequestTitleTemplate != "" {
+		branchRepository.PullRequestTitleTemplate = overrides.PullRequestTitleTemplate
+	}
+	if overrides.MinSeverity != "" {
+		branchRepository.MinSeverity = overrides.MinSeverity
+	}
+	if overrides.FixStrategy != "" {
+		branchRepository.FixStrategy = overrides.FixStrategy
+	}
+	return &branchRepository
+}
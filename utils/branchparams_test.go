@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildRepoAggregatorWithBranchOverrides(t *testing.T) {
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+	configFileContent, err := os.ReadFile(filepath.Join("..", "testdata", "config", "frogbot-config-branch-overrides.yml"))
+	require.NoError(t, err)
+	repoAggregator, err := BuildRepoAggregator(configFileContent, &Git{RepoOwner: "jfrog"}, &config.ServerDetails{}, ScanRepository)
+	require.NoError(t, err)
+	repo := &repoAggregator[0]
+	assert.Equal(t, []string{"master", "release/1.x"}, repo.Branches)
+	require.Len(t, repo.BranchOverrides, 1)
+	assert.Equal(t, "High", repo.BranchOverrides["release/1.x"].MinSeverity)
+
+	// A branch without overrides uses the parameters of the repository
+	masterRepo := repo.GetBranchRepository("master")
+	assert.True(t, masterRepo.AggregateFixes)
+	assert.Equal(t, FixStrategyMinor, masterRepo.FixStrategy)
+	assert.Empty(t, masterRepo.MinSeverity)
+	assert.Empty(t, masterRepo.PullRequestTitleTemplate)
+
+	releaseRepo := repo.GetBranchRepository("release/1.x")
+	assert.False(t, releaseRepo.AggregateFixes)
+	assert.Equal(t, FixStrategyPatch, releaseRepo.FixStrategy)
+	assert.Equal(t, "High", releaseRepo.MinSeverity)
+	assert.Equal(t, "[release/1.x] Upgrade {IMPACTED_PACKAGE} to {FIX_VERSION}", releaseRepo.PullRequestTitleTemplate)
+	// The parameters overridden by a project take precedence over the parameters overridden by the branch
+	assert.Equal(t, "Critical", releaseRepo.Projects[0].GetScanPolicy(&releaseRepo.Scan).MinSeverity)
+	// The repository isn't modified
+	assert.True(t, repo.AggregateFixes)
+
+	// The branches are written back with their overrides
+	content, err := yaml.Marshal(repoAggregator)
+	require.NoError(t, err)
+	var marshalledAggregator RepoAggregator
+	require.NoError(t, yaml.Unmarshal(content, &marshalledAggregator))
+	assert.Equal(t, repo.Branches, marshalledAggregator[0].Branches)
+	assert.Equal(t, repo.BranchOverrides, marshalledAggregator[0].BranchOverrides)
+}
+
+func TestBranchOverridesErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		branch        string
+		expectedError string
+	}{
+		{name: "Invalid fix strategy", branch: "{name: dev, fixStrategy: major}", expectedError: "0.params.git.branches.0.fixStrategy must be one of the following: \"any\", \"minor\", \"patch\""},
+		{name: "Invalid branch name template", branch: "{name: dev, branchNameTemplate: frogbot}", expectedError: "invalid parameters of the dev branch: branch name template must contain {BRANCH_NAME_HASH}"},
+		{name: "Invalid min severity", branch: "{name: dev, minSeverity: severe}", expectedError: "invalid parameters of the dev branch"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				assert.NoError(t, SanitizeEnv())
+			}()
+			configFileContent := []byte("- params:\n    git:\n      repoName: repo\n      branches: [" + test.branch + "]\n")
+			_, err := BuildRepoAggregator(configFileContent, &Git{RepoOwner: "jfrog"}, &config.ServerDetails{}, ScanRepository)
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"golang.org/x/exp/slices"
+)
+
+// The compare APIs of the Git providers return up to 300 changed files, so a list of this size may be partial
+const maxChangedFiles = 300
+
+var (
+	// Descriptor, lock and workspace files, which determine the dependencies of the projects in their directory and its subdirectories
+	descriptorFiles = []string{
+		"package.json", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", ".yarnrc.yml", ".npmrc", "pnpm-lock.yaml", "pnpm-workspace.yaml", "lerna.json",
+		"pom.xml", "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts", "gradle.properties",
+		"go.mod", "go.sum", "go.work", "go.work.sum",
+		"requirements.txt", "setup.py", "setup.cfg", "Pipfile", "Pipfile.lock", "pyproject.toml", "poetry.lock",
+		"packages.config", "nuget.config", "NuGet.Config", "Directory.Packages.props", "Directory.Build.props", "global.json",
+	}
+	descriptorFileExtensions = []string{".csproj", ".vbproj", ".fsproj", ".sln"}
+	// Documentation and image files, which don't affect the dependencies or the source code of the projects
+	documentationFileExtensions = []string{".md", ".markdown", ".rst", ".adoc", ".png", ".jpg", ".jpeg", ".gif", ".svg", ".ico"}
+)
+
+// ChangedFiles are the files changed by a pull request, which determine the working directories to scan.
+// A nil ChangedFiles means that the changed files are unknown, in which case all the working directories are scanned.
+type ChangedFiles struct {
+	// The paths of the files, relative to the root directory of the repository
+	paths []string
+}
+
+// GetPullRequestChangedFiles returns the files changed by the pull request, according to the Git provider.
+// Returns nil if the changed files can't be determined, for example, for pull requests opened from forks.
+func GetPullRequestChangedFiles(client vcsclient.VcsClient, pullRequestDetails *vcsclient.PullRequestInfo) *ChangedFiles {
+	source, target := pullRequestDetails.Source, pullRequestDetails.Target
+	if client == nil || source.Owner != target.Owner || source.Repository != target.Repository {
+		log.Info("The files changed by the pull request can't be compared in a single repository. All the working directories are scanned")
+		return nil
+	}
+	changedPaths, err := client.GetModifiedFiles(context.Background(), target.Owner, target.Repository, target.Name, source.Name)
+	if err != nil {
+		log.Info("Couldn't get the files changed by the pull request. All the working directories are scanned:", err.Error())
+		return nil
+	}
+	return NewChangedFiles(changedPaths)
+}
+
+// NewChangedFiles returns the changed files in the given paths, relative to the root directory of the repository.
+// Returns nil if the list is empty or may be partial.
+func NewChangedFiles(changedPaths []string) *ChangedFiles {
+	if len(changedPaths) == 0 || len(changedPaths) >= maxChangedFiles {
+		log.Info(fmt.Sprintf("%d changed files were found. All the working directories are scanned", len(changedPaths)))
+		return nil
+	}
+	changedFiles := &ChangedFiles{}
+	for _, changedPath := range changedPaths {
+		changedFiles.paths = append(changedFiles.paths, path.Clean(strings.TrimPrefix(filepath.ToSlash(changedPath), "/")))
+	}
+	log.Debug("The files changed by the pull request:", strings.Join(changedFiles.paths, ", "))
+	return changedFiles
+}
+
+// GetAffectedWorkingDirs returns the working directories that should be scanned, out of the given working directories.
+// A working directory is affected by the descriptor and source files changed in it, and by the descriptor and lock files changed in its parent directories,
+// such as the lock file at the root of a monorepo. Documentation files don't affect any working directory, including the root directory.
+// A change in the Frogbot directory affects all the working directories.
+func (changedFiles *ChangedFiles) GetAffectedWorkingDirs(workingDirs []string) (affectedDirs []string) {
+	if changedFiles == nil {
+		return workingDirs
+	}
+	for _, workingDir := range workingDirs {
+		if changedFiles.affects(path.Clean(filepath.ToSlash(workingDir))) {
+			affectedDirs = append(affectedDirs, workingDir)
+		}
+	}
+	return
+}
+
+func (changedFiles *ChangedFiles) affects(workingDir string) bool {
+	for _, changedPath := range changedFiles.paths {
+		if isInDir(changedPath, frogbotConfigDir) {
+			return true
+		}
+		if isDescriptorFile(changedPath) && isInDir(workingDir, path.Dir(changedPath)) {
+			return true
+		}
+		if !isDocumentationFile(changedPath) && isInDir(changedPath, workingDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterScanResults keeps the IaC, Secrets and SAST findings that touch the changed files only.
+// A finding touches the changed files if one of its locations, or one of the locations in its code flows, is in a changed file.
+// For example, a SAST finding in an untouched file is kept if its data flows from a source in a changed file.
+// The paths of the findings are matched relative to the root directory of the scanned branch.
+// This is a post-filter: the JFrog Advanced Security scanners accept only working directories and exclude patterns, not a list of files,
+// so they still scan the whole working directories, and only their results are filtered.
+func (changedFiles *ChangedFiles) FilterScanResults(scanResults *xrayutils.ExtendedScanResults, repoDir string) {
+	if changedFiles == nil {
+		return
+	}
+	isChanged := func(location *sarif.Location) bool {
+		return slices.Contains(changedFiles.paths, filepath.ToSlash(xrayutils.ExtractRelativePath(xrayutils.GetLocationFileName(location), repoDir)))
+	}
+	filterSourceCodeResults(scanResults, func(result *sarif.Result) bool {
+		for _, location := range result.Locations {
+			if isChanged(location) {
+				return true
+			}
+		}
+		for _, codeFlow := range result.CodeFlows {
+			for _, threadFlow := range codeFlow.ThreadFlows {
+				for _, threadFlowLocation := range threadFlow.Locations {
+					if threadFlowLocation != nil && isChanged(threadFlowLocation.Location) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	})
+}
+
+// Keeps the IaC, Secrets and SAST results for which keep returns true
+func filterSourceCodeResults(scanResults *xrayutils.ExtendedScanResults, keep func(result *sarif.Result) bool) {
+	if scanResults == nil {
+		return
+	}
+	for _, runs := range [][]*sarif.Run{scanResults.IacScanResults, scanResults.SecretsScanResults, scanResults.SastScanResults} {
+		for _, run := range runs {
+			var results []*sarif.Result
+			for _, result := range run.Results {
+				if keep(result) {
+					results = append(results, result)
+				}
+			}
+			run.Results = results
+		}
+	}
+}
+
+// Returns true if the slash-separated path is the directory or is in the directory. Every path is in the root directory.
+func isInDir(filePath, dir string) bool {
+	return dir == RootDir || filePath == dir || strings.HasPrefix(filePath, dir+"/")
+}
+
+func isDescriptorFile(filePath string) bool {
+	fileName := path.Base(filePath)
+	return slices.Contains(descriptorFiles, fileName) || slices.Contains(descriptorFileExtensions, path.Ext(fileName))
+}
+
+func isDocumentationFile(filePath string) bool {
+	return slices.Contains(documentationFileExtensions, strings.ToLower(path.Ext(filePath)))
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jfrog/frogbot/testdata"
+	"github.com/jfrog/froggit-go/vcsclient"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAffectedWorkingDirs(t *testing.T) {
+	workingDirs := []string{".", "services/api", "services/web/", "tools"}
+	testCases := []struct {
+		name         string
+		changedPaths []string
+		expectedDirs []string
+		expectedNil  bool
+	}{
+		{name: "Documentation only", changedPaths: []string{"services/README.md", "docs/images/diagram.png"}},
+		{name: "Root documentation", changedPaths: []string{"README.md"}},
+		{name: "Root source file", changedPaths: []string{"main.go"}, expectedDirs: []string{"."}},
+		{name: "Root descriptor", changedPaths: []string{"go.mod"}, expectedDirs: workingDirs},
+		{name: "Source file", changedPaths: []string{"services/api/main.go"}, expectedDirs: []string{".", "services/api"}},
+		{name: "Descriptor in a subdirectory", changedPaths: []string{"services/web/src/package.json"}, expectedDirs: []string{".", "services/web/"}},
+		{name: "Descriptor in a parent directory", changedPaths: []string{"services/go.work"}, expectedDirs: []string{".", "services/api", "services/web/"}},
+		{name: "Root lock file", changedPaths: []string{"package-lock.json"}, expectedDirs: workingDirs},
+		{name: "Documentation and source files", changedPaths: []string{"README.md", "tools/build.sh"}, expectedDirs: []string{".", "tools"}},
+		{name: "Frogbot configuration", changedPaths: []string{".frogbot/ignore.yml"}, expectedDirs: workingDirs},
+		{name: "Similar directory name", changedPaths: []string{"/toolsets/main.go"}, expectedDirs: []string{"."}},
+		{name: "No changed files", changedPaths: []string{}, expectedDirs: workingDirs, expectedNil: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			changedFiles := NewChangedFiles(test.changedPaths)
+			assert.Equal(t, test.expectedNil, changedFiles == nil)
+			assert.Equal(t, test.expectedDirs, changedFiles.GetAffectedWorkingDirs(workingDirs))
+		})
+	}
+	// Without the root directory
+	assert.Empty(t, NewChangedFiles([]string{"README.md"}).GetAffectedWorkingDirs([]string{"services/api"}))
+	assert.Nil(t, NewChangedFiles(make([]string, maxChangedFiles)))
+}
+
+func TestGetPullRequestChangedFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := testdata.NewMockVcsClient(ctrl)
+	pullRequest := &vcsclient.PullRequestInfo{
+		Source: vcsclient.BranchInfo{Name: "feature", Repository: "frogbot", Owner: "jfrog"},
+		Target: vcsclient.BranchInfo{Name: "master", Repository: "frogbot", Owner: "jfrog"},
+	}
+	client.EXPECT().GetModifiedFiles(context.Background(), "jfrog", "frogbot", "master", "feature").Return([]string{"services/api/main.go"}, nil)
+	changedFiles := GetPullRequestChangedFiles(client, pullRequest)
+	require.NotNil(t, changedFiles)
+	assert.Equal(t, []string{"services/api/main.go"}, changedFiles.paths)
+
+	client.EXPECT().GetModifiedFiles(context.Background(), "jfrog", "frogbot", "master", "feature").Return(nil, errors.New("not found"))
+	assert.Nil(t, GetPullRequestChangedFiles(client, pullRequest))
+
+	// Pull requests from forks are fully scanned
+	pullRequest.Source.Owner = "contributor"
+	assert.Nil(t, GetPullRequestChangedFiles(client, pullRequest))
+}
+
+func TestChangedFilesFilterScanResults(t *testing.T) {
+	repoDir := filepath.Join(string(filepath.Separator), "tmp", "repo")
+	result := func(relativePath string) *sarif.Result {
+		return sarif.NewRuleResult("rule").WithLocations([]*sarif.Location{
+			sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().WithArtifactLocation(sarif.NewSimpleArtifactLocation(fmt.Sprintf("file://%s", filepath.ToSlash(filepath.Join(repoDir, relativePath)))))),
+		})
+	}
+	secretsRun := sarif.NewRunWithInformationURI("JFrog Secrets scanner", "").WithResults([]*sarif.Result{result("api/config.js"), result("web/config.js")})
+	scanResults := &xrayutils.ExtendedScanResults{SecretsScanResults: []*sarif.Run{secretsRun}}
+	NewChangedFiles([]string{"api/config.js"}).FilterScanResults(scanResults, repoDir)
+	require.Len(t, secretsRun.Results, 1)
+	assert.Equal(t, "api/config.js", xrayutils.ExtractRelativePath(xrayutils.GetLocationFileName(secretsRun.Results[0].Locations[0]), repoDir))
+
+	// A SAST finding in an untouched file is kept if its code flow passes through a changed file
+	location := func(relativePath string) *sarif.Location {
+		return result(relativePath).Locations[0]
+	}
+	dataFlowResult := result("web/render.js").WithCodeFlows([]*sarif.CodeFlow{
+		sarif.NewCodeFlow().WithThreadFlows([]*sarif.ThreadFlow{
+			sarif.NewThreadFlow().WithLocations([]*sarif.ThreadFlowLocation{
+				sarif.NewThreadFlowLocation().WithLocation(location("api/input.js")),
+				sarif.NewThreadFlowLocation().WithLocation(location("web/render.js")),
+			}),
+		}),
+	})
+	untouchedFlowResult := result("web/render.js").WithCodeFlows([]*sarif.CodeFlow{
+		sarif.NewCodeFlow().WithThreadFlows([]*sarif.ThreadFlow{
+			sarif.NewThreadFlow().WithLocations([]*sarif.ThreadFlowLocation{sarif.NewThreadFlowLocation().WithLocation(location("web/render.js"))}),
+		}),
+	})
+	sastRun := sarif.NewRunWithInformationURI("USAF", "").WithResults([]*sarif.Result{dataFlowResult, untouchedFlowResult})
+	NewChangedFiles([]string{"api/input.js"}).FilterScanResults(&xrayutils.ExtendedScanResults{SastScanResults: []*sarif.Run{sastRun}}, repoDir)
+	require.Len(t, sastRun.Results, 1)
+	assert.Same(t, dataFlowResult, sastRun.Results[0])
+
+	// Unknown changed files don't filter the results
+	var changedFiles *ChangedFiles
+	changedFiles.FilterScanResults(scanResults, repoDir)
+	assert.Len(t, secretsRun.Results, 1)
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The frogbot-config.yml file is either a list of repositories, or a mapping of repositories that share default parameters.
+// The defaults are deep-merged into the parameters of each repository. The parameters of the repository take precedence over the defaults.
+type frogbotConfigWithDefaults struct {
+	// A path to another configuration file in the same repository, relative to the directory of this file.
+	// The defaults of the extended file are merged into the defaults of this file.
+	Extends      string                   `yaml:"extends,omitempty"`
+	Defaults     map[string]interface{}   `yaml:"defaults,omitempty"`
+	Repositories []map[string]interface{} `yaml:"repositories,omitempty"`
+}
+
+// Reads a configuration file, extended by the frogbot-config.yml file.
+// The path of the file is relative to the directory of the frogbot-config.yml file.
+type extendedConfigReader func(extendedPath string) ([]byte, error)
+
+// Returns the configuration if the frogbot-config.yml document is a mapping of repositories that share default parameters.
+// Returns nil if the document is a list of repositories.
+func parseConfigWithDefaults(document *yaml.Node) (config *frogbotConfigWithDefaults, err error) {
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return
+	}
+	config = &frogbotConfigWithDefaults{}
+	err = document.Content[0].Decode(config)
+	return
+}
+
+// Validates the content of a frogbot-config.yml file and returns its configuration, without interpolating the values.
+// The values are interpolated once, when the resolved configuration is unmarshalled.
+func parseConfigContentWithDefaults(configFileContent []byte) (config *frogbotConfigWithDefaults, err error) {
+	// The schema is validated after the interpolation, which may change the types of the values
+	if _, err = loadConfigDocument(configFileContent); err != nil {
+		return
+	}
+	var document yaml.Node
+	if err = yaml.Unmarshal(configFileContent, &document); err != nil {
+		return
+	}
+	return parseConfigWithDefaults(&document)
+}
+
+// resolveConfigExtends merges the defaults of the files extended by the frogbot-config.yml file into its defaults.
+// The returned content doesn't extend other files. Content that doesn't extend other files is returned as is.
+func resolveConfigExtends(configFileContent []byte, readExtendedConfig extendedConfigReader) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(configFileContent, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s file: %s", FrogbotConfigFile, err.Error())
+	}
+	config, err := parseConfigWithDefaults(&document)
+	if err != nil || config == nil || config.Extends == "" {
+		return configFileContent, err
+	}
+	// Validate the file before it is regenerated, so that the errors refer to the lines of the original file
+	if config, err = parseConfigContentWithDefaults(configFileContent); err != nil {
+		return nil, err
+	}
+	extendedPaths := map[string]bool{}
+	// The path of a file extended by an extended file is relative to the directory of the extending file
+	for extends, extendingDir := config.Extends, "."; extends != ""; {
+		if path.IsAbs(extends) {
+			return nil, fmt.Errorf("the extended configuration file %s should be relative to the directory of the %s file", extends, FrogbotConfigFile)
+		}
+		extendedPath := path.Join(extendingDir, extends)
+		// The extended files are read from the directory of the frogbot-config.yml file, and shouldn't escape it
+		if extendedPath == ".." || strings.HasPrefix(extendedPath, "../") {
+			return nil, fmt.Errorf("the extended configuration file %s is outside of the directory of the %s file", extends, FrogbotConfigFile)
+		}
+		if extendedPaths[extendedPath] {
+			return nil, fmt.Errorf("the %s file is extended more than once, which creates a cycle", extendedPath)
+		}
+		extendedPaths[extendedPath] = true
+		var extended *frogbotConfigWithDefaults
+		if extended, err = readExtendedConfigFile(extendedPath, readExtendedConfig); err != nil {
+			return nil, err
+		}
+		config.Defaults = mergeConfigMappings(extended.Defaults, config.Defaults)
+		extends, extendingDir = extended.Extends, path.Dir(extendedPath)
+	}
+	config.Extends = ""
+	return yaml.Marshal(config)
+}
+
+func readExtendedConfigFile(extendedPath string, readExtendedConfig extendedConfigReader) (extended *frogbotConfigWithDefaults, err error) {
+	content, err := readExtendedConfig(extendedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the extended configuration file %s: %w", extendedPath, err)
+	}
+	if extended, err = parseConfigContentWithDefaults(content); err != nil {
+		return nil, fmt.Errorf("the extended configuration file %s is invalid: %w", extendedPath, err)
+	}
+	if extended == nil || len(extended.Repositories) > 0 {
+		return nil, fmt.Errorf("the extended configuration file %s should include defaults only, without repositories", extendedPath)
+	}
+	return
+}
+
+// Returns the repositories of the configuration, after merging the defaults into the parameters of each repository.
+func (config *frogbotConfigWithDefaults) toRepoAggregator() (result RepoAggregator, err error) {
+	if config.Extends != "" {
+		return nil, fmt.Errorf("the %s file extends %s, which wasn't loaded. Extended files are loaded only when the %s file is read from the repository", FrogbotConfigFile, config.Extends, FrogbotConfigFile)
+	}
+	if len(config.Repositories) == 0 {
+		return nil, fmt.Errorf("the %s file doesn't include any repositories", FrogbotConfigFile)
+	}
+	repositories := make([]map[string]interface{}, 0, len(config.Repositories))
+	for _, repository := range config.Repositories {
+		params, _ := repository["params"].(map[string]interface{})
+		repositories = append(repositories, map[string]interface{}{"params": mergeConfigMappings(config.Defaults, params)})
+	}
+	content, err := yaml.Marshal(repositories)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(content, &result)
+	return
+}
+
+// Deep-merges the defaults into the values, without modifying any of them.
+// Mappings are merged recursively, while any other value, including lists, replaces the default value.
+func mergeConfigMappings(defaults, values map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults)+len(values))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range values {
+		if value == nil {
+			// Keys without values, such as "scan:", don't override the defaults
+			continue
+		}
+		defaultMapping, isDefaultMapping := merged[key].(map[string]interface{})
+		valueMapping, isValueMapping := value.(map[string]interface{})
+		if isDefaultMapping && isValueMapping {
+			merged[key] = mergeConfigMappings(defaultMapping, valueMapping)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRepoAggregatorWithDefaults(t *testing.T) {
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+	configFileContent, err := ReadConfigFromFileSystem(filepath.Join("..", "testdata", "config", "defaults", "frogbot-config.yml"))
+	require.NoError(t, err)
+	gitParams := &Git{RepoOwner: "jfrog"}
+	repoAggregator, err := BuildRepoAggregator(configFileContent, gitParams, &config.ServerDetails{}, ScanRepository)
+	require.NoError(t, err)
+	require.Len(t, repoAggregator, 2)
+
+	// The defaults of the config file take precedence over the defaults of the extended files
+	firstRepo := repoAggregator[0]
+	assert.Equal(t, "repo-1", firstRepo.RepoName)
+	assert.Equal(t, []string{"master"}, firstRepo.Branches)
+	assert.Equal(t, "High", firstRepo.MinSeverity)
+	assert.True(t, firstRepo.FixableOnly)
+	assert.Equal(t, []string{"MIT"}, firstRepo.AllowedLicenses)
+	assert.Equal(t, []string{"organization-watch"}, firstRepo.Watches)
+	assert.Equal(t, "[Security] Upgrade {IMPACTED_PACKAGE} to {FIX_VERSION}", firstRepo.PullRequestTitleTemplate)
+	assert.Equal(t, []string{RootDir}, firstRepo.Projects[0].WorkingDirs)
+
+	// The parameters of the repository take precedence over the defaults
+	secondRepo := repoAggregator[1]
+	assert.Equal(t, "repo-2", secondRepo.RepoName)
+	assert.Equal(t, []string{"dev"}, secondRepo.Branches)
+	assert.Equal(t, "High", secondRepo.MinSeverity)
+	assert.False(t, secondRepo.FixableOnly)
+	assert.Equal(t, []string{"MIT"}, secondRepo.AllowedLicenses)
+	assert.Equal(t, []string{"service"}, secondRepo.Projects[0].WorkingDirs)
+}
+
+func TestResolveConfigExtends(t *testing.T) {
+	files := map[string]string{
+		"a.yml":          "extends: nested/b.yml\ndefaults:\n  scan:\n    minSeverity: Low\n",
+		"nested/b.yml":   "extends: ../a.yml\n",
+		"repos.yml":      "defaults:\n  scan:\n    fixableOnly: true\nrepositories:\n  - params:\n      git:\n        repoName: repo\n",
+		"invalid.yml":    "defaults:\n  git:\n    unknown: value\n",
+		"repository.yml": "- params:\n    git:\n      repoName: repo\n      branches: [master]\n",
+		"nested/up.yml":  "extends: ../../outside.yml\n",
+		"../outside.yml": "defaults:\n  scan:\n    minSeverity: Low\n",
+	}
+	readFile := func(extendedPath string) ([]byte, error) {
+		if content, exists := files[extendedPath]; exists {
+			return []byte(content), nil
+		}
+		return nil, errors.New("file not found")
+	}
+	repositories := "\nrepositories:\n  - params:\n      git:\n        repoName: repo\n"
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{name: "Cycle", content: "extends: a.yml" + repositories, expectedError: "creates a cycle"},
+		{name: "Extended file with repositories", content: "extends: repos.yml" + repositories, expectedError: "should include defaults only"},
+		{name: "Extended list of repositories", content: "extends: repository.yml" + repositories, expectedError: "should include defaults only"},
+		{name: "Invalid extended file", content: "extends: invalid.yml" + repositories, expectedError: "the extended configuration file invalid.yml is invalid"},
+		{name: "Missing extended file", content: "extends: missing.yml" + repositories, expectedError: "file not found"},
+		{name: "Absolute path", content: "extends: /etc/frogbot.yml" + repositories, expectedError: "should be relative"},
+		{name: "Path outside of the directory", content: "extends: ../outside.yml" + repositories, expectedError: "is outside of the directory"},
+		{name: "Path in the directory that resolves outside of it", content: "extends: nested/../../outside.yml" + repositories, expectedError: "is outside of the directory"},
+		{name: "Extended path outside of the directory", content: "extends: nested/up.yml" + repositories, expectedError: "is outside of the directory"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := resolveConfigExtends([]byte(test.content), readFile)
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+
+	// Content that doesn't extend other files is returned as is
+	content := []byte(files["repository.yml"])
+	resolvedContent, err := resolveConfigExtends(content, readFile)
+	assert.NoError(t, err)
+	assert.Equal(t, content, resolvedContent)
+
+	// Extended files can't be resolved when the content is provided without its repository
+	_, err = unmarshalFrogbotConfigYaml([]byte("extends: a.yml" + repositories))
+	assert.ErrorContains(t, err, "which wasn't loaded")
+}
+
+func TestMergeConfigMappings(t *testing.T) {
+	defaults := map[string]interface{}{
+		"git":  map[string]interface{}{"branches": []interface{}{"master"}, "aggregateFixes": true},
+		"scan": map[string]interface{}{"allowedLicenses": []interface{}{"MIT", "ISC"}},
+	}
+	values := map[string]interface{}{
+		"git":           map[string]interface{}{"repoName": "repo", "aggregateFixes": false},
+		"scan":          map[string]interface{}{"allowedLicenses": []interface{}{"Apache-2.0"}},
+		"jfrogPlatform": nil,
+	}
+	assert.Equal(t, map[string]interface{}{
+		"git":  map[string]interface{}{"repoName": "repo", "branches": []interface{}{"master"}, "aggregateFixes": false},
+		"scan": map[string]interface{}{"allowedLicenses": []interface{}{"Apache-2.0"}},
+	}, mergeConfigMappings(defaults, values))
+	// The defaults aren't modified
+	assert.Equal(t, true, defaults["git"].(map[string]interface{})["aggregateFixes"])
+}
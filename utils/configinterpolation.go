@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const fileReferencePrefix = "file:"
+
+// Matches the ${VAR} and ${file:/path} references in the values of the frogbot-config.yml file, and the $${ escape sequence
+var configReferencePattern = regexp.MustCompile(`\$\$\{|\$\{([^}]*)}`)
+
+// interpolateConfigValues replaces the references in the values of the frogbot-config.yml document:
+// ${VAR} is replaced with the value of the VAR environment variable, and ${file:/path} with the content of the file.
+// A reference can be escaped with $${, for example: $${VAR} is replaced with ${VAR}.
+// Keys aren't interpolated, and the interpolated values aren't interpolated again.
+func interpolateConfigValues(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return interpolateConfigValue(node)
+	}
+	for i, child := range node.Content {
+		// The content of a mapping node is a list of keys, each followed by its value
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			continue
+		}
+		if err := interpolateConfigValues(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func interpolateConfigValue(node *yaml.Node) (err error) {
+	if !strings.Contains(node.Value, "${") {
+		return
+	}
+	value := configReferencePattern.ReplaceAllStringFunc(node.Value, func(reference string) string {
+		if reference == "$${" || err != nil {
+			return "${"
+		}
+		var resolved string
+		resolved, err = resolveConfigReference(strings.TrimSuffix(strings.TrimPrefix(reference, "${"), "}"))
+		return resolved
+	})
+	if err != nil {
+		return fmt.Errorf("failed to interpolate the value in line %d, column %d of the %s file: %w", node.Line, node.Column, FrogbotConfigFile, err)
+	}
+	node.Value = value
+	if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+		// The type of unquoted values is determined by the interpolated value, allowing references in boolean parameters.
+		// To keep a value as a string, such as a numeric token, quote the value.
+		node.Tag = ""
+	}
+	return
+}
+
+func resolveConfigReference(reference string) (string, error) {
+	if filePath, isFileReference := strings.CutPrefix(reference, fileReferencePrefix); isFileReference {
+		content, err := os.ReadFile(strings.TrimSpace(filePath))
+		if err != nil {
+			return "", err
+		}
+		// Mounted secret files usually end with a line break
+		return strings.TrimSpace(string(content)), nil
+	}
+	if reference == "" {
+		return "", fmt.Errorf("empty reference ${}")
+	}
+	value, exists := os.LookupEnv(reference)
+	if !exists {
+		return "", fmt.Errorf("the %s environment variable isn't set", reference)
+	}
+	return value, nil
+}
+
+// Parses the content of the frogbot-config.yml file, interpolates its values and validates it against the Frogbot schema.
+// The lines in the schema errors are the lines of the original content.
+func loadConfigDocument(configFileContent []byte) (document *yaml.Node, err error) {
+	document = &yaml.Node{}
+	if err = yaml.Unmarshal(configFileContent, document); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s file: %s", FrogbotConfigFile, err.Error())
+	}
+	if err = interpolateConfigValues(document); err != nil {
+		return
+	}
+	err = validateConfigSchema(document)
+	return
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateConfigValues(t *testing.T) {
+	watchesFile := filepath.Join(t.TempDir(), "watch")
+	require.NoError(t, os.WriteFile(watchesFile, []byte("mounted-watch\n"), 0600))
+	SetEnvAndAssert(t, map[string]string{"FROGBOT_TEST_REPO": "frogbot", "FROGBOT_TEST_FIXABLE_ONLY": "true", "FROGBOT_TEST_BRANCH": "dev"})
+	defer func() {
+		assert.NoError(t, os.Unsetenv("FROGBOT_TEST_REPO"))
+		assert.NoError(t, os.Unsetenv("FROGBOT_TEST_FIXABLE_ONLY"))
+		assert.NoError(t, os.Unsetenv("FROGBOT_TEST_BRANCH"))
+	}()
+	configFileContent := []byte(`
+- params:
+    git:
+      repoName: ${FROGBOT_TEST_REPO}
+      branches:
+        - release-${FROGBOT_TEST_BRANCH}
+      pullRequestTitleTemplate: "Upgrade $${IMPACTED_PACKAGE}"
+    scan:
+      fixableOnly: ${FROGBOT_TEST_FIXABLE_ONLY}
+    jfrogPlatform:
+      watches:
+        - ${file:` + watchesFile + `}
+`)
+	repoAggregator, err := BuildRepoAggregator(configFileContent, &Git{}, &config.ServerDetails{}, ScanRepository)
+	require.NoError(t, err)
+	repo := repoAggregator[0]
+	assert.Equal(t, "frogbot", repo.RepoName)
+	assert.Equal(t, []string{"release-dev"}, repo.Branches)
+	assert.Equal(t, "Upgrade ${IMPACTED_PACKAGE}", repo.PullRequestTitleTemplate)
+	assert.True(t, repo.FixableOnly)
+	assert.Equal(t, []string{"mounted-watch"}, repo.Watches)
+}
+
+func TestInterpolateConfigValuesErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{name: "Missing environment variable", content: "- params:\n    git:\n      repoName: ${FROGBOT_TEST_MISSING}\n", expectedError: "line 3, column 17 of the frogbot-config.yml file: the FROGBOT_TEST_MISSING environment variable isn't set"},
+		{name: "Missing file", content: "- params:\n    git:\n      repoName: ${file:/frogbot/missing}\n", expectedError: "no such file or directory"},
+		{name: "Empty reference", content: "- params:\n    git:\n      repoName: ${}\n", expectedError: "empty reference"},
+		// Quoted values remain strings
+		{name: "Quoted boolean", content: "- params:\n    git:\n      repoName: frogbot\n      branches: [master]\n    scan:\n      fixableOnly: \"${FROGBOT_TEST_FIXABLE_ONLY}\"\n", expectedError: "line 6, column 20: 0.params.scan.fixableOnly: Invalid type. Expected: boolean, given: string"},
+	}
+	SetEnvAndAssert(t, map[string]string{"FROGBOT_TEST_FIXABLE_ONLY": "true"})
+	defer func() {
+		assert.NoError(t, os.Unsetenv("FROGBOT_TEST_FIXABLE_ONLY"))
+	}()
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := unmarshalFrogbotConfigYaml([]byte(test.content))
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestReadSecretEnv(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("mounted-token\n"), 0600))
+	testCases := []struct {
+		name          string
+		envs          map[string]string
+		expectedValue string
+		expectedError string
+	}{
+		{name: "Environment variable", envs: map[string]string{JFrogTokenEnv: "token"}, expectedValue: "token"},
+		{name: "Secret file", envs: map[string]string{JFrogTokenEnv + SecretFileEnvSuffix: tokenFile}, expectedValue: "mounted-token"},
+		{name: "Not set", envs: map[string]string{}},
+		{name: "Both set", envs: map[string]string{JFrogTokenEnv: "token", JFrogTokenEnv + SecretFileEnvSuffix: tokenFile}, expectedError: "only one of the JF_ACCESS_TOKEN and JF_ACCESS_TOKEN_FILE environment variables should be set"},
+		{name: "Missing file", envs: map[string]string{JFrogTokenEnv + SecretFileEnvSuffix: filepath.Join(t.TempDir(), "missing")}, expectedError: "failed to read the file in the JF_ACCESS_TOKEN_FILE environment variable"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			SetEnvAndAssert(t, test.envs)
+			defer func() {
+				assert.NoError(t, SanitizeEnv())
+			}()
+			value, err := ReadSecretEnv(JFrogTokenEnv)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, value)
+		})
+	}
+}
+
+func TestExtractParamsFromEnvSecretFiles(t *testing.T) {
+	secretsDir := t.TempDir()
+	for _, secret := range []string{"jfrog-token", "git-token"} {
+		require.NoError(t, os.WriteFile(filepath.Join(secretsDir, secret), []byte(secret+"-value\n"), 0600))
+	}
+	SetEnvAndAssert(t, map[string]string{
+		JFrogUrlEnv:                         "http://127.0.0.1:8081",
+		JFrogTokenEnv + SecretFileEnvSuffix: filepath.Join(secretsDir, "jfrog-token"),
+		GitProvider:                         string(GitHub),
+		GitRepoOwnerEnv:                     "jfrog",
+		GitRepoEnv:                          "frogbot",
+		GitTokenEnv + SecretFileEnvSuffix:   filepath.Join(secretsDir, "git-token"),
+	})
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+	server, err := extractJFrogCredentialsFromEnvs()
+	require.NoError(t, err)
+	assert.Equal(t, "jfrog-token-value", server.AccessToken)
+	gitParams, err := extractGitParamsFromEnvs(ScanRepository)
+	require.NoError(t, err)
+	assert.Equal(t, "git-token-value", gitParams.Token)
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/frogbot/schema"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+const rootSchemaField = "(root)"
+
+// ValidateConfigSchema validates the content of the frogbot-config.yml file against the Frogbot JSON schema.
+// The returned error describes each schema violation, along with its line and column in the file.
+func ValidateConfigSchema(configFileContent []byte) error {
+	var document yaml.Node
+	if err := yaml.Unmarshal(configFileContent, &document); err != nil {
+		return fmt.Errorf("failed to parse the %s file: %s", FrogbotConfigFile, err.Error())
+	}
+	return validateConfigSchema(&document)
+}
+
+func validateConfigSchema(document *yaml.Node) error {
+	if len(document.Content) == 0 {
+		// Empty config file
+		return nil
+	}
+	rootNode := document.Content[0]
+	var configContent interface{}
+	if err := rootNode.Decode(&configContent); err != nil {
+		return fmt.Errorf("failed to parse the %s file: %s", FrogbotConfigFile, err.Error())
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema.FrogbotSchema), gojsonschema.NewGoLoader(convertYamlToJson(configContent)))
+	if err != nil {
+		return err
+	}
+	if result.Valid() {
+		return nil
+	}
+	schemaErrors := []string{fmt.Sprintf("the %s file doesn't match the Frogbot schema:", FrogbotConfigFile)}
+	for _, resultError := range result.Errors() {
+		node := findYamlNode(rootNode, resultError.Field())
+		schemaErrors = append(schemaErrors, fmt.Sprintf("line %d, column %d: %s", node.Line, node.Column, resultError.String()))
+	}
+	return errors.New(strings.Join(schemaErrors, "\n"))
+}
+
+// Recursively converts the YAML values to values that can be marshaled to JSON.
+func convertYamlToJson(yamlValue interface{}) interface{} {
+	switch value := yamlValue.(type) {
+	case map[string]interface{}:
+		jsonMapping := map[string]interface{}{}
+		for key, mapValue := range value {
+			jsonMapping[key] = convertYamlToJson(mapValue)
+		}
+		return jsonMapping
+	case map[interface{}]interface{}:
+		jsonMapping := map[string]interface{}{}
+		for key, mapValue := range value {
+			jsonMapping[fmt.Sprint(key)] = convertYamlToJson(mapValue)
+		}
+		return jsonMapping
+	case []interface{}:
+		for i, arrayValue := range value {
+			value[i] = convertYamlToJson(arrayValue)
+		}
+	}
+	return yamlValue
+}
+
+// Returns the YAML node in the given schema field path, for example: "0.params.git".
+// If the path can't be fully resolved, the deepest node that was found is returned.
+func findYamlNode(node *yaml.Node, fieldPath string) *yaml.Node {
+	if fieldPath == rootSchemaField {
+		return node
+	}
+	for _, field := range strings.Split(fieldPath, ".") {
+		next := findChildYamlNode(node, field)
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+func findChildYamlNode(node *yaml.Node, field string) *yaml.Node {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(field); err == nil && index < len(node.Content) {
+			return node.Content[index]
+		}
+	case yaml.MappingNode:
+		// Mapping nodes content is a list of keys, each followed by its value
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == field {
+				return node.Content[i+1]
+			}
+		}
+	}
+	return nil
+}
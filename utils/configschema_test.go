@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateConfigSchema(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configContent string
+		expectedError string
+	}{
+		{name: "empty", configContent: ""},
+		{name: "valid", configContent: "- params:\n    git:\n      repoName: frogbot\n      branches:\n        - master\n"},
+		{
+			name:          "additional property",
+			configContent: "- params:\n    git:\n      repoName: frogbot\n      branches:\n        - master\n      typo: true\n",
+			expectedError: "line 3, column 7: 0.params.git: Additional property typo is not allowed",
+		},
+		{
+			name:          "missing property",
+			configContent: "- params:\n    git:\n      branches:\n        - master\n",
+			expectedError: "line 3, column 7: 0.params.git: repoName is required",
+		},
+		{
+			name:          "wrong type",
+			configContent: "- params:\n    git:\n      repoName: frogbot\n      branches:\n        - master\n    scan:\n      fixableOnly: maybe\n",
+			expectedError: "line 7, column 20: 0.params.scan.fixableOnly: Invalid type. Expected: boolean, given: string",
+		},
+		{
+			name:          "mapping without repositories section",
+			configContent: "params:\n  git:\n",
+			expectedError: "line 1, column 1: (root): Additional property params is not allowed",
+		},
+		{name: "defaults", configContent: "defaults:\n  git:\n    branches:\n      - master\nrepositories:\n  - params:\n      git:\n        repoName: frogbot\n"},
+		{
+			name:          "wrong type in defaults",
+			configContent: "defaults:\n  scan:\n    fixableOnly: maybe\nrepositories:\n  - params:\n      git:\n        repoName: frogbot\n",
+			expectedError: "line 3, column 18: defaults.scan.fixableOnly: Invalid type. Expected: boolean, given: string",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateConfigSchema([]byte(test.configContent))
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestBuildRepoAggregatorInvalidConfig(t *testing.T) {
+	gitParams := &Git{RepoName: "frogbot", Branches: []string{"master"}}
+	_, err := BuildRepoAggregator([]byte("- params:\n    git:\n      repoName: frogbot\n      branches: [master]\n    scan:\n      minSeverty: high\n"), gitParams, &config.ServerDetails{}, ScanRepository)
+	assert.ErrorContains(t, err, "line 6, column 7: 0.params.scan: Additional property minSeverty is not allowed")
+}
+
+func TestGetEffectiveConfig(t *testing.T) {
+	SetEnvAndAssert(t, map[string]string{MinSeverityEnv: "low", CommitMessageTemplateEnv: "commit-msg"})
+	configAggregator, err := GetEffectiveConfig(filepath.Join("..", "testdata", "config", "frogbot-config-test-params-merge.yml"), nil)
+	assert.NoError(t, err)
+	AssertSanitizedEnv(t)
+
+	// Values from the config file take precedence over the environment variables
+	repo := configAggregator[0]
+	assert.Equal(t, "High", repo.MinSeverity)
+	assert.Equal(t, "commit-msg", repo.CommitMessageTemplate)
+
+	// The effective configuration should be a valid config file, without runtime details
+	effectiveConfig, err := yaml.Marshal(configAggregator)
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateConfigSchema(effectiveConfig))
+
+	// Missing config file
+	_, err = GetEffectiveConfig(filepath.Join(t.TempDir(), "frogbot-config.yml"), nil)
+	assert.Error(t, err)
+}
@@ -28,6 +28,12 @@ const (
 	JFrogPasswordEnv       = "JF_PASSWORD"
 	JFrogTokenEnv          = "JF_ACCESS_TOKEN"
 
+	// JFrog platform OIDC environment variables - The OIDC ID token issued by the CI is exchanged for a short-lived access token
+	JFrogOidcProviderNameEnv = "JF_OIDC_PROVIDER_NAME"
+	//#nosec G101 -- False positive - no hardcoded credentials.
+	JFrogOidcTokenEnv    = "JF_OIDC_TOKEN"
+	JFrogOidcAudienceEnv = "JF_OIDC_AUDIENCE"
+
 	// Git environment variables
 	GitProvider     = "JF_GIT_PROVIDER"
 	GitRepoOwnerEnv = "JF_GIT_OWNER"
@@ -39,6 +45,7 @@ const (
 	BranchNameTemplateEnv       = "JF_BRANCH_NAME_TEMPLATE"
 	CommitMessageTemplateEnv    = "JF_COMMIT_MESSAGE_TEMPLATE"
 	PullRequestTitleTemplateEnv = "JF_PULL_REQUEST_TITLE_TEMPLATE"
+	FixStrategyEnv              = "JF_FIX_STRATEGY"
 
 	// Repository environment variables - Ignored if the frogbot-config.yml file is used
 	InstallCommandEnv            = "JF_INSTALL_DEPS_CMD"
@@ -48,11 +55,15 @@ const (
 	jfrogProjectEnv              = "JF_PROJECT"
 	IncludeAllVulnerabilitiesEnv = "JF_INCLUDE_ALL_VULNERABILITIES"
 	FailOnSecurityIssuesEnv      = "JF_FAIL"
+	PublishCommitStatusEnv       = "JF_PUBLISH_COMMIT_STATUS"
 	UseWrapperEnv                = "JF_USE_WRAPPER"
 	DepsRepoEnv                  = "JF_DEPS_REPO"
 	MinSeverityEnv               = "JF_MIN_SEVERITY"
 	FixableOnlyEnv               = "JF_FIXABLE_ONLY"
 	AllowedLicensesEnv           = "JF_ALLOWED_LICENSES"
+	FailMinSeverityEnv           = "JF_FAIL_MIN_SEVERITY"
+	FailApplicableOnlyEnv        = "JF_FAIL_APPLICABLE_ONLY"
+	FailFixableOnlyEnv           = "JF_FAIL_FIXABLE_ONLY"
 	WatchesDelimiter             = ","
 
 	// Email related environment variables
@@ -70,6 +81,22 @@ const (
 	GitAggregateFixesEnv = "JF_GIT_AGGREGATE_FIXES"
 	GitEmailAuthorEnv    = "JF_GIT_EMAIL_AUTHOR"
 
+	// Webhook server environment variables
+	//#nosec G101 -- False positive - no hardcoded credentials.
+	WebhookSecretEnv = "JF_WEBHOOK_SECRET"
+	WebhookPortEnv   = "JF_WEBHOOK_PORT"
+
+	// The path of the JSON file to write the run summary to
+	RunSummaryFileEnv = "JF_RUN_SUMMARY_FILE"
+
+	// Audit results cache environment variables
+	ResultsCacheDirEnv     = "JF_RESULTS_CACHE_DIR"
+	ResultsCacheRepoEnv    = "JF_RESULTS_CACHE_REPO"
+	DisableResultsCacheEnv = "JF_DISABLE_RESULTS_CACHE"
+
+	// The suffix of the environment variables that include the path to a file with the value of a secret environment variable, such as JF_ACCESS_TOKEN_FILE
+	SecretFileEnvSuffix = "_FILE"
+
 	// Product ID for usage reporting
 	productId = "frogbot"
 
@@ -0,0 +1,72 @@
+package utils
+
+// ExitCode is the exit code of the Frogbot process, allowing pipelines to act according to the result of the command.
+type ExitCode int
+
+// Exit code 2 is reserved by the JFrog CLI core, therefore the Frogbot specific exit codes start at 3.
+const (
+	ExitCodeSuccess            ExitCode = 0
+	ExitCodeGeneralError       ExitCode = 1
+	ExitCodeIssuesFound        ExitCode = 3
+	ExitCodeConfigurationError ExitCode = 4
+	ExitCodeAuditError         ExitCode = 5
+	ExitCodeVcsApiError        ExitCode = 6
+	ExitCodePartialFixFailure  ExitCode = 7
+)
+
+// When several errors occur, the exit code is determined by the first error type in this list.
+// Failures that prevent Frogbot from completing the command precede the security issues found.
+var exitCodesPriority = []ExitCode{ExitCodeConfigurationError, ExitCodeVcsApiError, ExitCodeAuditError, ExitCodePartialFixFailure, ExitCodeIssuesFound}
+
+type errorWithExitCode struct {
+	err      error
+	exitCode ExitCode
+}
+
+func (e *errorWithExitCode) Error() string {
+	return e.err.Error()
+}
+
+func (e *errorWithExitCode) Unwrap() error {
+	return e.err
+}
+
+// WithExitCode attaches the exit code to the error, without changing its message.
+// If the error already contains an error with an exit code, the more specific exit code is kept.
+func WithExitCode(err error, exitCode ExitCode) error {
+	if err == nil || GetExitCode(err) != ExitCodeGeneralError {
+		return err
+	}
+	return &errorWithExitCode{err: err, exitCode: exitCode}
+}
+
+// GetExitCode returns the exit code of the error. Errors without an exit code are general errors.
+func GetExitCode(err error) ExitCode {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	exitCodes := map[ExitCode]bool{}
+	collectExitCodes(err, exitCodes)
+	for _, exitCode := range exitCodesPriority {
+		if exitCodes[exitCode] {
+			return exitCode
+		}
+	}
+	return ExitCodeGeneralError
+}
+
+// Collects the exit codes of the error tree, including the errors combined by errors.Join.
+func collectExitCodes(err error, exitCodes map[ExitCode]bool) {
+	switch e := err.(type) {
+	case *errorWithExitCode:
+		exitCodes[e.exitCode] = true
+	case interface{ Unwrap() []error }:
+		for _, joinedErr := range e.Unwrap() {
+			collectExitCodes(joinedErr, exitCodes)
+		}
+	case interface{ Unwrap() error }:
+		if wrappedErr := e.Unwrap(); wrappedErr != nil {
+			collectExitCodes(wrappedErr, exitCodes)
+		}
+	}
+}
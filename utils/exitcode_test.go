@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExitCode(t *testing.T) {
+	issuesFoundErr := WithExitCode(errors.New("issues were detected"), ExitCodeIssuesFound)
+	auditErr := WithExitCode(errors.New("audit failed"), ExitCodeAuditError)
+	vcsErr := WithExitCode(errors.New("not found"), ExitCodeVcsApiError)
+	testCases := []struct {
+		name             string
+		err              error
+		expectedExitCode ExitCode
+	}{
+		{name: "No error", err: nil, expectedExitCode: ExitCodeSuccess},
+		{name: "General error", err: errors.New("error"), expectedExitCode: ExitCodeGeneralError},
+		{name: "Issues found", err: issuesFoundErr, expectedExitCode: ExitCodeIssuesFound},
+		{name: "Wrapped error", err: fmt.Errorf("failed to scan: %w", auditErr), expectedExitCode: ExitCodeAuditError},
+		{name: "Joined errors", err: errors.Join(issuesFoundErr, errors.New("error"), vcsErr, auditErr), expectedExitCode: ExitCodeVcsApiError},
+		{name: "Joined general errors", err: errors.Join(errors.New("error1"), errors.New("error2")), expectedExitCode: ExitCodeGeneralError},
+		{name: "Nested joined errors", err: errors.Join(issuesFoundErr, fmt.Errorf("wrapped: %w", errors.Join(errors.New("error"), auditErr))), expectedExitCode: ExitCodeAuditError},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedExitCode, GetExitCode(test.err))
+		})
+	}
+}
+
+func TestWithExitCode(t *testing.T) {
+	assert.NoError(t, WithExitCode(nil, ExitCodeConfigurationError))
+
+	// The message of the error doesn't change
+	err := WithExitCode(errors.New("missing config"), ExitCodeConfigurationError)
+	assert.EqualError(t, err, "missing config")
+	assert.Equal(t, ExitCodeConfigurationError, GetExitCode(err))
+
+	// The more specific exit code is kept
+	err = WithExitCode(fmt.Errorf("failed to read the config: %w", WithExitCode(errors.New("not found"), ExitCodeVcsApiError)), ExitCodeConfigurationError)
+	assert.Equal(t, ExitCodeVcsApiError, GetExitCode(err))
+}
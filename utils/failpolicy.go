@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// FailPolicy determines which of the issues found fail the scan, if failOnSecurityIssues is set.
+// Each category of issues is checked separately, and fails the scan if the number of its issues that meet the conditions of the policy exceeds the category's threshold.
+type FailPolicy struct {
+	// The minimum severity of the issues that fail the scan, independent of minSeverity, which filters the displayed issues.
+	// If not set, the minSeverity of the displayed issues is used.
+	MinSeverity string `yaml:"minSeverity,omitempty"`
+	// Only vulnerabilities and security violations with an applicable CVE fail the scan
+	ApplicableOnly bool `yaml:"applicableOnly,omitempty"`
+	// Only vulnerabilities and security violations with a fix version fail the scan.
+	// If not set, the fixableOnly of the displayed issues is used.
+	FixableOnly *bool          `yaml:"fixableOnly,omitempty"`
+	Thresholds  FailThresholds `yaml:"thresholds,omitempty"`
+}
+
+// FailThresholds are the numbers of issues of each category allowed before the scan fails.
+// By default, any issue fails the scan. A category with a negative threshold never fails the scan.
+type FailThresholds struct {
+	Vulnerabilities    int `yaml:"vulnerabilities,omitempty"`
+	SecurityViolations int `yaml:"securityViolations,omitempty"`
+	Licenses           int `yaml:"licenses,omitempty"`
+	Iac                int `yaml:"iac,omitempty"`
+	Secrets            int `yaml:"secrets,omitempty"`
+	Sast               int `yaml:"sast,omitempty"`
+}
+
+func (fp *FailPolicy) setDefaultsIfNeeded() (err error) {
+	e := &ErrMissingEnv{}
+	if fp.MinSeverity == "" {
+		if err = readParamFromEnv(FailMinSeverityEnv, &fp.MinSeverity); err != nil && !e.IsMissingEnvErr(err) {
+			return
+		}
+	}
+	if fp.MinSeverity, err = xrutils.GetSeveritiesFormat(fp.MinSeverity); err != nil {
+		return
+	}
+	if !fp.ApplicableOnly {
+		if fp.ApplicableOnly, err = getBoolEnv(FailApplicableOnlyEnv, false); err != nil {
+			return
+		}
+	}
+	if fp.FixableOnly == nil && getTrimmedEnv(FailFixableOnlyEnv) != "" {
+		var fixableOnly bool
+		if fixableOnly, err = getBoolEnv(FailFixableOnlyEnv, false); err != nil {
+			return
+		}
+		fp.FixableOnly = &fixableOnly
+	}
+	return
+}
+
+// GetFailPolicy returns the fail policy of the scan, in which the conditions that aren't set are taken from the filters of the displayed issues.
+// By default, the scan therefore fails only on the issues it displays.
+func (s *Scan) GetFailPolicy() *FailPolicy {
+	policy := s.FailPolicy
+	if policy.MinSeverity == "" {
+		policy.MinSeverity = s.MinSeverity
+	}
+	if policy.FixableOnly == nil {
+		fixableOnly := s.FixableOnly
+		policy.FixableOnly = &fixableOnly
+	}
+	return &policy
+}
+
+// IsFailing returns true if any category of the issues exceeds its threshold.
+// If violations is true, the scan is performed against Xray watches or a JFrog project, so the vulnerabilities of the issues are security violations, and the licenses are license violations.
+func (fp *FailPolicy) IsFailing(issues *IssuesCollection, violations bool) bool {
+	vulnerabilitiesCategory, vulnerabilitiesThreshold := "vulnerabilities", fp.Thresholds.Vulnerabilities
+	if violations {
+		vulnerabilitiesCategory, vulnerabilitiesThreshold = "security violations", fp.Thresholds.SecurityViolations
+	}
+	failing := false
+	for _, category := range []struct {
+		name      string
+		count     int
+		threshold int
+	}{
+		{name: vulnerabilitiesCategory, count: fp.countVulnerabilities(issues.Vulnerabilities), threshold: vulnerabilitiesThreshold},
+		{name: "licenses", count: fp.countLicenses(issues.Licenses), threshold: fp.Thresholds.Licenses},
+		{name: "IaC issues", count: fp.countSourceCodeIssues(issues.Iacs), threshold: fp.Thresholds.Iac},
+		{name: "secrets", count: fp.countSourceCodeIssues(issues.Secrets), threshold: fp.Thresholds.Secrets},
+		{name: "SAST issues", count: fp.countSourceCodeIssues(issues.Sast), threshold: fp.Thresholds.Sast},
+	} {
+		if category.threshold >= 0 && category.count > category.threshold {
+			log.Info(fmt.Sprintf("%d %s that fail the scan were found, exceeding the threshold of %d", category.count, category.name, category.threshold))
+			failing = true
+		}
+	}
+	return failing
+}
+
+func (fp *FailPolicy) countVulnerabilities(rows []formats.VulnerabilityOrViolationRow) (count int) {
+	for _, row := range rows {
+		if fp.ApplicableOnly && row.Applicable != string(xrutils.Applicable) {
+			continue
+		}
+		if fp.FixableOnly != nil && *fp.FixableOnly && len(row.FixedVersions) == 0 {
+			continue
+		}
+		if fp.meetsMinSeverity(row.Severity) {
+			count++
+		}
+	}
+	return
+}
+
+func (fp *FailPolicy) countLicenses(rows []formats.LicenseRow) (count int) {
+	for _, row := range rows {
+		if fp.meetsMinSeverity(row.Severity) {
+			count++
+		}
+	}
+	return
+}
+
+func (fp *FailPolicy) countSourceCodeIssues(rows []formats.SourceCodeRow) (count int) {
+	for _, row := range rows {
+		if fp.meetsMinSeverity(row.Severity) {
+			count++
+		}
+	}
+	return
+}
+
+// Issues without a severity, such as licenses that aren't allowed, can't be compared to the minimum severity, and always meet it
+func (fp *FailPolicy) meetsMinSeverity(severity string) bool {
+	return MeetsMinSeverity(severity, fp.MinSeverity)
+}
+
+// MeetsMinSeverity returns true if the severity is at least minSeverity.
+// An empty minSeverity or severity always meets the condition.
+func MeetsMinSeverity(severity, minSeverity string) bool {
+	if minSeverity == "" || severity == "" {
+		return true
+	}
+	return xrutils.GetSeverity(severity, xrutils.ApplicabilityUndetermined).NumValue() >= xrutils.GetSeverity(minSeverity, xrutils.ApplicabilityUndetermined).NumValue()
+}
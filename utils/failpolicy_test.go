@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailPolicyIsFailing(t *testing.T) {
+	vulnerability := func(severity, applicable string, fixedVersions ...string) formats.VulnerabilityOrViolationRow {
+		return formats.VulnerabilityOrViolationRow{
+			ImpactedDependencyDetails: formats.ImpactedDependencyDetails{SeverityDetails: formats.SeverityDetails{Severity: severity}},
+			Applicable:                applicable,
+			FixedVersions:             fixedVersions,
+		}
+	}
+	sourceCodeIssue := func(severity string) formats.SourceCodeRow {
+		return formats.SourceCodeRow{SeverityDetails: formats.SeverityDetails{Severity: severity}}
+	}
+	fixableOnly := true
+	testCases := []struct {
+		name       string
+		policy     FailPolicy
+		issues     *IssuesCollection
+		violations bool
+		expected   bool
+	}{
+		{name: "No issues", issues: &IssuesCollection{}},
+		{name: "Any issue fails by default", issues: &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("Low", "")}}, expected: true},
+		{name: "Secrets fail the scan", issues: &IssuesCollection{Secrets: []formats.SourceCodeRow{sourceCodeIssue("High")}}, expected: true},
+		{name: "Licenses without a severity", policy: FailPolicy{MinSeverity: "Critical"}, issues: &IssuesCollection{Licenses: []formats.LicenseRow{{LicenseKey: "GPL-3.0"}}}, expected: true},
+		{
+			name:   "Below the minimum severity",
+			policy: FailPolicy{MinSeverity: "High"},
+			issues: &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("Medium", "")}, Iacs: []formats.SourceCodeRow{sourceCodeIssue("Low")}},
+		},
+		{
+			name:     "Above the minimum severity",
+			policy:   FailPolicy{MinSeverity: "High"},
+			issues:   &IssuesCollection{Sast: []formats.SourceCodeRow{sourceCodeIssue("Critical")}},
+			expected: true,
+		},
+		{
+			name:   "Not applicable",
+			policy: FailPolicy{ApplicableOnly: true},
+			issues: &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("High", "Not Applicable"), vulnerability("High", "Undetermined")}},
+		},
+		{
+			name:     "Applicable",
+			policy:   FailPolicy{ApplicableOnly: true},
+			issues:   &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("High", "Applicable")}},
+			expected: true,
+		},
+		{
+			name:   "Not fixable",
+			policy: FailPolicy{FixableOnly: &fixableOnly},
+			issues: &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("High", "")}},
+		},
+		{
+			name:     "Fixable",
+			policy:   FailPolicy{FixableOnly: &fixableOnly},
+			issues:   &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("High", "", "1.0.1")}},
+			expected: true,
+		},
+		{
+			name:   "Within the threshold",
+			policy: FailPolicy{Thresholds: FailThresholds{Iac: 2}},
+			issues: &IssuesCollection{Iacs: []formats.SourceCodeRow{sourceCodeIssue("High"), sourceCodeIssue("High")}},
+		},
+		{
+			name:     "Exceeding the threshold",
+			policy:   FailPolicy{Thresholds: FailThresholds{Iac: 1}},
+			issues:   &IssuesCollection{Iacs: []formats.SourceCodeRow{sourceCodeIssue("High"), sourceCodeIssue("High")}},
+			expected: true,
+		},
+		{
+			name:   "Disabled category",
+			policy: FailPolicy{Thresholds: FailThresholds{Sast: -1}},
+			issues: &IssuesCollection{Sast: []formats.SourceCodeRow{sourceCodeIssue("Critical")}},
+		},
+		{
+			name:       "Security violations threshold",
+			policy:     FailPolicy{Thresholds: FailThresholds{Vulnerabilities: -1}},
+			issues:     &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("High", "")}},
+			violations: true,
+			expected:   true,
+		},
+		{
+			name:   "Vulnerabilities threshold",
+			policy: FailPolicy{Thresholds: FailThresholds{Vulnerabilities: -1}},
+			issues: &IssuesCollection{Vulnerabilities: []formats.VulnerabilityOrViolationRow{vulnerability("High", "")}},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.policy.IsFailing(test.issues, test.violations))
+		})
+	}
+}
+
+func TestScanGetFailPolicy(t *testing.T) {
+	fixableOnly, allIssues := true, false
+	testCases := []struct {
+		name     string
+		scan     Scan
+		expected FailPolicy
+	}{
+		{name: "Inherits the display filters", scan: Scan{MinSeverity: "High", FixableOnly: true}, expected: FailPolicy{MinSeverity: "High", FixableOnly: &fixableOnly}},
+		{
+			name:     "Lower minimum severity",
+			scan:     Scan{MinSeverity: "High", FailPolicy: FailPolicy{MinSeverity: "Low"}},
+			expected: FailPolicy{MinSeverity: "Low", FixableOnly: &allIssues},
+		},
+		{
+			name:     "Fails on issues without a fix version",
+			scan:     Scan{FixableOnly: true, FailPolicy: FailPolicy{FixableOnly: &allIssues}},
+			expected: FailPolicy{FixableOnly: &allIssues},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, &test.expected, test.scan.GetFailPolicy())
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	clitool "github.com/urfave/cli/v2"
+)
+
+// Each command-line flag is mapped to the environment variable it overrides.
+// The values of the flags take precedence over both the environment variables and the frogbot-config.yml file.
+// Secrets, such as passwords and tokens, have no flags, since the command line is exposed in the process list, the shell history and the CI logs.
+// They're provided with the environment variables, or with files in the matching <env>_FILE environment variables.
+type envFlag struct {
+	name   string
+	env    string
+	usage  string
+	isBool bool
+}
+
+var (
+	jfrogCredentialsFlags = []envFlag{
+		{name: "jfrog-url", env: JFrogUrlEnv, usage: "JFrog Platform URL"},
+		{name: "jfrog-xray-url", env: jfrogXrayUrlEnv, usage: "JFrog Xray URL. Can be used instead of the JFrog Platform URL, together with the JFrog Artifactory URL"},
+		{name: "jfrog-artifactory-url", env: jfrogArtifactoryUrlEnv, usage: "JFrog Artifactory URL. Can be used instead of the JFrog Platform URL, together with the JFrog Xray URL"},
+		{name: "jfrog-user", env: JFrogUserEnv, usage: "JFrog Platform username"},
+		{name: "jfrog-releases-repo", env: jfrogReleasesRepoEnv, usage: "Remote repository in Artifactory that proxies https://releases.jfrog.io, used to download the JFrog CLI and analyzer manager"},
+	}
+
+	jfrogPlatformFlags = []envFlag{
+		{name: "watches", env: jfrogWatchesEnv, usage: "Comma separated list of JFrog Xray watches"},
+		{name: "jfrog-project", env: jfrogProjectEnv, usage: "JFrog project key"},
+	}
+
+	gitFlags = []envFlag{
+		{name: "git-provider", env: GitProvider, usage: fmt.Sprintf("Git provider. Possible values: %s, %s, %s, %s", GitHub, GitLab, BitbucketServer, AzureRepos)},
+		{name: "git-owner", env: GitRepoOwnerEnv, usage: "Git repository owner (organization or user)"},
+		{name: "git-repo", env: GitRepoEnv, usage: "Git repository name"},
+		{name: "git-api-endpoint", env: GitApiEndpointEnv, usage: "Git provider API endpoint, for on-premises installations"},
+		{name: "git-username", env: GitUsernameEnv, usage: "Git username. Mandatory for Bitbucket Server"},
+		{name: "git-project", env: GitProjectEnv, usage: "Azure Repos project name. Mandatory for Azure Repos"},
+		{name: "git-base-branch", env: GitBaseBranchEnv, usage: "Git base branch"},
+	}
+
+	pullRequestFlags = []envFlag{
+		{name: "git-pull-request-id", env: GitPullRequestIDEnv, usage: "ID of the pull request to scan"},
+	}
+
+	scanFlags = []envFlag{
+		{name: "working-dir", env: WorkingDirectoryEnv, usage: "Relative path to the project's working directory"},
+		{name: "install-deps-cmd", env: InstallCommandEnv, usage: "Command to install the project dependencies"},
+		{name: "requirements-file", env: RequirementsFileEnv, usage: "Pip requirements file, for pip projects"},
+		{name: "use-wrapper", env: UseWrapperEnv, usage: "Whether to use the Gradle or Maven wrapper", isBool: true},
+		{name: "deps-repo", env: DepsRepoEnv, usage: "Artifactory repository to resolve the project dependencies from"},
+		{name: "include-all-vulnerabilities", env: IncludeAllVulnerabilitiesEnv, usage: "Whether to show all the vulnerabilities, and not only the ones added by the pull request", isBool: true},
+		{name: "fail-on-security-issues", env: FailOnSecurityIssuesEnv, usage: "Whether to fail the scan if security issues are found", isBool: true},
+		{name: "min-severity", env: MinSeverityEnv, usage: "Minimum severity of the issues to show. Possible values: Low, Medium, High, Critical"},
+		{name: "fixable-only", env: FixableOnlyEnv, usage: "Whether to show only issues with an available fix version", isBool: true},
+		{name: "allowed-licenses", env: AllowedLicensesEnv, usage: "Comma separated list of allowed licenses"},
+		{name: "fail-min-severity", env: FailMinSeverityEnv, usage: "Minimum severity of the issues that fail the scan, regardless of the minimum severity of the issues to show. Possible values: Low, Medium, High, Critical"},
+		{name: "fail-applicable-only", env: FailApplicableOnlyEnv, usage: "Whether only vulnerabilities with an applicable CVE fail the scan", isBool: true},
+		{name: "fail-fixable-only", env: FailFixableOnlyEnv, usage: "Whether only vulnerabilities with an available fix version fail the scan", isBool: true},
+	}
+
+	// Publishing a commit status is relevant only for commands scanning pull requests
+	commitStatusFlags = []envFlag{
+		{name: "publish-commit-status", env: PublishCommitStatusEnv, usage: "Whether to publish the scan result as a commit status on the head commit of the pull request", isBool: true},
+	}
+
+	emailFlags = []envFlag{
+		{name: "smtp-server", env: SmtpServerEnv, usage: "SMTP server and port, in the format smtp.server.com:port, for exposed secrets email notifications"},
+		{name: "smtp-user", env: SmtpUserEnv, usage: "SMTP server username"},
+		{name: "email-receivers", env: EmailReceiversEnv, usage: "Comma separated list of emails to notify about exposed secrets"},
+	}
+
+	fixFlags = []envFlag{
+		{name: "branch-name-template", env: BranchNameTemplateEnv, usage: "Template for the names of the fix branches. Must contain " + BranchHashPlaceHolder},
+		{name: "commit-message-template", env: CommitMessageTemplateEnv, usage: "Template for the fix commit messages"},
+		{name: "pull-request-title-template", env: PullRequestTitleTemplateEnv, usage: "Template for the fix pull request titles"},
+		{name: "git-email-author", env: GitEmailAuthorEnv, usage: "Email of the fix commits author"},
+		{name: "git-aggregate-fixes", env: GitAggregateFixesEnv, usage: "Whether to aggregate all the fixes into a single pull request", isBool: true},
+		{name: "fix-strategy", env: FixStrategyEnv, usage: fmt.Sprintf("The fix versions to upgrade the vulnerable dependencies to. Possible values: %s (default), %s (same major version), %s (same major and minor versions)", FixStrategyAny, FixStrategyMinor, FixStrategyPatch)},
+	}
+
+	// Scanning the local working tree doesn't involve a Git provider. The provider only determines the report format.
+	localGitFlags = []envFlag{
+		{name: "git-provider", env: GitProvider, usage: fmt.Sprintf("Git provider, determines the format of the report. Possible values: %s, %s, %s, %s", GitHub, GitLab, BitbucketServer, AzureRepos)},
+		{name: "git-repo", env: GitRepoEnv, usage: "Git repository name. Defaults to the name of the current directory"},
+	}
+
+	// The Git parameters that are stored in the frogbot-config.yml file
+	configGitFlags = []envFlag{
+		{name: "git-repo", env: GitRepoEnv, usage: "Git repository name. Defaults to the name of the current directory"},
+		{name: "git-base-branch", env: GitBaseBranchEnv, usage: "Git base branch"},
+	}
+
+	runSummaryFlags = []envFlag{
+		{name: "run-summary-file", env: RunSummaryFileEnv, usage: "Path of a JSON file to write a summary of the run to, including the exit code, the issues found and the pull requests created"},
+	}
+
+	// The audit results of the target branches are cached for the commands that scan pull requests
+	resultsCacheFlags = []envFlag{
+		{name: "results-cache-dir", env: ResultsCacheDirEnv, usage: "Local directory to cache the audit results of the target branches in. Defaults to the user cache directory"},
+		{name: "results-cache-repo", env: ResultsCacheRepoEnv, usage: "Generic repository in Artifactory to cache the audit results of the target branches in, instead of a local directory"},
+		{name: "disable-results-cache", env: DisableResultsCacheEnv, usage: "Whether to audit the target branches without caching the results", isBool: true},
+	}
+
+	flagsToEnvs = mapFlagsToEnvs(jfrogCredentialsFlags, jfrogPlatformFlags, gitFlags, pullRequestFlags, scanFlags, commitStatusFlags, emailFlags, fixFlags, runSummaryFlags, resultsCacheFlags)
+)
+
+func mapFlagsToEnvs(flagsGroups ...[]envFlag) map[string]string {
+	flagsToEnvs := make(map[string]string)
+	for _, flags := range flagsGroups {
+		for _, flag := range flags {
+			flagsToEnvs[flag.name] = flag.env
+		}
+	}
+	return flagsToEnvs
+}
+
+func toCliFlags(flagsGroups ...[]envFlag) (cliFlags []clitool.Flag) {
+	for _, flags := range flagsGroups {
+		for _, flag := range flags {
+			usage := fmt.Sprintf("%s. Overrides the %s environment variable", flag.usage, flag.env)
+			if flag.isBool {
+				cliFlags = append(cliFlags, &clitool.BoolFlag{Name: flag.name, Usage: usage})
+				continue
+			}
+			cliFlags = append(cliFlags, &clitool.StringFlag{Name: flag.name, Usage: usage})
+		}
+	}
+	return
+}
+
+func GetScanPullRequestFlags() []clitool.Flag {
+	return toCliFlags(jfrogCredentialsFlags, jfrogPlatformFlags, gitFlags, pullRequestFlags, scanFlags, commitStatusFlags, emailFlags, runSummaryFlags, resultsCacheFlags)
+}
+
+func GetScanAllPullRequestsFlags() []clitool.Flag {
+	return toCliFlags(jfrogCredentialsFlags, jfrogPlatformFlags, gitFlags, scanFlags, commitStatusFlags, emailFlags, runSummaryFlags, resultsCacheFlags)
+}
+
+func GetScanRepositoryFlags() []clitool.Flag {
+	return toCliFlags(jfrogCredentialsFlags, jfrogPlatformFlags, gitFlags, scanFlags, emailFlags, fixFlags, runSummaryFlags)
+}
+
+func GetServeFlags() []clitool.Flag {
+	return toCliFlags(jfrogCredentialsFlags, jfrogPlatformFlags, gitFlags, scanFlags, commitStatusFlags, emailFlags, runSummaryFlags, resultsCacheFlags)
+}
+
+func GetReportFlags() []clitool.Flag {
+	return toCliFlags(jfrogCredentialsFlags, jfrogPlatformFlags, gitFlags, scanFlags, runSummaryFlags)
+}
+
+func GetScanDiffFlags() []clitool.Flag {
+	return toCliFlags(jfrogCredentialsFlags, jfrogPlatformFlags, gitFlags, scanFlags, runSummaryFlags)
+}
+
+func GetScanLocalFlags() []clitool.Flag {
+	return toCliFlags(jfrogCredentialsFlags, jfrogPlatformFlags, localGitFlags, scanFlags, runSummaryFlags)
+}
+
+func GetValidateConfigFlags() []clitool.Flag {
+	return toCliFlags(jfrogPlatformFlags, scanFlags, fixFlags, runSummaryFlags)
+}
+
+func GetMigrateConfigFlags() []clitool.Flag {
+	return toCliFlags(jfrogPlatformFlags, configGitFlags, scanFlags, fixFlags, runSummaryFlags)
+}
+
+// SetEnvsFromFlags sets the environment variables mapped to the flags provided in the command line.
+// Returns the names of the environment variables that were set.
+func SetEnvsFromFlags(ctx *clitool.Context) (flagsEnvs []string, err error) {
+	for _, flag := range ctx.Command.Flags {
+		flagName := flag.Names()[0]
+		env, exists := flagsToEnvs[flagName]
+		if !exists || !ctx.IsSet(flagName) {
+			continue
+		}
+		if err = os.Setenv(env, fmt.Sprint(ctx.Value(flagName))); err != nil {
+			return
+		}
+		flagsEnvs = append(flagsEnvs, env)
+	}
+	return
+}
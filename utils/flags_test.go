@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	clitool "github.com/urfave/cli/v2"
+)
+
+func TestSetEnvsFromFlags(t *testing.T) {
+	SetEnvAndAssert(t, map[string]string{MinSeverityEnv: "Low", FixableOnlyEnv: "false", jfrogWatchesEnv: "watch-1"})
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+
+	var flagsEnvs []string
+	app := clitool.App{
+		Commands: []*clitool.Command{{
+			Name:  ScanRepository,
+			Flags: GetScanRepositoryFlags(),
+			Action: func(ctx *clitool.Context) (err error) {
+				flagsEnvs, err = SetEnvsFromFlags(ctx)
+				return
+			},
+		}},
+	}
+	assert.NoError(t, app.Run([]string{"frogbot", ScanRepository, "--min-severity=High", "--fixable-only", "--git-aggregate-fixes=false"}))
+	assert.ElementsMatch(t, []string{MinSeverityEnv, FixableOnlyEnv, GitAggregateFixesEnv}, flagsEnvs)
+
+	// Flags should override the environment variables
+	assert.Equal(t, "High", getTrimmedEnv(MinSeverityEnv))
+	assert.Equal(t, "true", getTrimmedEnv(FixableOnlyEnv))
+	assert.Equal(t, "false", getTrimmedEnv(GitAggregateFixesEnv))
+	// Environment variables without a flag should remain untouched
+	assert.Equal(t, "watch-1", getTrimmedEnv(jfrogWatchesEnv))
+}
+
+func TestFlagsMappedToEnvs(t *testing.T) {
+	for _, flags := range [][]clitool.Flag{GetScanPullRequestFlags(), GetScanAllPullRequestsFlags(), GetScanRepositoryFlags(), GetScanLocalFlags()} {
+		for _, flag := range flags {
+			assert.Contains(t, flagsToEnvs, flag.Names()[0])
+		}
+	}
+}
+
+func TestNoSecretFlags(t *testing.T) {
+	secretEnvs := []string{JFrogPasswordEnv, JFrogTokenEnv, JFrogOidcTokenEnv, GitTokenEnv, SmtpPasswordEnv, WebhookSecretEnv}
+	for flag, env := range flagsToEnvs {
+		assert.NotContains(t, secretEnvs, env, "the %s flag exposes a secret in the command line", flag)
+	}
+}
@@ -305,6 +305,27 @@ func (gm *GitManager) Push(force bool, branchName string) error {
 	return nil
 }
 
+// GetHeadCommitDiff returns the changes made by the HEAD commit, compared to its parent commit, in the unified diff format.
+func (gm *GitManager) GetHeadCommitDiff() (string, error) {
+	head, err := gm.localGitRepository.Head()
+	if err != nil {
+		return "", err
+	}
+	headCommit, err := gm.localGitRepository.CommitObject(head.Hash())
+	if err != nil {
+		return "", err
+	}
+	parentCommit, err := headCommit.Parent(0)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the parent of commit %s: %s", head.Hash(), err.Error())
+	}
+	patch, err := parentCommit.Patch(headCommit)
+	if err != nil {
+		return "", err
+	}
+	return patch.String(), nil
+}
+
 // IsClean returns true if all the files are in Unmodified status.
 func (gm *GitManager) IsClean() (bool, error) {
 	worktree, err := gm.localGitRepository.Worktree()
@@ -375,6 +396,29 @@ func (gm *GitManager) GenerateFixBranchName(branch string, impactedPackage strin
 	return formatStringWithPlaceHolders(branchFormat, fixedPackageName, fixVersion, hash, "", false), nil
 }
 
+// GenerateFixBranchNameRegex returns a regular expression that matches the branch names generated by GenerateFixBranchName, for any package and fix version.
+func (gm *GitManager) GenerateFixBranchNameRegex() (*regexp.Regexp, error) {
+	branchFormat := gm.customTemplates.branchNameTemplate
+	if branchFormat == "" {
+		branchFormat = BranchNameTemplate
+	}
+	pattern := regexp.QuoteMeta(strings.ReplaceAll(branchFormat, " ", "_"))
+	replacements := []struct {
+		placeholder string
+		pattern     string
+	}{
+		{PackagePlaceHolder, ".+"},
+		{FixVersionPlaceHolder, ".+"},
+		{BranchHashPlaceHolder, "[0-9a-f]{32}"},
+	}
+	for _, r := range replacements {
+		// Same as in formatStringWithPlaceHolders, only the first placeholder is replaced, with or without the dollar sign ($) prefix
+		pattern = strings.Replace(pattern, regexp.QuoteMeta("$"+r.placeholder), r.pattern, 1)
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(r.placeholder), r.pattern, 1)
+	}
+	return regexp.Compile("^" + pattern + "$")
+}
+
 func (gm *GitManager) GeneratePullRequestTitle(impactedPackage string, version string) string {
 	template := PullRequestTitleTemplate
 	pullRequestFormat := gm.customTemplates.pullRequestTitleTemplate
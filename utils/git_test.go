@@ -100,6 +100,50 @@ func TestGitManager_GenerateFixBranchName(t *testing.T) {
 	}
 }
 
+func TestGitManager_GenerateFixBranchNameRegex(t *testing.T) {
+	testCases := []struct {
+		description      string
+		gitManager       GitManager
+		matchingBranches []string
+		otherBranches    []string
+	}{
+		{
+			description:      "No template",
+			gitManager:       GitManager{},
+			matchingBranches: []string{"frogbot-mquery-41b1f45136b25e3624b15999bd57a476", "frogbot-org.apache_log4j-41b1f45136b25e3624b15999bd57a476"},
+			otherBranches:    []string{"main", "frogbot-mquery-1234", "frogbot-update-npm-dependencies-master", "my-frogbot-mquery-41b1f45136b25e3624b15999bd57a476"},
+		},
+		{
+			description:      "Custom template",
+			gitManager:       GitManager{customTemplates: CustomTemplates{branchNameTemplate: "[Feature]-${IMPACTED_PACKAGE}-${FIX_VERSION}-${BRANCH_NAME_HASH}"}},
+			matchingBranches: []string{"[Feature]-mquery-3.4.5-41b1f45136b25e3624b15999bd57a476"},
+			otherBranches:    []string{"Feature-mquery-3.4.5-41b1f45136b25e3624b15999bd57a476", "[Feature]-mquery-41b1f45136b25e3624b15999bd57a476"},
+		},
+		{
+			description:      "Custom template without inputs",
+			gitManager:       GitManager{customTemplates: CustomTemplates{branchNameTemplate: "just-a-branch-${BRANCH_NAME_HASH}"}},
+			matchingBranches: []string{"just-a-branch-41b1f45136b25e3624b15999bd57a476"},
+			otherBranches:    []string{"just-a-branch-", "just-a-branch-mquery-41b1f45136b25e3624b15999bd57a476"},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			branchNameRegex, err := test.gitManager.GenerateFixBranchNameRegex()
+			assert.NoError(t, err)
+			for _, branch := range test.matchingBranches {
+				assert.True(t, branchNameRegex.MatchString(branch), branch)
+			}
+			for _, branch := range test.otherBranches {
+				assert.False(t, branchNameRegex.MatchString(branch), branch)
+			}
+			// The generated branch names should always match
+			generatedBranch, err := test.gitManager.GenerateFixBranchName("main", "org.apache:log4j", "2.17.1")
+			assert.NoError(t, err)
+			assert.True(t, branchNameRegex.MatchString(generatedBranch), generatedBranch)
+		})
+	}
+}
+
 func TestGitManager_GeneratePullRequestTitle(t *testing.T) {
 	testCases := []struct {
 		gitManager      GitManager
@@ -223,6 +267,36 @@ func TestGitManager_Checkout(t *testing.T) {
 	assert.Equal(t, "master", currBranch)
 }
 
+func TestGitManager_GetHeadCommitDiff(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fileutils.RemoveTempDir(tmpDir))
+	}()
+	restoreWd, err := Chdir(tmpDir)
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, restoreWd())
+	}()
+	gitManager := createFakeDotGit(t, tmpDir)
+	_, err = gitManager.SetGitParams(&Git{EmailAuthor: frogbotAuthorEmail})
+	assert.NoError(t, err)
+
+	// A commit without a parent
+	_, err = gitManager.GetHeadCommitDiff()
+	assert.Error(t, err)
+
+	assert.NoError(t, gitManager.CreateBranchAndCheckout("fix"))
+	assert.NoError(t, os.WriteFile("README.md", []byte("# My Fixed Repository\n"), 0644))
+	assert.NoError(t, gitManager.AddAllAndCommit("fix readme"))
+	diff, err := gitManager.GetHeadCommitDiff()
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "--- a/README.md")
+	assert.Contains(t, diff, "+++ b/README.md")
+	assert.Contains(t, diff, "-# My New Repository")
+	assert.Contains(t, diff, "+# My Fixed Repository")
+}
+
 func createFakeDotGit(t *testing.T, testPath string) *GitManager {
 	// Initialize a new in-memory repository
 	repo, err := git.PlainInit(testPath, false)
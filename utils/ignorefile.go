@@ -0,0 +1,345 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FrogbotIgnoreFile      = "ignore.yml"
+	ignoreRuleDateLayout   = "2006-01-02"
+	invalidVersionRangeErr = "invalid version range %s. A version range should be formatted as [1.0.0,2.0.0)"
+)
+
+var OsFrogbotIgnorePath = filepath.Join(frogbotConfigDir, FrogbotIgnoreFile)
+
+// The .frogbot/ignore.yml file, read from the scanned branch.
+// Each rule excludes the findings it matches from the scan results, until the rule expires.
+type IgnoreFile struct {
+	// The root directory of the scanned branch
+	repoDir string
+	// The rules that haven't expired
+	activeRules []IgnoreRule
+	// The expired rules, which no longer exclude findings
+	expiredRules []IgnoreRule
+}
+
+// A rule of the .frogbot/ignore.yml file.
+// A rule matches a finding by a single selector: a CVE, an Xray ID, a package, a license, a rule ID or a path.
+// A rule ID may be combined with a path, to ignore the rule in specific files only.
+type IgnoreRule struct {
+	Cve     string         `yaml:"cve,omitempty" json:"cve,omitempty"`
+	XrayId  string         `yaml:"xrayId,omitempty" json:"xrayId,omitempty"`
+	Package IgnoredPackage `yaml:"package,omitempty" json:"package,omitempty"`
+	License string         `yaml:"license,omitempty" json:"license,omitempty"`
+	RuleId  string         `yaml:"ruleId,omitempty" json:"ruleId,omitempty"`
+	// A glob pattern of the files to ignore the source code findings in, relative to the root of the repository.
+	// '*' matches any part of a single path segment, and '**' matches any number of path segments.
+	Path   string `yaml:"path,omitempty" json:"path,omitempty"`
+	Reason string `yaml:"reason" json:"reason"`
+	// The date (YYYY-MM-DD) after which the rule stops excluding findings
+	ExpiresOn string `yaml:"expiresOn,omitempty" json:"expiresOn,omitempty"`
+}
+
+type IgnoredPackage struct {
+	Name string `yaml:"name" json:"name"`
+	// An exact version, or a range such as [1.0.0,2.0.0). An empty version matches all the versions of the package.
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+}
+
+type ignoreFileContent struct {
+	Ignore []IgnoreRule `yaml:"ignore"`
+}
+
+// ReadIgnoreFile reads the .frogbot/ignore.yml file from the root directory of the scanned branch.
+// Returns nil if the file doesn't exist. The methods of a nil IgnoreFile don't ignore any finding.
+func ReadIgnoreFile(repoDir string) (*IgnoreFile, error) {
+	content, err := os.ReadFile(filepath.Join(repoDir, OsFrogbotIgnorePath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	ignoreFile, err := parseIgnoreFile(content, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	ignoreFile.repoDir = repoDir
+	for _, rule := range ignoreFile.expiredRules {
+		log.Warn(fmt.Sprintf("The %s rule in the %s file expired on %s and no longer ignores findings", rule.Finding(), OsFrogbotIgnorePath, rule.ExpiresOn))
+	}
+	return ignoreFile, nil
+}
+
+func parseIgnoreFile(content []byte, now time.Time) (ignoreFile *IgnoreFile, err error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	var fileContent ignoreFileContent
+	// An empty file doesn't include any rule
+	if decodeErr := decoder.Decode(&fileContent); decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
+		return nil, fmt.Errorf("failed to parse the %s file: %s", OsFrogbotIgnorePath, decodeErr.Error())
+	}
+	ignoreFile = &IgnoreFile{}
+	today := now.Format(ignoreRuleDateLayout)
+	for i, rule := range fileContent.Ignore {
+		if err = rule.validate(); err != nil {
+			return nil, fmt.Errorf("rule #%d in the %s file is invalid: %w", i+1, OsFrogbotIgnorePath, err)
+		}
+		// Dates in the YYYY-MM-DD format are ordered lexicographically
+		if rule.ExpiresOn != "" && rule.ExpiresOn < today {
+			ignoreFile.expiredRules = append(ignoreFile.expiredRules, rule)
+			continue
+		}
+		ignoreFile.activeRules = append(ignoreFile.activeRules, rule)
+	}
+	return
+}
+
+func (rule *IgnoreRule) validate() error {
+	if strings.TrimSpace(rule.Reason) == "" {
+		return errors.New("a reason is mandatory")
+	}
+	selectors := 0
+	for _, selector := range []string{rule.Cve, rule.XrayId, rule.Package.Name, rule.License, rule.RuleId} {
+		if selector != "" {
+			selectors++
+		}
+	}
+	if rule.Path != "" && rule.RuleId == "" {
+		selectors++
+	}
+	if selectors != 1 {
+		return errors.New("exactly one of cve, xrayId, package, license, ruleId or path should be set. A path may be combined with a ruleId only")
+	}
+	if rule.Package.Version != "" && rule.Package.Name == "" {
+		return errors.New("a package version requires a package name")
+	}
+	if _, _, err := parseVersionRange(rule.Package.Version); err != nil {
+		return err
+	}
+	if _, err := path.Match(strings.ReplaceAll(rule.Path, "**", "*"), ""); err != nil {
+		return fmt.Errorf("invalid path pattern %s: %w", rule.Path, err)
+	}
+	if rule.ExpiresOn != "" {
+		if _, err := time.Parse(ignoreRuleDateLayout, rule.ExpiresOn); err != nil {
+			return fmt.Errorf("the expiration date %s should be in the YYYY-MM-DD format", rule.ExpiresOn)
+		}
+	}
+	return nil
+}
+
+// Finding returns a short description of the findings the rule matches.
+func (rule *IgnoreRule) Finding() string {
+	switch {
+	case rule.Cve != "":
+		return rule.Cve
+	case rule.XrayId != "":
+		return rule.XrayId
+	case rule.Package.Name != "" && rule.Package.Version != "":
+		return rule.Package.Name + ":" + rule.Package.Version
+	case rule.Package.Name != "":
+		return rule.Package.Name
+	case rule.License != "":
+		return rule.License
+	case rule.RuleId != "" && rule.Path != "":
+		return rule.RuleId + " in " + rule.Path
+	case rule.RuleId != "":
+		return rule.RuleId
+	}
+	return rule.Path
+}
+
+// FilterIssues removes the vulnerabilities, violations and licenses matched by the active rules from the issues,
+// and adds the expired rules to the issues, so they can be called out.
+// The source code findings are filtered before the issues are created, using FilterScanResults.
+func (ignoreFile *IgnoreFile) FilterIssues(issues *IssuesCollection) {
+	if ignoreFile == nil || issues == nil {
+		return
+	}
+	var vulnerabilities []formats.VulnerabilityOrViolationRow
+	for i := range issues.Vulnerabilities {
+		if !ignoreFile.IgnoresVulnerability(&issues.Vulnerabilities[i]) {
+			vulnerabilities = append(vulnerabilities, issues.Vulnerabilities[i])
+		}
+	}
+	issues.Vulnerabilities = vulnerabilities
+	var licenses []formats.LicenseRow
+	for i := range issues.Licenses {
+		if !ignoreFile.ignoresLicense(&issues.Licenses[i]) {
+			licenses = append(licenses, issues.Licenses[i])
+		}
+	}
+	issues.Licenses = licenses
+	issues.appendExpiredIgnoreRules(ignoreFile.expiredRules)
+}
+
+// IgnoresVulnerability returns true if an active rule matches the vulnerability or security violation.
+func (ignoreFile *IgnoreFile) IgnoresVulnerability(vulnerability *formats.VulnerabilityOrViolationRow) bool {
+	if ignoreFile == nil {
+		return false
+	}
+	for i := range ignoreFile.activeRules {
+		rule := &ignoreFile.activeRules[i]
+		switch {
+		case rule.Cve != "":
+			for _, cve := range vulnerability.Cves {
+				if strings.EqualFold(cve.Id, rule.Cve) {
+					return true
+				}
+			}
+		case rule.XrayId != "":
+			if strings.EqualFold(vulnerability.IssueId, rule.XrayId) {
+				return true
+			}
+		case rule.Package.Name != "":
+			if rule.matchesPackage(vulnerability.ImpactedDependencyName, vulnerability.ImpactedDependencyVersion) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (ignoreFile *IgnoreFile) ignoresLicense(license *formats.LicenseRow) bool {
+	for i := range ignoreFile.activeRules {
+		rule := &ignoreFile.activeRules[i]
+		if rule.License != "" && strings.EqualFold(license.LicenseKey, rule.License) {
+			return true
+		}
+		if rule.Package.Name != "" && rule.matchesPackage(license.ImpactedDependencyName, license.ImpactedDependencyVersion) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *IgnoreRule) matchesPackage(name, packageVersion string) bool {
+	if name != rule.Package.Name {
+		return false
+	}
+	return isVersionInRange(packageVersion, rule.Package.Version)
+}
+
+// FilterScanResults removes the IaC, Secrets and SAST findings matched by the active rules from the scan results.
+// The paths of the findings are matched relative to the root directory of the scanned branch.
+func (ignoreFile *IgnoreFile) FilterScanResults(scanResults *xrayutils.ExtendedScanResults) {
+	if ignoreFile == nil {
+		return
+	}
+	filterSourceCodeResults(scanResults, func(result *sarif.Result) bool {
+		return !ignoreFile.ignoresSourceCodeResult(result)
+	})
+}
+
+func (ignoreFile *IgnoreFile) ignoresSourceCodeResult(result *sarif.Result) bool {
+	for i := range ignoreFile.activeRules {
+		rule := &ignoreFile.activeRules[i]
+		if rule.RuleId == "" && rule.Path == "" {
+			continue
+		}
+		if rule.RuleId != "" && (result.RuleID == nil || *result.RuleID != rule.RuleId) {
+			continue
+		}
+		if rule.Path == "" {
+			return true
+		}
+		for _, location := range result.Locations {
+			if matchPathPattern(rule.Path, xrayutils.ExtractRelativePath(xrayutils.GetLocationFileName(location), ignoreFile.repoDir)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Parses a version range such as [1.0.0,2.0.0), (,1.2.3] or an exact version such as 1.2.3.
+// Returns the bounds of the range, where a bound is nil if the range is unbounded on that side.
+func parseVersionRange(versionRange string) (lowerBound, upperBound *versionBound, err error) {
+	if versionRange == "" {
+		return
+	}
+	if !strings.ContainsAny(versionRange[:1], "[(") {
+		exactVersion := &versionBound{version: versionRange, inclusive: true}
+		return exactVersion, exactVersion, nil
+	}
+	if len(versionRange) < 2 || !strings.ContainsAny(versionRange[len(versionRange)-1:], "])") {
+		return nil, nil, fmt.Errorf(invalidVersionRangeErr, versionRange)
+	}
+	lowerVersion, upperVersion, found := strings.Cut(versionRange[1:len(versionRange)-1], ",")
+	if !found || strings.Contains(upperVersion, ",") {
+		return nil, nil, fmt.Errorf(invalidVersionRangeErr, versionRange)
+	}
+	if lowerVersion = strings.TrimSpace(lowerVersion); lowerVersion != "" {
+		lowerBound = &versionBound{version: lowerVersion, inclusive: versionRange[0] == '['}
+	}
+	if upperVersion = strings.TrimSpace(upperVersion); upperVersion != "" {
+		upperBound = &versionBound{version: upperVersion, inclusive: versionRange[len(versionRange)-1] == ']'}
+	}
+	return
+}
+
+type versionBound struct {
+	version   string
+	inclusive bool
+}
+
+func isVersionInRange(packageVersion, versionRange string) bool {
+	lowerBound, upperBound, err := parseVersionRange(versionRange)
+	if err != nil {
+		return false
+	}
+	currentVersion := version.NewVersion(packageVersion)
+	if lowerBound != nil {
+		// Compare returns 1 if the bound is greater than the current version
+		if comparison := currentVersion.Compare(lowerBound.version); comparison > 0 || (comparison == 0 && !lowerBound.inclusive) {
+			return false
+		}
+	}
+	if upperBound != nil {
+		if comparison := currentVersion.Compare(upperBound.version); comparison < 0 || (comparison == 0 && !upperBound.inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches a slash separated path against a glob pattern, in which '**' matches any number of path segments.
+func matchPathPattern(pattern, filePath string) bool {
+	return matchPathSegments(strings.Split(pattern, "/"), strings.Split(filepath.ToSlash(filePath), "/"))
+}
+
+func matchPathSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(pathSegments); i++ {
+			if matchPathSegments(patternSegments[1:], pathSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pathSegments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(patternSegments[0], pathSegments[0]); err != nil || !matched {
+		return false
+	}
+	return matchPathSegments(patternSegments[1:], pathSegments[1:])
+}
@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testIgnoreFileContent = []byte(`
+ignore:
+  - cve: CVE-2023-0001
+    reason: The vulnerable function isn't used
+  - xrayId: XRAY-1000
+    reason: Accepted risk
+    expiresOn: 2024-06-30
+  - package:
+      name: lodash
+      version: "[4.0.0,4.17.21)"
+    reason: Upgrade is planned
+  - license: GPL-3.0
+    reason: Approved by legal
+  - path: "test/**/*.tf"
+    reason: Test fixtures
+  - ruleId: aws-s3-public
+    path: public/*
+    reason: Public assets bucket
+  - cve: CVE-2023-0002
+    reason: Expired
+    expiresOn: 2024-01-01
+`)
+
+func TestParseIgnoreFile(t *testing.T) {
+	ignoreFile, err := parseIgnoreFile(testIgnoreFileContent, time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	// A rule is active until the end of its expiration date
+	assert.Len(t, ignoreFile.activeRules, 6)
+	require.Len(t, ignoreFile.expiredRules, 1)
+	assert.Equal(t, "CVE-2023-0002", ignoreFile.expiredRules[0].Cve)
+
+	ignoreFile, err = parseIgnoreFile(testIgnoreFileContent, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Len(t, ignoreFile.activeRules, 5)
+	assert.Len(t, ignoreFile.expiredRules, 2)
+
+	ignoreFile, err = parseIgnoreFile([]byte{}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, ignoreFile.activeRules)
+}
+
+func TestParseIgnoreFileErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{name: "Missing reason", content: "ignore:\n  - cve: CVE-2023-0001\n", expectedError: "rule #1 in the .frogbot/ignore.yml file is invalid: a reason is mandatory"},
+		{name: "No selector", content: "ignore:\n  - reason: No selector\n", expectedError: "exactly one of cve, xrayId, package, license, ruleId or path should be set"},
+		{name: "Multiple selectors", content: "ignore:\n  - cve: CVE-2023-0001\n    license: MIT\n    reason: Multiple\n", expectedError: "exactly one of cve, xrayId, package, license, ruleId or path should be set"},
+		{name: "Package version without name", content: "ignore:\n  - cve: CVE-2023-0001\n    package:\n      version: 1.0.0\n    reason: Version\n", expectedError: "a package version requires a package name"},
+		{name: "Invalid version range", content: "ignore:\n  - package:\n      name: lodash\n      version: \"[1.0.0\"\n    reason: Range\n", expectedError: "invalid version range [1.0.0"},
+		{name: "Invalid expiration date", content: "ignore:\n  - cve: CVE-2023-0001\n    reason: Date\n    expiresOn: 30/06/2024\n", expectedError: "the expiration date 30/06/2024 should be in the YYYY-MM-DD format"},
+		{name: "Invalid path", content: "ignore:\n  - path: \"[a\"\n    reason: Path\n", expectedError: "invalid path pattern [a"},
+		{name: "Unknown field", content: "ignore:\n  - cves: CVE-2023-0001\n    reason: Typo\n", expectedError: "field cves not found"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseIgnoreFile([]byte(test.content), time.Now())
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestReadIgnoreFile(t *testing.T) {
+	repoDir := t.TempDir()
+	// A missing file doesn't ignore any finding
+	ignoreFile, err := ReadIgnoreFile(repoDir)
+	require.NoError(t, err)
+	assert.Nil(t, ignoreFile)
+	assert.False(t, ignoreFile.IgnoresVulnerability(&formats.VulnerabilityOrViolationRow{IssueId: "XRAY-1000"}))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, frogbotConfigDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, OsFrogbotIgnorePath), []byte("ignore:\n  - xrayId: XRAY-1000\n    reason: Accepted risk\n"), 0644))
+	ignoreFile, err = ReadIgnoreFile(repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, repoDir, ignoreFile.repoDir)
+	assert.True(t, ignoreFile.IgnoresVulnerability(&formats.VulnerabilityOrViolationRow{IssueId: "XRAY-1000"}))
+}
+
+func TestIgnoreFileFilterIssues(t *testing.T) {
+	ignoreFile, err := parseIgnoreFile(testIgnoreFileContent, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	vulnerability := func(name, version, issueId string, cves ...string) formats.VulnerabilityOrViolationRow {
+		row := formats.VulnerabilityOrViolationRow{
+			ImpactedDependencyDetails: formats.ImpactedDependencyDetails{ImpactedDependencyName: name, ImpactedDependencyVersion: version},
+			IssueId:                   issueId,
+		}
+		for _, cve := range cves {
+			row.Cves = append(row.Cves, formats.CveRow{Id: cve})
+		}
+		return row
+	}
+	license := func(name, version, licenseKey string) formats.LicenseRow {
+		return formats.LicenseRow{
+			ImpactedDependencyDetails: formats.ImpactedDependencyDetails{ImpactedDependencyName: name, ImpactedDependencyVersion: version},
+			LicenseKey:                licenseKey,
+		}
+	}
+	issues := &IssuesCollection{
+		Vulnerabilities: []formats.VulnerabilityOrViolationRow{
+			vulnerability("minimist", "1.2.5", "XRAY-1", "CVE-2023-0001"),
+			vulnerability("minimist", "1.2.5", "XRAY-1000"),
+			vulnerability("lodash", "4.17.20", "XRAY-2", "CVE-2023-0003"),
+			vulnerability("lodash", "4.17.21", "XRAY-3", "CVE-2023-0004"),
+			vulnerability("express", "4.0.0", "XRAY-4", "CVE-2023-0002"),
+		},
+		Licenses: []formats.LicenseRow{
+			license("gpl-package", "1.0.0", "GPL-3.0"),
+			license("lodash", "4.0.0", "AGPL-3.0"),
+			license("express", "4.0.0", "AGPL-3.0"),
+		},
+	}
+	ignoreFile.FilterIssues(issues)
+	assert.Equal(t, []formats.VulnerabilityOrViolationRow{
+		vulnerability("lodash", "4.17.21", "XRAY-3", "CVE-2023-0004"),
+		// The rule of CVE-2023-0002 expired
+		vulnerability("express", "4.0.0", "XRAY-4", "CVE-2023-0002"),
+	}, issues.Vulnerabilities)
+	assert.Equal(t, []formats.LicenseRow{license("express", "4.0.0", "AGPL-3.0")}, issues.Licenses)
+	require.Len(t, issues.ExpiredIgnoreRules, 1)
+	assert.Equal(t, "CVE-2023-0002", issues.ExpiredIgnoreRules[0].Finding())
+
+	// Each expired rule is added once, when the issues of several projects are appended
+	allIssues := &IssuesCollection{}
+	allIssues.Append(issues)
+	allIssues.Append(issues)
+	assert.Len(t, allIssues.ExpiredIgnoreRules, 1)
+}
+
+func TestIgnoreFileFilterScanResults(t *testing.T) {
+	repoDir := filepath.Join(string(filepath.Separator), "tmp", "repo")
+	ignoreFile, err := parseIgnoreFile(testIgnoreFileContent, time.Now())
+	require.NoError(t, err)
+	ignoreFile.repoDir = repoDir
+	result := func(ruleId, relativePath string) *sarif.Result {
+		return sarif.NewRuleResult(ruleId).WithLocations([]*sarif.Location{
+			sarif.NewLocationWithPhysicalLocation(sarif.NewPhysicalLocation().WithArtifactLocation(sarif.NewSimpleArtifactLocation("file://" + filepath.ToSlash(filepath.Join(repoDir, relativePath))))),
+		})
+	}
+	iacRun := sarif.NewRunWithInformationURI("JFrog Terraform scanner", "").WithResults([]*sarif.Result{
+		result("aws-s3-versioning", "test/modules/s3/main.tf"),
+		result("aws-s3-versioning", "main.tf"),
+		result("aws-s3-public", "public/bucket.tf"),
+		result("aws-s3-public", "private/bucket.tf"),
+	})
+	scanResults := &xrayutils.ExtendedScanResults{IacScanResults: []*sarif.Run{iacRun}}
+	ignoreFile.FilterScanResults(scanResults)
+	require.Len(t, iacRun.Results, 2)
+	assert.Equal(t, "main.tf", xrayutils.ExtractRelativePath(xrayutils.GetLocationFileName(iacRun.Results[0].Locations[0]), repoDir))
+	assert.Equal(t, "private/bucket.tf", xrayutils.ExtractRelativePath(xrayutils.GetLocationFileName(iacRun.Results[1].Locations[0]), repoDir))
+}
+
+func TestIsVersionInRange(t *testing.T) {
+	testCases := []struct {
+		version      string
+		versionRange string
+		expected     bool
+	}{
+		{version: "1.0.0", versionRange: "", expected: true},
+		{version: "1.0.0", versionRange: "1.0.0", expected: true},
+		{version: "1.0.1", versionRange: "1.0.0", expected: false},
+		{version: "1.0.0", versionRange: "[1.0.0,2.0.0)", expected: true},
+		{version: "2.0.0", versionRange: "[1.0.0,2.0.0)", expected: false},
+		{version: "1.0.0", versionRange: "(1.0.0,2.0.0]", expected: false},
+		{version: "2.0.0", versionRange: "(1.0.0,2.0.0]", expected: true},
+		{version: "0.1.0", versionRange: "(,1.0.0]", expected: true},
+		{version: "3.0.0", versionRange: "[2.0.0,)", expected: true},
+		{version: "1.9.9", versionRange: "[2.0.0,)", expected: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.version+" "+test.versionRange, func(t *testing.T) {
+			assert.Equal(t, test.expected, isVersionInRange(test.version, test.versionRange))
+		})
+	}
+}
+
+func TestMatchPathPattern(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{pattern: "main.tf", path: "main.tf", expected: true},
+		{pattern: "*.tf", path: "modules/main.tf", expected: false},
+		{pattern: "**/*.tf", path: "modules/main.tf", expected: true},
+		{pattern: "**/*.tf", path: "main.tf", expected: true},
+		{pattern: "test/**", path: "test/a/b/c.go", expected: true},
+		{pattern: "test/**", path: "src/test/c.go", expected: false},
+		{pattern: "src/*/config.yml", path: "src/app/config.yml", expected: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.pattern+" "+test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, matchPathPattern(test.pattern, test.path))
+		})
+	}
+}
@@ -1,13 +1,21 @@
 package utils
 
-import "github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+import (
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	"golang.org/x/exp/slices"
+)
 
 type IssuesCollection struct {
-	Vulnerabilities []formats.VulnerabilityOrViolationRow
-	Iacs            []formats.SourceCodeRow
-	Secrets         []formats.SourceCodeRow
-	Sast            []formats.SourceCodeRow
-	Licenses        []formats.LicenseRow
+	Vulnerabilities []formats.VulnerabilityOrViolationRow `json:"vulnerabilities,omitempty"`
+	Iacs            []formats.SourceCodeRow               `json:"iacViolations,omitempty"`
+	Secrets         []formats.SourceCodeRow               `json:"secrets,omitempty"`
+	Sast            []formats.SourceCodeRow               `json:"sastViolations,omitempty"`
+	Licenses        []formats.LicenseRow                  `json:"licenses,omitempty"`
+	// The expired rules of the .frogbot/ignore.yml file. The findings they match are no longer ignored.
+	ExpiredIgnoreRules []IgnoreRule `json:"expiredIgnoreRules,omitempty"`
+	// The issues of the target branch that aren't found in the source branch. They don't count as issues of the pull request.
+	FixedIssues []outputwriter.FixedIssue `json:"fixedIssues,omitempty"`
 }
 
 func (ic *IssuesCollection) VulnerabilitiesExists() bool {
@@ -31,7 +39,19 @@ func (ic *IssuesCollection) SastExists() bool {
 }
 
 func (ic *IssuesCollection) IssuesExists() bool {
-	return ic.VulnerabilitiesExists() || ic.IacExists() || ic.LicensesExists() || ic.SastExists()
+	return ic.VulnerabilitiesExists() || ic.IacExists() || ic.LicensesExists() || ic.SecretsExists() || ic.SastExists()
+}
+
+// FilterVulnerabilities keeps only the vulnerabilities and security violations of at least minSeverity, which have a fix version if fixableOnly is set.
+// Like the filters of the Xray scan, it doesn't apply to the other categories of issues.
+func (ic *IssuesCollection) FilterVulnerabilities(minSeverity string, fixableOnly bool) {
+	var filtered []formats.VulnerabilityOrViolationRow
+	for _, row := range ic.Vulnerabilities {
+		if MeetsMinSeverity(row.Severity, minSeverity) && (!fixableOnly || len(row.FixedVersions) > 0) {
+			filtered = append(filtered, row)
+		}
+	}
+	ic.Vulnerabilities = filtered
 }
 
 func (ic *IssuesCollection) Append(issues *IssuesCollection) {
@@ -53,4 +73,17 @@ func (ic *IssuesCollection) Append(issues *IssuesCollection) {
 	if len(issues.Licenses) > 0 {
 		ic.Licenses = append(ic.Licenses, issues.Licenses...)
 	}
+	if len(issues.FixedIssues) > 0 {
+		ic.FixedIssues = append(ic.FixedIssues, issues.FixedIssues...)
+	}
+	ic.appendExpiredIgnoreRules(issues.ExpiredIgnoreRules)
+}
+
+// The issues of all the projects are filtered by the same ignore file, so each expired rule is added once
+func (ic *IssuesCollection) appendExpiredIgnoreRules(rules []IgnoreRule) {
+	for _, rule := range rules {
+		if !slices.Contains(ic.ExpiredIgnoreRules, rule) {
+			ic.ExpiredIgnoreRules = append(ic.ExpiredIgnoreRules, rule)
+		}
+	}
 }
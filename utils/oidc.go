@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The path of the JFrog Access API that exchanges an OIDC ID token for an access token, relative to the JFrog platform URL
+	oidcTokenExchangeApi      = "access/api/v1/oidc/token"
+	oidcTokenExchangeGrant    = "urn:ietf:params:oauth:grant-type:token-exchange"
+	oidcIdTokenType           = "urn:ietf:params:oauth:token-type:id_token"
+	oidcRequestTimeout        = 30 * time.Second
+	gitHubIdTokenRequestUrl   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	gitHubIdTokenRequestToken = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+type oidcTokenExchangeRequest struct {
+	GrantType        string `json:"grant_type"`
+	SubjectTokenType string `json:"subject_token_type"`
+	SubjectToken     string `json:"subject_token"`
+	ProviderName     string `json:"provider_name"`
+}
+
+type oidcTokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	Username    string `json:"username"`
+}
+
+type gitHubIdTokenResponse struct {
+	Value string `json:"value"`
+}
+
+// Returns the URL of the JFrog platform, whose Access service exchanges the OIDC ID token. The URLs are expected without a trailing slash.
+// The JF_URL environment variable is used even if the Xray and Artifactory URLs are set separately. Without it, the platform URL is derived from the Artifactory URL.
+func getOidcPlatformUrl(platformUrl, rtUrl string) string {
+	if platformUrl == "" {
+		if rtUrl == "" {
+			return ""
+		}
+		platformUrl = strings.TrimSuffix(rtUrl, "/artifactory")
+	}
+	return platformUrl + "/"
+}
+
+// exchangeOidcToken exchanges the OIDC ID token issued by the CI for a short-lived JFrog access token,
+// using the OIDC integration of the given provider name in the JFrog platform.
+func exchangeOidcToken(platformUrl, providerName string) (accessToken string, err error) {
+	if platformUrl == "" {
+		return "", fmt.Errorf("the %s environment variable is required for exchanging the OIDC token of the %s provider", JFrogUrlEnv, providerName)
+	}
+	idToken, err := getOidcIdToken()
+	if err != nil {
+		return
+	}
+	requestBody, err := json.Marshal(oidcTokenExchangeRequest{
+		GrantType:        oidcTokenExchangeGrant,
+		SubjectTokenType: oidcIdTokenType,
+		SubjectToken:     idToken,
+		ProviderName:     providerName,
+	})
+	if err != nil {
+		return
+	}
+	log.Info("Exchanging the OIDC ID token for a JFrog access token, using the", providerName, "OIDC provider")
+	request, err := http.NewRequest(http.MethodPost, platformUrl+oidcTokenExchangeApi, bytes.NewReader(requestBody))
+	if err != nil {
+		return
+	}
+	request.Header.Set("Content-Type", "application/json")
+	var response oidcTokenExchangeResponse
+	if err = sendOidcRequest(request, &response); err != nil {
+		return "", fmt.Errorf("failed to exchange the OIDC ID token for a JFrog access token: %w", err)
+	}
+	if response.AccessToken == "" {
+		return "", errors.New("failed to exchange the OIDC ID token for a JFrog access token: the response doesn't include an access token")
+	}
+	log.Debug(fmt.Sprintf("Received a JFrog access token of %s, which expires in %d seconds", response.Username, response.ExpiresIn))
+	return response.AccessToken, nil
+}
+
+// Returns the OIDC ID token from the JF_OIDC_TOKEN environment variable.
+// On GitHub Actions, if the variable isn't set, the ID token is requested from GitHub.
+func getOidcIdToken() (idToken string, err error) {
+	if idToken, err = ReadSecretEnv(JFrogOidcTokenEnv); err != nil || idToken != "" {
+		return
+	}
+	requestUrl, requestToken := os.Getenv(gitHubIdTokenRequestUrl), os.Getenv(gitHubIdTokenRequestToken)
+	if requestUrl == "" || requestToken == "" {
+		return "", fmt.Errorf("the %s environment variable is missing. On GitHub Actions, the ID token can be requested automatically by granting the workflow the 'id-token: write' permission", JFrogOidcTokenEnv)
+	}
+	return requestGitHubIdToken(requestUrl, requestToken, getTrimmedEnv(JFrogOidcAudienceEnv))
+}
+
+func requestGitHubIdToken(requestUrl, requestToken, audience string) (idToken string, err error) {
+	if audience != "" {
+		// The request URL already includes query parameters
+		requestUrl += "&audience=" + url.QueryEscape(audience)
+	}
+	request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return
+	}
+	request.Header.Set("Authorization", "Bearer "+requestToken)
+	var response gitHubIdTokenResponse
+	if err = sendOidcRequest(request, &response); err != nil {
+		return "", fmt.Errorf("failed to request an OIDC ID token from GitHub Actions: %w", err)
+	}
+	if response.Value == "" {
+		return "", errors.New("failed to request an OIDC ID token from GitHub Actions: the response doesn't include a token")
+	}
+	return response.Value, nil
+}
+
+// Sends the request and decodes the JSON response into the result
+func sendOidcRequest(request *http.Request, result interface{}) (err error) {
+	client := &http.Client{Timeout: oidcRequestTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, response.Body.Close())
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("received status %s: %s", response.Status, string(body))
+	}
+	return json.Unmarshal(body, result)
+}
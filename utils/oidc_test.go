@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A stand-in for the token exchange API of the JFrog platform, which accepts the "ci-id-token" ID token of the "ci-provider" provider
+func createOidcTokenExchangeHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/"+oidcTokenExchangeApi, r.URL.Path)
+		var request oidcTokenExchangeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, oidcTokenExchangeGrant, request.GrantType)
+		assert.Equal(t, oidcIdTokenType, request.SubjectTokenType)
+		if request.ProviderName != "ci-provider" || request.SubjectToken != "ci-id-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, err := w.Write([]byte(`{"errors":[{"code":"UNAUTHORIZED","message":"Invalid token"}]}`))
+			assert.NoError(t, err)
+			return
+		}
+		response, err := json.Marshal(oidcTokenExchangeResponse{AccessToken: "jfrog-access-token", ExpiresIn: 3600, Username: "frogbot"})
+		require.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func TestExtractJFrogCredentialsFromEnvsWithOidc(t *testing.T) {
+	server := httptest.NewServer(createOidcTokenExchangeHandler(t))
+	defer server.Close()
+	testCases := []struct {
+		name                string
+		envs                map[string]string
+		urlEnvs             map[string]string
+		expectedAccessToken string
+		expectedUrl         string
+		expectedError       string
+	}{
+		{name: "Valid ID token", envs: map[string]string{JFrogOidcProviderNameEnv: "ci-provider", JFrogOidcTokenEnv: "ci-id-token"}, expectedAccessToken: "jfrog-access-token", expectedUrl: server.URL + "/"},
+		{name: "Invalid ID token", envs: map[string]string{JFrogOidcProviderNameEnv: "ci-provider", JFrogOidcTokenEnv: "other-token"}, expectedError: "failed to exchange the OIDC ID token for a JFrog access token: received status 401 Unauthorized"},
+		{name: "Missing ID token", envs: map[string]string{JFrogOidcProviderNameEnv: "ci-provider"}, expectedError: "the JF_OIDC_TOKEN environment variable is missing"},
+		// A static access token takes precedence over the OIDC token exchange
+		{name: "Static access token", envs: map[string]string{JFrogOidcProviderNameEnv: "ci-provider", JFrogTokenEnv: "static-token"}, expectedAccessToken: "static-token", expectedUrl: server.URL + "/"},
+		// The token is exchanged by the platform URL, even if the Xray and Artifactory URLs are set separately
+		{
+			name:                "Separate Xray and Artifactory URLs",
+			envs:                map[string]string{JFrogOidcProviderNameEnv: "ci-provider", JFrogOidcTokenEnv: "ci-id-token"},
+			urlEnvs:             map[string]string{JFrogUrlEnv: " " + server.URL + "/ ", jfrogXrayUrlEnv: server.URL + "/xray", jfrogArtifactoryUrlEnv: server.URL + "/artifactory"},
+			expectedAccessToken: "jfrog-access-token",
+		},
+		{
+			name:                "Separate Xray and Artifactory URLs without a platform URL",
+			envs:                map[string]string{JFrogOidcProviderNameEnv: "ci-provider", JFrogOidcTokenEnv: "ci-id-token"},
+			urlEnvs:             map[string]string{jfrogXrayUrlEnv: server.URL + "/xray/", jfrogArtifactoryUrlEnv: server.URL + "/artifactory/"},
+			expectedAccessToken: "jfrog-access-token",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if test.urlEnvs == nil {
+				test.urlEnvs = map[string]string{JFrogUrlEnv: server.URL}
+			}
+			for key, value := range test.urlEnvs {
+				test.envs[key] = value
+			}
+			SetEnvAndAssert(t, test.envs)
+			defer func() {
+				assert.NoError(t, SanitizeEnv())
+			}()
+			jfrogServer, err := extractJFrogCredentialsFromEnvs()
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedAccessToken, jfrogServer.AccessToken)
+			assert.Equal(t, test.expectedUrl, jfrogServer.Url)
+		})
+	}
+}
+
+func TestExchangeOidcTokenWithGitHubIdToken(t *testing.T) {
+	gitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer github-request-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "jfrog-github", r.URL.Query().Get("audience"))
+		_, err := w.Write([]byte(`{"value":"ci-id-token"}`))
+		assert.NoError(t, err)
+	}))
+	defer gitHubServer.Close()
+	jfrogServer := httptest.NewServer(createOidcTokenExchangeHandler(t))
+	defer jfrogServer.Close()
+
+	SetEnvAndAssert(t, map[string]string{
+		gitHubIdTokenRequestUrl:   gitHubServer.URL + "/token?api-version=2.0",
+		gitHubIdTokenRequestToken: "github-request-token",
+		JFrogOidcAudienceEnv:      "jfrog-github",
+	})
+	defer func() {
+		assert.NoError(t, os.Unsetenv(gitHubIdTokenRequestUrl))
+		assert.NoError(t, os.Unsetenv(gitHubIdTokenRequestToken))
+		assert.NoError(t, SanitizeEnv())
+	}()
+	accessToken, err := exchangeOidcToken(jfrogServer.URL+"/", "ci-provider")
+	require.NoError(t, err)
+	assert.Equal(t, "jfrog-access-token", accessToken)
+
+	_, err = exchangeOidcToken("", "ci-provider")
+	assert.EqualError(t, err, "the JF_URL environment variable is required for exchanging the OIDC token of the ci-provider provider")
+}
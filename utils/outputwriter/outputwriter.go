@@ -9,19 +9,35 @@ import (
 	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
 )
 
+const (
+	// The number of characters of a secret shown, followed by a fixed number of asterisks
+	secretVisibleCharacters = 3
+	secretMaskLength        = 12
+)
+
 const (
 	FrogbotTitlePrefix                               = "[🐸 Frogbot]"
 	CommentGeneratedByFrogbot                        = "[🐸 JFrog Frogbot](https://github.com/jfrog/frogbot#readme)"
 	vulnerabilitiesTableHeader                       = "\n| SEVERITY                | DIRECT DEPENDENCIES                  | IMPACTED DEPENDENCY                   | FIXED VERSIONS                       | CVES                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | :---------------------------------: |"
 	vulnerabilitiesTableHeaderWithContextualAnalysis = "| SEVERITY                | CONTEXTUAL ANALYSIS                  | DIRECT DEPENDENCIES                  | IMPACTED DEPENDENCY                   | FIXED VERSIONS                       | CVES                       |\n| :---------------------: | :----------------------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | :---------------------------------: |"
 	iacTableHeader                                   = "\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: |"
+	secretsTableHeader                               = "\n| SEVERITY                | FILE                  | LINE:COLUMN                   | SECRET                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: |"
+	sastTableHeader                                  = "\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: |"
 	vulnerableDependenciesTitle                      = "## 📦 Vulnerable Dependencies"
 	summaryTitle                                     = "### ✍️ Summary"
 	researchDetailsTitle                             = "## 🔬 Research Details"
 	iacTitle                                         = "## 🛠️ Infrastructure as Code"
+	secretsTitle                                     = "## 🔑 Secrets"
+	sastTitle                                        = "## 🎯 Static Application Security Testing (SAST)"
 	licenseTitle                                     = "## ⚖️ Violated Licenses"
 	contextualAnalysisTitle                          = "## 📦🔍 Contextual Analysis CVE Vulnerability\n"
 	licenseTableHeader                               = "\n| LICENSE                | DIRECT DEPENDENCIES                  | IMPACTED DEPENDENCY                   | \n| :---------------------: | :----------------------------------: | :-----------------------------------: |"
+	expiredIgnoreRulesTitle                          = "## ⏰ Expired Ignore Rules"
+	expiredIgnoreRulesDescription                    = "The following rules of the `.frogbot/ignore.yml` file have expired. The findings they matched are no longer ignored."
+	expiredIgnoreRulesTableHeader                    = "\n| FINDING                | REASON                  | EXPIRED ON                   | \n| :---------------------: | :----------------------------------: | :-----------------------------------: |"
+	fixedIssuesTitle                                 = "## ✅ Fixed by this PR"
+	fixedIssuesDescription                           = "The following issues were found in the target branch, and aren't found after the changes of this pull request."
+	fixedIssuesTableHeader                           = "\n| SEVERITY                | TYPE                  | ISSUE                   | LOCATION                   |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: |"
 	SecretsEmailCSS                                  = `body {
             font-family: Arial, sans-serif;
             background-color: #f5f5f5;
@@ -106,7 +122,11 @@ type OutputWriter interface {
 	VulnerabilitiesTitle(isComment bool) string
 	VulnerabilitiesContent(vulnerabilities []formats.VulnerabilityOrViolationRow) string
 	LicensesContent(licenses []formats.LicenseRow) string
+	ExpiredIgnoreRulesContent(expiredRules []ExpiredIgnoreRule) string
+	FixedIssuesContent(fixedIssues []FixedIssue) string
 	IacTableContent(iacRows []formats.SourceCodeRow) string
+	SecretsTableContent(secretRows []formats.SourceCodeRow) string
+	SastTableContent(sastRows []formats.SourceCodeRow) string
 	Footer() string
 	Separator() string
 	FormattedSeverity(severity, applicability string) string
@@ -121,6 +141,24 @@ type OutputWriter interface {
 	SastReviewContent(severity, finding, fullDetails string, codeFlows [][]formats.Location) string
 }
 
+// An expired rule of the .frogbot/ignore.yml file, called out in the pull request comment
+type ExpiredIgnoreRule struct {
+	Finding   string
+	Reason    string
+	ExpiresOn string
+}
+
+// An issue of the target branch that isn't found in the source branch, called out in the pull request comment as fixed by the pull request
+type FixedIssue struct {
+	Severity string `json:"severity,omitempty"`
+	// The type of the issue, such as Vulnerability, License or IaC
+	Type string `json:"type"`
+	// The CVEs or Xray ID of a vulnerability, the key of a license, or the finding of a source code issue
+	Issue string `json:"issue"`
+	// The impacted dependency, or the file and line of a source code issue
+	Location string `json:"location,omitempty"`
+}
+
 func GetCompatibleOutputWriter(provider vcsutils.VcsProvider) OutputWriter {
 	switch provider {
 	case vcsutils.BitbucketServer:
@@ -172,6 +210,27 @@ func getLicensesTableContent(licenses []formats.LicenseRow, writer OutputWriter)
 	return tableContent.String()
 }
 
+func getExpiredIgnoreRulesTableContent(expiredRules []ExpiredIgnoreRule) string {
+	var tableContent strings.Builder
+	for _, rule := range expiredRules {
+		tableContent.WriteString(fmt.Sprintf("\n| %s | %s | %s |", rule.Finding, rule.Reason, rule.ExpiresOn))
+	}
+	return tableContent.String()
+}
+
+func getFixedIssuesTableContent(fixedIssues []FixedIssue, writer OutputWriter) string {
+	var tableContent strings.Builder
+	for _, issue := range fixedIssues {
+		// Licenses that aren't allowed have no severity
+		severity := "-"
+		if issue.Severity != "" {
+			severity = writer.FormattedSeverity(issue.Severity, string(xrayutils.Applicable))
+		}
+		tableContent.WriteString(fmt.Sprintf("\n| %s | %s | %s | %s |", severity, issue.Type, issue.Issue, issue.Location))
+	}
+	return tableContent.String()
+}
+
 func getIacTableContent(iacRows []formats.SourceCodeRow, writer OutputWriter) string {
 	var tableContent string
 	for _, iac := range iacRows {
@@ -180,6 +239,36 @@ func getIacTableContent(iacRows []formats.SourceCodeRow, writer OutputWriter) st
 	return tableContent
 }
 
+// The secrets are masked by the secrets scanner. They're masked again to make sure they're never exposed in the pull request
+func getSecretsTableContent(secretRows []formats.SourceCodeRow, writer OutputWriter) string {
+	var tableContent strings.Builder
+	for _, secret := range secretRows {
+		tableContent.WriteString(fmt.Sprintf("\n| %s | %s | %s | %s |", writer.FormattedSeverity(secret.Severity, string(xrayutils.Applicable)), secret.File, fmt.Sprintf("%d:%d", secret.StartLine, secret.StartColumn), MarkAsQuote(maskSecret(secret.Snippet))))
+	}
+	return tableContent.String()
+}
+
+func getSastTableContent(sastRows []formats.SourceCodeRow, writer OutputWriter) string {
+	var tableContent strings.Builder
+	for _, sast := range sastRows {
+		tableContent.WriteString(fmt.Sprintf("\n| %s | %s | %s | %s |", writer.FormattedSeverity(sast.Severity, string(xrayutils.Applicable)), sast.File, fmt.Sprintf("%d:%d", sast.StartLine, sast.StartColumn), sast.Finding))
+	}
+	return tableContent.String()
+}
+
+// Keeps the first characters of the secret only, the same way the secrets scanner masks the secrets
+func maskSecret(secret string) string {
+	if len(secret) <= secretVisibleCharacters {
+		return strings.Repeat("*", secretVisibleCharacters)
+	}
+	return secret[:secretVisibleCharacters] + strings.Repeat("*", secretMaskLength)
+}
+
+// Returns the title with the number of the issues in the table below it
+func getTitleWithCount(title string, count int) string {
+	return fmt.Sprintf("%s (%d)", title, count)
+}
+
 func MarkdownComment(text string) string {
 	return fmt.Sprintf("\n\n[comment]: <> (%s)\n", text)
 }
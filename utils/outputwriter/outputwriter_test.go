@@ -62,3 +62,19 @@ func testGetLicensesTableContent(t *testing.T, writer OutputWriter) {
 	expected = "\n| License1 | Comp1 1.0 | Dep1 2.0 |\n| License2 | Comp2 2.0 | Dep2 3.0 |"
 	assert.Equal(t, expected, result)
 }
+
+func TestMaskSecret(t *testing.T) {
+	testCases := []struct {
+		secret   string
+		expected string
+	}{
+		{secret: "", expected: "***"},
+		{secret: "abc", expected: "***"},
+		{secret: "password123", expected: "pas************"},
+		// Secrets masked by the secrets scanner remain the same
+		{secret: "pas************", expected: "pas************"},
+	}
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, maskSecret(test.secret))
+	}
+}
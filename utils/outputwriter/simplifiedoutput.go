@@ -247,6 +247,48 @@ func (smo *SimplifiedOutput) LicensesContent(licenses []formats.LicenseRow) stri
 		getLicensesTableContent(licenses, smo))
 }
 
+func (smo *SimplifiedOutput) ExpiredIgnoreRulesContent(expiredRules []ExpiredIgnoreRule) string {
+	if len(expiredRules) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+---
+%s
+---
+
+%s
+
+%s %s
+
+`,
+		expiredIgnoreRulesTitle,
+		expiredIgnoreRulesDescription,
+		expiredIgnoreRulesTableHeader,
+		getExpiredIgnoreRulesTableContent(expiredRules))
+}
+
+func (smo *SimplifiedOutput) FixedIssuesContent(fixedIssues []FixedIssue) string {
+	if len(fixedIssues) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+---
+%s
+---
+
+%s
+
+%s %s
+
+`,
+		getTitleWithCount(fixedIssuesTitle, len(fixedIssues)),
+		fixedIssuesDescription,
+		fixedIssuesTableHeader,
+		getFixedIssuesTableContent(fixedIssues, smo))
+}
+
 func (smo *SimplifiedOutput) IacTableContent(iacRows []formats.SourceCodeRow) string {
 	if len(iacRows) == 0 {
 		return ""
@@ -258,11 +300,43 @@ func (smo *SimplifiedOutput) IacTableContent(iacRows []formats.SourceCodeRow) st
 %s %s
 
 `,
-		iacTitle,
+		getTitleWithCount(iacTitle, len(iacRows)),
 		iacTableHeader,
 		getIacTableContent(iacRows, smo))
 }
 
+func (smo *SimplifiedOutput) SecretsTableContent(secretRows []formats.SourceCodeRow) string {
+	if len(secretRows) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+%s
+
+%s %s
+
+`,
+		getTitleWithCount(secretsTitle, len(secretRows)),
+		secretsTableHeader,
+		getSecretsTableContent(secretRows, smo))
+}
+
+func (smo *SimplifiedOutput) SastTableContent(sastRows []formats.SourceCodeRow) string {
+	if len(sastRows) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+%s
+
+%s %s
+
+`,
+		getTitleWithCount(sastTitle, len(sastRows)),
+		sastTableHeader,
+		getSastTableContent(sastRows, smo))
+}
+
 func (smo *SimplifiedOutput) Footer() string {
 	return fmt.Sprintf("\n%s", CommentGeneratedByFrogbot)
 }
@@ -274,7 +274,7 @@ func TestSimplifiedOutput_IacContent(t *testing.T) {
 					},
 				},
 			},
-			expectedOutput: "\n## 🛠️ Infrastructure as Code\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n",
+			expectedOutput: "\n## 🛠️ Infrastructure as Code (1)\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n",
 		},
 		{
 			name: "Multiple IAC rows",
@@ -298,7 +298,7 @@ func TestSimplifiedOutput_IacContent(t *testing.T) {
 					},
 				},
 			},
-			expectedOutput: "\n## 🛠️ Infrastructure as Code\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| High | applicable/req_sw_terraform_azure_redis_patch.tf | 11:1 | Missing redis firewall definition or start_ip=0.0.0.0 was detected, Missing redis firewall definition or start_ip=0.0.0.0 was detected |\n| High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n",
+			expectedOutput: "\n## 🛠️ Infrastructure as Code (2)\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| High | applicable/req_sw_terraform_azure_redis_patch.tf | 11:1 | Missing redis firewall definition or start_ip=0.0.0.0 was detected, Missing redis firewall definition or start_ip=0.0.0.0 was detected |\n| High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n",
 		},
 	}
 
@@ -371,7 +371,39 @@ func TestSimplifiedOutput_GetIacTableContent(t *testing.T) {
 	}
 }
 
+func TestSimplifiedOutput_SecretsAndSastTableContent(t *testing.T) {
+	writer := &SimplifiedOutput{}
+	assert.Empty(t, writer.SecretsTableContent(nil))
+	assert.Empty(t, writer.SastTableContent(nil))
+	row := formats.SourceCodeRow{
+		SeverityDetails: formats.SeverityDetails{Severity: "High"},
+		Location:        formats.Location{File: "file1", StartLine: 1, StartColumn: 10, Snippet: "password123"},
+		Finding:         "Hardcoded password",
+	}
+	expectedSecretsContent := "\n## 🔑 Secrets (1)\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | SECRET                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| High | file1 | 1:10 | `pas************` |\n\n"
+	assert.Equal(t, expectedSecretsContent, writer.SecretsTableContent([]formats.SourceCodeRow{row}))
+	expectedSastContent := "\n## 🎯 Static Application Security Testing (SAST) (1)\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| High | file1 | 1:10 | Hardcoded password |\n\n"
+	assert.Equal(t, expectedSastContent, writer.SastTableContent([]formats.SourceCodeRow{row}))
+}
+
 func TestSimplifiedOutput_GetLicensesTableContent(t *testing.T) {
 	writer := &SimplifiedOutput{}
 	testGetLicensesTableContent(t, writer)
 }
+
+func TestSimplifiedOutput_ExpiredIgnoreRulesContent(t *testing.T) {
+	writer := &SimplifiedOutput{}
+	assert.Empty(t, writer.ExpiredIgnoreRulesContent(nil))
+	expectedContent := "\n---\n## ⏰ Expired Ignore Rules\n---\n\nThe following rules of the `.frogbot/ignore.yml` file have expired. The findings they matched are no longer ignored.\n\n\n| FINDING                | REASON                  | EXPIRED ON                   | \n| :---------------------: | :----------------------------------: | :-----------------------------------: | \n| lodash:[4.0.0,4.17.21) | Upgrade is planned | 2024-01-01 |\n\n"
+	assert.Equal(t, expectedContent, writer.ExpiredIgnoreRulesContent([]ExpiredIgnoreRule{{Finding: "lodash:[4.0.0,4.17.21)", Reason: "Upgrade is planned", ExpiresOn: "2024-01-01"}}))
+}
+
+func TestSimplifiedOutput_FixedIssuesContent(t *testing.T) {
+	writer := &SimplifiedOutput{}
+	assert.Empty(t, writer.FixedIssuesContent(nil))
+	expectedContent := "\n---\n## ✅ Fixed by this PR (2)\n---\n\nThe following issues were found in the target branch, and aren't found after the changes of this pull request.\n\n\n| SEVERITY                | TYPE                  | ISSUE                   | LOCATION                   |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| High | Vulnerability | CVE-2023-0001 | lodash:4.17.20 |\n| - | License | GPL-3.0 | lodash:4.17.20 |\n\n"
+	assert.Equal(t, expectedContent, writer.FixedIssuesContent([]FixedIssue{
+		{Severity: "High", Type: "Vulnerability", Issue: "CVE-2023-0001", Location: "lodash:4.17.20"},
+		{Type: "License", Issue: "GPL-3.0", Location: "lodash:4.17.20"},
+	}))
+}
@@ -277,13 +277,35 @@ func (so *StandardOutput) SastReviewContent(severity, finding, fullDetails strin
 	return contentBuilder.String()
 }
 
+func (so *StandardOutput) FixedIssuesContent(fixedIssues []FixedIssue) string {
+	if len(fixedIssues) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+%s
+
+%s
+
+<div align="center">
+
+%s %s
+
+</div>
+
+`,
+		getTitleWithCount(fixedIssuesTitle, len(fixedIssues)),
+		fixedIssuesDescription,
+		fixedIssuesTableHeader,
+		getFixedIssuesTableContent(fixedIssues, so))
+}
+
 func (so *StandardOutput) IacTableContent(iacRows []formats.SourceCodeRow) string {
 	if len(iacRows) == 0 {
 		return ""
 	}
 
 	return fmt.Sprintf(`
-## 🛠️ Infrastructure as Code 
+%s
 
 <div align="center">
 
@@ -292,10 +314,49 @@ func (so *StandardOutput) IacTableContent(iacRows []formats.SourceCodeRow) strin
 </div>
 
 `,
+		getTitleWithCount(iacTitle, len(iacRows)),
 		iacTableHeader,
 		getIacTableContent(iacRows, so))
 }
 
+func (so *StandardOutput) SecretsTableContent(secretRows []formats.SourceCodeRow) string {
+	if len(secretRows) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+%s
+
+<div align="center">
+
+%s %s
+
+</div>
+
+`,
+		getTitleWithCount(secretsTitle, len(secretRows)),
+		secretsTableHeader,
+		getSecretsTableContent(secretRows, so))
+}
+
+func (so *StandardOutput) SastTableContent(sastRows []formats.SourceCodeRow) string {
+	if len(sastRows) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+%s
+
+<div align="center">
+
+%s %s
+
+</div>
+
+`,
+		getTitleWithCount(sastTitle, len(sastRows)),
+		sastTableHeader,
+		getSastTableContent(sastRows, so))
+}
+
 func (so *StandardOutput) Footer() string {
 	return fmt.Sprintf(`
 ---
@@ -348,3 +409,25 @@ func (so *StandardOutput) LicensesContent(licenses []formats.LicenseRow) string
 		licenseTableHeader,
 		getLicensesTableContent(licenses, so))
 }
+
+func (so *StandardOutput) ExpiredIgnoreRulesContent(expiredRules []ExpiredIgnoreRule) string {
+	if len(expiredRules) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+%s
+
+%s
+
+<div align="center">
+
+%s %s
+
+</div>
+
+`,
+		expiredIgnoreRulesTitle,
+		expiredIgnoreRulesDescription,
+		expiredIgnoreRulesTableHeader,
+		getExpiredIgnoreRulesTableContent(expiredRules))
+}
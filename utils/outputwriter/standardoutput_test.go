@@ -302,7 +302,7 @@ func TestStandardOutput_IacContent(t *testing.T) {
 					},
 				},
 			},
-			expectedOutput: "\n## 🛠️ Infrastructure as Code \n\n<div align=\"center\">\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n</div>\n\n",
+			expectedOutput: "\n## 🛠️ Infrastructure as Code (1)\n\n<div align=\"center\">\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n</div>\n\n",
 		},
 		{
 			name: "Multiple IAC rows",
@@ -326,7 +326,7 @@ func TestStandardOutput_IacContent(t *testing.T) {
 					},
 				},
 			},
-			expectedOutput: "\n## 🛠️ Infrastructure as Code \n\n<div align=\"center\">\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | applicable/req_sw_terraform_azure_redis_patch.tf | 11:1 | Missing redis firewall definition or start_ip=0.0.0.0 was detected, Missing redis firewall definition or start_ip=0.0.0.0 was detected |\n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n</div>\n\n",
+			expectedOutput: "\n## 🛠️ Infrastructure as Code (2)\n\n<div align=\"center\">\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | applicable/req_sw_terraform_azure_redis_patch.tf | 11:1 | Missing redis firewall definition or start_ip=0.0.0.0 was detected, Missing redis firewall definition or start_ip=0.0.0.0 was detected |\n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | applicable/req_sw_terraform_azure_redis_auth.tf | 11:1 | Missing Periodic patching was detected |\n\n</div>\n\n",
 		},
 	}
 
@@ -399,7 +399,36 @@ func TestStandardOutput_GetIacTableContent(t *testing.T) {
 	}
 }
 
+func TestStandardOutput_SecretsAndSastTableContent(t *testing.T) {
+	writer := &StandardOutput{}
+	assert.Empty(t, writer.SecretsTableContent(nil))
+	assert.Empty(t, writer.SastTableContent(nil))
+	row := formats.SourceCodeRow{
+		SeverityDetails: formats.SeverityDetails{Severity: "High"},
+		Location:        formats.Location{File: "file1", StartLine: 1, StartColumn: 10, Snippet: "password123"},
+		Finding:         "Hardcoded password",
+	}
+	expectedSecretsContent := "\n## 🔑 Secrets (1)\n\n<div align=\"center\">\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | SECRET                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | file1 | 1:10 | `pas************` |\n\n</div>\n\n"
+	assert.Equal(t, expectedSecretsContent, writer.SecretsTableContent([]formats.SourceCodeRow{row}))
+	expectedSastContent := "\n## 🎯 Static Application Security Testing (SAST) (2)\n\n<div align=\"center\">\n\n\n| SEVERITY                | FILE                  | LINE:COLUMN                   | FINDING                       |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | file1 | 1:10 | Hardcoded password |\n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | file1 | 1:10 | Hardcoded password |\n\n</div>\n\n"
+	assert.Equal(t, expectedSastContent, writer.SastTableContent([]formats.SourceCodeRow{row, row}))
+}
+
 func TestStandardOutput_GetLicensesTableContent(t *testing.T) {
 	writer := &StandardOutput{}
 	testGetLicensesTableContent(t, writer)
 }
+
+func TestStandardOutput_ExpiredIgnoreRulesContent(t *testing.T) {
+	writer := &StandardOutput{}
+	assert.Empty(t, writer.ExpiredIgnoreRulesContent(nil))
+	expectedContent := "\n## ⏰ Expired Ignore Rules\n\nThe following rules of the `.frogbot/ignore.yml` file have expired. The findings they matched are no longer ignored.\n\n<div align=\"center\">\n\n\n| FINDING                | REASON                  | EXPIRED ON                   | \n| :---------------------: | :----------------------------------: | :-----------------------------------: | \n| CVE-2023-0001 | Accepted risk | 2024-01-01 |\n\n</div>\n\n"
+	assert.Equal(t, expectedContent, writer.ExpiredIgnoreRulesContent([]ExpiredIgnoreRule{{Finding: "CVE-2023-0001", Reason: "Accepted risk", ExpiresOn: "2024-01-01"}}))
+}
+
+func TestStandardOutput_FixedIssuesContent(t *testing.T) {
+	writer := &StandardOutput{}
+	assert.Empty(t, writer.FixedIssuesContent(nil))
+	expectedContent := "\n## ✅ Fixed by this PR (1)\n\nThe following issues were found in the target branch, and aren't found after the changes of this pull request.\n\n<div align=\"center\">\n\n\n| SEVERITY                | TYPE                  | ISSUE                   | LOCATION                   |\n| :---------------------: | :----------------------------------: | :-----------------------------------: | :---------------------------------: | \n| ![](https://raw.githubusercontent.com/jfrog/frogbot/master/resources/v2/applicableHighSeverity.png)<br>    High | IaC | Missing encryption | main.tf:3 |\n\n</div>\n\n"
+	assert.Equal(t, expectedContent, writer.FixedIssuesContent([]FixedIssue{{Severity: "High", Type: "IaC", Issue: "Missing encryption", Location: "main.tf:3"}}))
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -18,7 +19,6 @@ import (
 	"github.com/jfrog/froggit-go/vcsutils"
 	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/log"
-	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -29,7 +29,7 @@ const (
 var (
 	errFrogbotConfigNotFound = fmt.Errorf("%s wasn't found in the Frogbot directory and its subdirectories. Assuming all the configuration is stored as environment variables", FrogbotConfigFile)
 	// Possible Config file path's to Frogbot Management repository
-	osFrogbotConfigPath = filepath.Join(frogbotConfigDir, FrogbotConfigFile)
+	OsFrogbotConfigPath = filepath.Join(frogbotConfigDir, FrogbotConfigFile)
 )
 
 type FrogbotDetails struct {
@@ -47,9 +47,13 @@ func newRepoAggregator() RepoAggregator {
 }
 
 type Repository struct {
-	Params `yaml:"params,omitempty"`
-	outputwriter.OutputWriter
-	Server coreconfig.ServerDetails
+	Params                    `yaml:"params,omitempty"`
+	outputwriter.OutputWriter `yaml:"-"`
+	Server                    coreconfig.ServerDetails `yaml:"-"`
+	// The summary of the current command, shared by all the repositories
+	RunSummary *RunSummary `yaml:"-"`
+	// The cache of the audit results, shared by all the repositories
+	ResultsCache *ResultsCache `yaml:"-"`
 }
 
 type Params struct {
@@ -68,17 +72,106 @@ func (p *Params) setDefaultsIfNeeded(gitParamsFromEnv *Git, commandName string)
 	return p.Scan.setDefaultsIfNeeded()
 }
 
+// resetParamsOverriddenByFlags clears the parameters whose environment variables were set by command-line flags.
+// The environment variables are used only if the parameters are missing from the frogbot-config.yml file,
+// so clearing the parameters ensures that the values provided by the flags take precedence over the config file.
+func (p *Params) resetParamsOverriddenByFlags(flagsEnvs []string) {
+	for _, env := range flagsEnvs {
+		switch env {
+		case GitBaseBranchEnv:
+			p.Branches = nil
+		case BranchNameTemplateEnv:
+			p.BranchNameTemplate = ""
+		case CommitMessageTemplateEnv:
+			p.CommitMessageTemplate = ""
+		case PullRequestTitleTemplateEnv:
+			p.PullRequestTitleTemplate = ""
+		case GitEmailAuthorEnv:
+			p.EmailAuthor = ""
+		case GitAggregateFixesEnv:
+			p.AggregateFixes = false
+		case FixStrategyEnv:
+			p.FixStrategy = ""
+		case jfrogWatchesEnv:
+			p.Watches = nil
+		case jfrogProjectEnv:
+			p.JFrogProjectKey = ""
+		case IncludeAllVulnerabilitiesEnv:
+			p.IncludeAllVulnerabilities = false
+		case FixableOnlyEnv:
+			p.FixableOnly = false
+		case FailOnSecurityIssuesEnv:
+			p.FailOnSecurityIssues = nil
+		case FailMinSeverityEnv:
+			p.FailPolicy.MinSeverity = ""
+		case FailApplicableOnlyEnv:
+			p.FailPolicy.ApplicableOnly = false
+		case FailFixableOnlyEnv:
+			p.FailPolicy.FixableOnly = nil
+		case PublishCommitStatusEnv:
+			p.PublishCommitStatus = false
+		case MinSeverityEnv:
+			p.MinSeverity = ""
+		case AllowedLicensesEnv:
+			p.AllowedLicenses = nil
+		case EmailReceiversEnv:
+			p.EmailReceivers = nil
+		}
+	}
+	for i := range p.Projects {
+		p.Projects[i].resetParamsOverriddenByFlags(flagsEnvs)
+	}
+}
+
 type Project struct {
 	InstallCommand      string   `yaml:"installCommand,omitempty"`
 	PipRequirementsFile string   `yaml:"pipRequirementsFile,omitempty"`
 	WorkingDirs         []string `yaml:"workingDirs,omitempty"`
 	UseWrapper          *bool    `yaml:"useWrapper,omitempty"`
 	DepsRepo            string   `yaml:"repository,omitempty"`
-	InstallCommandName  string
-	InstallCommandArgs  []string
+	InstallCommandName  string   `yaml:"-"`
+	InstallCommandArgs  []string `yaml:"-"`
+	// Overrides of the repository's scan parameters, applied to this project only
+	MinSeverity               string   `yaml:"minSeverity,omitempty"`
+	FixableOnly               *bool    `yaml:"fixableOnly,omitempty"`
+	AllowedLicenses           []string `yaml:"allowedLicenses,omitempty"`
+	FailOnSecurityIssues      *bool    `yaml:"failOnSecurityIssues,omitempty"`
+	IncludeAllVulnerabilities *bool    `yaml:"includeAllVulnerabilities,omitempty"`
+	// Replaces the repository's fail policy
+	FailPolicy *FailPolicy `yaml:"failPolicy,omitempty"`
+}
+
+func (p *Project) resetParamsOverriddenByFlags(flagsEnvs []string) {
+	for _, env := range flagsEnvs {
+		switch env {
+		case WorkingDirectoryEnv:
+			p.WorkingDirs = nil
+		case UseWrapperEnv:
+			p.UseWrapper = nil
+		case InstallCommandEnv:
+			p.InstallCommand = ""
+		case RequirementsFileEnv:
+			p.PipRequirementsFile = ""
+		case DepsRepoEnv:
+			p.DepsRepo = ""
+		case MinSeverityEnv:
+			p.MinSeverity = ""
+		case FixableOnlyEnv:
+			p.FixableOnly = nil
+		case AllowedLicensesEnv:
+			p.AllowedLicenses = nil
+		case FailOnSecurityIssuesEnv:
+			p.FailOnSecurityIssues = nil
+		case IncludeAllVulnerabilitiesEnv:
+			p.IncludeAllVulnerabilities = nil
+		case FailMinSeverityEnv, FailApplicableOnlyEnv, FailFixableOnlyEnv:
+			// The fail policy of the project replaces the repository's policy as a whole, so the flags apply the repository's policy instead
+			p.FailPolicy = nil
+		}
+	}
 }
 
-func (p *Project) setDefaultsIfNeeded() error {
+func (p *Project) setDefaultsIfNeeded() (err error) {
 	if len(p.WorkingDirs) == 0 {
 		workingDir := getTrimmedEnv(WorkingDirectoryEnv)
 		if workingDir == "" {
@@ -105,24 +198,59 @@ func (p *Project) setDefaultsIfNeeded() error {
 	if p.DepsRepo == "" {
 		p.DepsRepo = getTrimmedEnv(DepsRepoEnv)
 	}
-	return nil
+	if p.MinSeverity != "" {
+		if p.MinSeverity, err = xrutils.GetSeveritiesFormat(p.MinSeverity); err != nil {
+			return
+		}
+	}
+	if p.FailPolicy != nil {
+		p.FailPolicy.MinSeverity, err = xrutils.GetSeveritiesFormat(p.FailPolicy.MinSeverity)
+	}
+	return
+}
+
+// GetScanPolicy returns the scan parameters that apply to the project.
+// Parameters that aren't overridden by the project are taken from the repository's scan parameters.
+func (p *Project) GetScanPolicy(scan *Scan) Scan {
+	policy := *scan
+	if p.MinSeverity != "" {
+		policy.MinSeverity = p.MinSeverity
+	}
+	if p.FixableOnly != nil {
+		policy.FixableOnly = *p.FixableOnly
+	}
+	if len(p.AllowedLicenses) > 0 {
+		policy.AllowedLicenses = p.AllowedLicenses
+	}
+	if p.FailOnSecurityIssues != nil {
+		policy.FailOnSecurityIssues = p.FailOnSecurityIssues
+	}
+	if p.IncludeAllVulnerabilities != nil {
+		policy.IncludeAllVulnerabilities = *p.IncludeAllVulnerabilities
+	}
+	if p.FailPolicy != nil {
+		policy.FailPolicy = *p.FailPolicy
+	}
+	return policy
 }
 
 type Scan struct {
-	IncludeAllVulnerabilities bool      `yaml:"includeAllVulnerabilities,omitempty"`
-	FixableOnly               bool      `yaml:"fixableOnly,omitempty"`
-	FailOnSecurityIssues      *bool     `yaml:"failOnSecurityIssues,omitempty"`
-	MinSeverity               string    `yaml:"minSeverity,omitempty"`
-	AllowedLicenses           []string  `yaml:"allowedLicenses,omitempty"`
-	Projects                  []Project `yaml:"projects,omitempty"`
+	IncludeAllVulnerabilities bool       `yaml:"includeAllVulnerabilities,omitempty"`
+	FixableOnly               bool       `yaml:"fixableOnly,omitempty"`
+	FailOnSecurityIssues      *bool      `yaml:"failOnSecurityIssues,omitempty"`
+	FailPolicy                FailPolicy `yaml:"failPolicy,omitempty"`
+	PublishCommitStatus       bool       `yaml:"publishCommitStatus,omitempty"`
+	MinSeverity               string     `yaml:"minSeverity,omitempty"`
+	AllowedLicenses           []string   `yaml:"allowedLicenses,omitempty"`
+	Projects                  []Project  `yaml:"projects,omitempty"`
 	EmailDetails              `yaml:",inline"`
 }
 
 type EmailDetails struct {
-	SmtpServer     string
-	SmtpPort       string
-	SmtpUser       string
-	SmtpPassword   string
+	SmtpServer     string   `yaml:"-"`
+	SmtpPort       string   `yaml:"-"`
+	SmtpUser       string   `yaml:"-"`
+	SmtpPassword   string   `yaml:"-"`
 	EmailReceivers []string `yaml:"emailReceivers,omitempty"`
 }
 
@@ -138,7 +266,10 @@ func (s *Scan) SetEmailDetails() error {
 	s.SmtpServer = splittedServerAndPort[0]
 	s.SmtpPort = splittedServerAndPort[1]
 	s.SmtpUser = getTrimmedEnv(SmtpUserEnv)
-	s.SmtpPassword = getTrimmedEnv(SmtpPasswordEnv)
+	var err error
+	if s.SmtpPassword, err = ReadSecretEnv(SmtpPasswordEnv); err != nil {
+		return err
+	}
 	if s.SmtpUser == "" {
 		return fmt.Errorf("failed while setting your email details. SMTP username is expected, but the %s environment variable is empty", SmtpUserEnv)
 	}
@@ -172,6 +303,14 @@ func (s *Scan) setDefaultsIfNeeded() (err error) {
 		}
 		s.FailOnSecurityIssues = &failOnSecurityIssues
 	}
+	if err = s.FailPolicy.setDefaultsIfNeeded(); err != nil {
+		return
+	}
+	if !s.PublishCommitStatus {
+		if s.PublishCommitStatus, err = getBoolEnv(PublishCommitStatusEnv, false); err != nil {
+			return
+		}
+	}
 	if s.MinSeverity == "" {
 		if err = readParamFromEnv(MinSeverityEnv, &s.MinSeverity); err != nil && !e.IsMissingEnvErr(err) {
 			return
@@ -208,6 +347,7 @@ func (jp *JFrogPlatform) setDefaultsIfNeeded() (err error) {
 		if jp.Watches, err = readArrayParamFromEnv(jfrogWatchesEnv, WatchesDelimiter); err != nil && !e.IsMissingEnvErr(err) {
 			return
 		}
+		err = nil
 	}
 
 	if jp.JFrogProjectKey == "" {
@@ -221,18 +361,21 @@ func (jp *JFrogPlatform) setDefaultsIfNeeded() (err error) {
 }
 
 type Git struct {
-	GitProvider vcsutils.VcsProvider
-	vcsclient.VcsInfo
-	RepoOwner                string
-	RepoName                 string   `yaml:"repoName,omitempty"`
-	Branches                 []string `yaml:"branches,omitempty"`
-	BranchNameTemplate       string   `yaml:"branchNameTemplate,omitempty"`
-	CommitMessageTemplate    string   `yaml:"commitMessageTemplate,omitempty"`
-	PullRequestTitleTemplate string   `yaml:"pullRequestTitleTemplate,omitempty"`
-	EmailAuthor              string   `yaml:"emailAuthor,omitempty"`
-	AggregateFixes           bool     `yaml:"aggregateFixes,omitempty"`
-	PullRequestDetails       vcsclient.PullRequestInfo
-	RepositoryCloneUrl       string
+	GitProvider              vcsutils.VcsProvider `yaml:"-"`
+	vcsclient.VcsInfo        `yaml:"-"`
+	RepoOwner                string                    `yaml:"-"`
+	RepoName                 string                    `yaml:"repoName,omitempty"`
+	Branches                 []string                  `yaml:"branches,omitempty"`
+	BranchNameTemplate       string                    `yaml:"branchNameTemplate,omitempty"`
+	CommitMessageTemplate    string                    `yaml:"commitMessageTemplate,omitempty"`
+	PullRequestTitleTemplate string                    `yaml:"pullRequestTitleTemplate,omitempty"`
+	EmailAuthor              string                    `yaml:"emailAuthor,omitempty"`
+	AggregateFixes           bool                      `yaml:"aggregateFixes,omitempty"`
+	FixStrategy              string                    `yaml:"fixStrategy,omitempty"`
+	PullRequestDetails       vcsclient.PullRequestInfo `yaml:"-"`
+	RepositoryCloneUrl       string                    `yaml:"-"`
+	// The parameters overridden by the branches in the branches list, by the names of the branches
+	BranchOverrides map[string]BranchParams `yaml:"-"`
 }
 
 func (g *Git) setDefaultsIfNeeded(gitParamsFromEnv *Git, commandName string) (err error) {
@@ -255,7 +398,7 @@ func (g *Git) setDefaultsIfNeeded(gitParamsFromEnv *Git, commandName string) (er
 	if commandName == ScanPullRequest && gitParamsFromEnv.PullRequestDetails.ID == 0 {
 		return fmt.Errorf("no pull request ID was provided. Please configure it using the 'JF_GIT_PULL_REQUEST_ID' environment variable")
 	}
-	if commandName == ScanRepository || commandName == ScanMultipleRepositories {
+	if commandName == ScanRepository || commandName == ScanMultipleRepositories || commandName == Report || commandName == ValidateConfig || commandName == MigrateConfig {
 		if err = g.extractScanRepositoryEnvParams(gitParamsFromEnv); err != nil {
 			return
 		}
@@ -291,7 +434,13 @@ func (g *Git) extractScanRepositoryEnvParams(gitParamsFromEnv *Git) (err error)
 			return
 		}
 	}
-	return
+	if g.FixStrategy == "" {
+		g.FixStrategy = getTrimmedEnv(FixStrategyEnv)
+	}
+	if err = validateFixStrategy(g.FixStrategy); err != nil {
+		return
+	}
+	return g.validateBranchOverrides()
 }
 
 func validateHashPlaceHolder(template string) error {
@@ -304,13 +453,20 @@ func validateHashPlaceHolder(template string) error {
 	return nil
 }
 
-func GetFrogbotDetails(commandName string) (frogbotDetails *FrogbotDetails, err error) {
+// GetFrogbotDetails builds the Frogbot configuration from the frogbot-config.yml file and the environment variables.
+// flagsEnvs are the environment variables that were set by command-line flags. Their values take precedence over the frogbot-config.yml file.
+func GetFrogbotDetails(commandName string, flagsEnvs []string) (frogbotDetails *FrogbotDetails, err error) {
 	// Get server and git details
 	jfrogServer, err := extractJFrogCredentialsFromEnvs()
 	if err != nil {
 		return
 	}
-	gitParamsFromEnv, err := extractGitParamsFromEnvs(commandName)
+	var gitParamsFromEnv *Git
+	if commandName == ScanLocal {
+		gitParamsFromEnv, err = extractLocalGitParamsFromEnvs()
+	} else {
+		gitParamsFromEnv, err = extractGitParamsFromEnvs(commandName)
+	}
 	if err != nil {
 		return
 	}
@@ -319,8 +475,35 @@ func GetFrogbotDetails(commandName string) (frogbotDetails *FrogbotDetails, err
 		err = errors.Join(err, SanitizeEnv())
 	}()
 
-	// Build a version control client for REST API requests
-	client, err := vcsclient.
+	// Build a version control client for REST API requests.
+	// Local scans don't interact with a Git provider, therefore no client is needed.
+	var client vcsclient.VcsClient
+	if commandName != ScanLocal {
+		if client, err = buildVcsClient(gitParamsFromEnv); err != nil {
+			return nil, err
+		}
+	}
+
+	configAggregator, err := getConfigAggregator(client, gitParamsFromEnv, jfrogServer, commandName, flagsEnvs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The results of the target branches are cached for the commands that scan pull requests
+	if commandName == ScanPullRequest || commandName == ScanAllPullRequests || commandName == Serve {
+		var resultsCache *ResultsCache
+		if resultsCache, err = newResultsCacheFromEnv(jfrogServer); err != nil {
+			return nil, err
+		}
+		for i := range configAggregator {
+			configAggregator[i].ResultsCache = resultsCache
+		}
+	}
+	return &FrogbotDetails{Repositories: configAggregator, GitClient: client, ServerDetails: jfrogServer, ReleasesRepo: os.Getenv(jfrogReleasesRepoEnv)}, err
+}
+
+func buildVcsClient(gitParamsFromEnv *Git) (vcsclient.VcsClient, error) {
+	return vcsclient.
 		NewClientBuilder(gitParamsFromEnv.GitProvider).
 		ApiEndpoint(strings.TrimSuffix(gitParamsFromEnv.APIEndpoint, "/")).
 		Token(gitParamsFromEnv.Token).
@@ -328,19 +511,10 @@ func GetFrogbotDetails(commandName string) (frogbotDetails *FrogbotDetails, err
 		Logger(log.GetLogger()).
 		Username(gitParamsFromEnv.Username).
 		Build()
-	if err != nil {
-		return nil, err
-	}
-
-	configAggregator, err := getConfigAggregator(client, gitParamsFromEnv, jfrogServer, commandName)
-	if err != nil {
-		return nil, err
-	}
-	return &FrogbotDetails{Repositories: configAggregator, GitClient: client, ServerDetails: jfrogServer, ReleasesRepo: os.Getenv(jfrogReleasesRepoEnv)}, err
 }
 
 // getConfigAggregator returns a RepoAggregator based on frogbot-config.yml and environment variables.
-func getConfigAggregator(gitClient vcsclient.VcsClient, gitParamsFromEnv *Git, jfrogServer *coreconfig.ServerDetails, commandName string) (RepoAggregator, error) {
+func getConfigAggregator(gitClient vcsclient.VcsClient, gitParamsFromEnv *Git, jfrogServer *coreconfig.ServerDetails, commandName string, flagsEnvs []string) (RepoAggregator, error) {
 	configFileContent, err := getConfigFileContent(gitClient, gitParamsFromEnv, commandName)
 	// Don't return error in case of a missing frogbot-config.yml file
 	// If an error occurs due to a missing file, attempt to generate an environment variable-based configuration aggregator as an alternative.
@@ -348,15 +522,15 @@ func getConfigAggregator(gitClient vcsclient.VcsClient, gitParamsFromEnv *Git, j
 	if !errors.As(err, &errMissingConfig) && len(configFileContent) == 0 {
 		return nil, err
 	}
-	return BuildRepoAggregator(configFileContent, gitParamsFromEnv, jfrogServer, commandName)
+	return buildRepoAggregator(configFileContent, gitParamsFromEnv, jfrogServer, commandName, flagsEnvs)
 }
 
 // The getConfigFileContent function retrieves the frogbot-config.yml file content.
 // If the JF_GIT_REPO and JF_GIT_OWNER environment variables are set, this function will attempt to retrieve the frogbot-config.yml file from the target repository based on these variables.
 // If these variables aren't set, this function will attempt to retrieve the frogbot-config.yml file from the current working directory.
 func getConfigFileContent(gitClient vcsclient.VcsClient, gitParamsFromEnv *Git, commandName string) (configFileContent []byte, err error) {
-	if commandName == ScanRepository || commandName == ScanMultipleRepositories {
-		configFileContent, err = ReadConfigFromFileSystem(osFrogbotConfigPath)
+	if commandName == ScanRepository || commandName == ScanMultipleRepositories || commandName == ScanLocal || commandName == Serve || commandName == Report {
+		configFileContent, err = ReadConfigFromFileSystem(OsFrogbotConfigPath)
 		return
 	}
 	return readConfigFromTarget(gitClient, gitParamsFromEnv)
@@ -364,15 +538,20 @@ func getConfigFileContent(gitClient vcsclient.VcsClient, gitParamsFromEnv *Git,
 
 // BuildRepoAggregator receives the content of a frogbot-config.yml file, along with the Git (built from environment variables) and ServerDetails parameters.
 // Returns a RepoAggregator instance with all the defaults and necessary fields.
-func BuildRepoAggregator(configFileContent []byte, gitParamsFromEnv *Git, server *coreconfig.ServerDetails, commandName string) (resultAggregator RepoAggregator, err error) {
+func BuildRepoAggregator(configFileContent []byte, gitParamsFromEnv *Git, server *coreconfig.ServerDetails, commandName string) (RepoAggregator, error) {
+	return buildRepoAggregator(configFileContent, gitParamsFromEnv, server, commandName, nil)
+}
+
+func buildRepoAggregator(configFileContent []byte, gitParamsFromEnv *Git, server *coreconfig.ServerDetails, commandName string, flagsEnvs []string) (resultAggregator RepoAggregator, err error) {
 	var cleanAggregator RepoAggregator
-	// Unmarshal the frogbot-config.yml file if exists
+	// Unmarshal and validate the frogbot-config.yml file if exists
 	if cleanAggregator, err = unmarshalFrogbotConfigYaml(configFileContent); err != nil {
 		return
 	}
 	for _, repository := range cleanAggregator {
 		repository.Server = *server
 		repository.OutputWriter = outputwriter.GetCompatibleOutputWriter(gitParamsFromEnv.GitProvider)
+		repository.Params.resetParamsOverriddenByFlags(flagsEnvs)
 		if err = repository.Params.setDefaultsIfNeeded(gitParamsFromEnv, commandName); err != nil {
 			return
 		}
@@ -382,14 +561,70 @@ func BuildRepoAggregator(configFileContent []byte, gitParamsFromEnv *Git, server
 	return
 }
 
+// GetEffectiveConfig validates the frogbot-config.yml file in the given path, and returns the configuration of each repository after applying the defaults from the environment variables.
+// flagsEnvs are the environment variables that were set by command-line flags.
+func GetEffectiveConfig(configPath string, flagsEnvs []string) (configAggregator RepoAggregator, err error) {
+	gitParamsFromEnv, err := extractLocalGitParamsFromEnvs()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, SanitizeEnv())
+	}()
+	configFileContent, err := ReadConfigFromFileSystem(configPath)
+	if err != nil {
+		return
+	}
+	return buildRepoAggregator(configFileContent, gitParamsFromEnv, &coreconfig.ServerDetails{}, ValidateConfig, flagsEnvs)
+}
+
+// GetMigratedConfig returns the configuration of each repository, resolved from the frogbot-config.yml file in the given path and the environment variables,
+// the same way the configuration is resolved before each scan. The file is optional, since the configuration may be stored in environment variables only.
+// flagsEnvs are the environment variables that were set by command-line flags.
+func GetMigratedConfig(configPath string, flagsEnvs []string) (configAggregator RepoAggregator, err error) {
+	gitParamsFromEnv, err := extractLocalGitParamsFromEnvs()
+	if err != nil {
+		return
+	}
+	if branch := getTrimmedEnv(GitBaseBranchEnv); branch != "" {
+		gitParamsFromEnv.Branches = []string{branch}
+	}
+	defer func() {
+		err = errors.Join(err, SanitizeEnv())
+	}()
+	configFileContent, err := ReadConfigFromFileSystem(configPath)
+	var errMissingConfig *ErrMissingConfig
+	if errors.As(err, &errMissingConfig) {
+		log.Info(fmt.Sprintf("The %s file wasn't found. The configuration is migrated from the environment variables only", FrogbotConfigFile))
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+	return buildRepoAggregator(configFileContent, gitParamsFromEnv, &coreconfig.ServerDetails{}, MigrateConfig, flagsEnvs)
+}
+
 // unmarshalFrogbotConfigYaml uses the yaml.Unmarshaler interface to parse the yamlContent.
+// The references to environment variables and files in the values are interpolated, and the result is validated against the Frogbot schema.
+// If the yamlContent includes defaults, they are merged into the parameters of each repository.
 // If there is no config file, the function returns a RepoAggregator with an empty repository.
 func unmarshalFrogbotConfigYaml(yamlContent []byte) (result RepoAggregator, err error) {
 	if len(yamlContent) == 0 {
 		result = newRepoAggregator()
 		return
 	}
-	err = yaml.Unmarshal(yamlContent, &result)
+	document, err := loadConfigDocument(yamlContent)
+	if err != nil {
+		return
+	}
+	configWithDefaults, err := parseConfigWithDefaults(document)
+	if err != nil {
+		return
+	}
+	if configWithDefaults != nil {
+		return configWithDefaults.toRepoAggregator()
+	}
+	err = document.Decode(&result)
 	return
 }
 
@@ -410,15 +645,26 @@ func extractJFrogCredentialsFromEnvs() (*coreconfig.ServerDetails, error) {
 		server.ArtifactoryUrl = platformUrl + "/artifactory/"
 	}
 
-	password := getTrimmedEnv(JFrogPasswordEnv)
+	password, err := ReadSecretEnv(JFrogPasswordEnv)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := ReadSecretEnv(JFrogTokenEnv)
+	if err != nil {
+		return nil, err
+	}
 	user := getTrimmedEnv(JFrogUserEnv)
 	if password != "" && user != "" {
 		server.User = user
 		server.Password = password
-	} else if accessToken := getTrimmedEnv(JFrogTokenEnv); accessToken != "" {
+	} else if accessToken != "" {
 		server.AccessToken = accessToken
+	} else if oidcProviderName := getTrimmedEnv(JFrogOidcProviderNameEnv); oidcProviderName != "" {
+		if server.AccessToken, err = exchangeOidcToken(getOidcPlatformUrl(platformUrl, rtUrl), oidcProviderName); err != nil {
+			return nil, err
+		}
 	} else {
-		return nil, fmt.Errorf("%s and %s or %s environment variables are missing", JFrogUserEnv, JFrogPasswordEnv, JFrogTokenEnv)
+		return nil, fmt.Errorf("%s and %s, %s or %s environment variables are missing", JFrogUserEnv, JFrogPasswordEnv, JFrogTokenEnv, JFrogOidcProviderNameEnv)
 	}
 	return &server, nil
 }
@@ -454,12 +700,15 @@ func extractGitParamsFromEnvs(commandName string) (*Git, error) {
 		return nil, err
 	}
 	// [Mandatory] Set the access token to the git provider
-	if err = readParamFromEnv(GitTokenEnv, &gitEnvParams.Token); err != nil {
+	if gitEnvParams.Token, err = ReadSecretEnv(GitTokenEnv); err != nil {
 		return nil, err
 	}
+	if gitEnvParams.Token == "" {
+		return nil, &ErrMissingEnv{VariableName: GitTokenEnv}
+	}
 
-	// [Mandatory] Set the repository name, except for multi repository.
-	if err = readParamFromEnv(GitRepoEnv, &gitEnvParams.RepoName); err != nil && commandName != ScanMultipleRepositories {
+	// [Mandatory] Set the repository name, except for multi repository commands.
+	if err = readParamFromEnv(GitRepoEnv, &gitEnvParams.RepoName); err != nil && commandName != ScanMultipleRepositories && commandName != Serve && commandName != Report {
 		return nil, err
 	}
 
@@ -484,6 +733,27 @@ func extractGitParamsFromEnvs(commandName string) (*Git, error) {
 	return gitEnvParams, nil
 }
 
+// extractLocalGitParamsFromEnvs extracts the Git parameters for commands that don't interact with a Git provider.
+// None of the Git environment variables are mandatory in this case. If the repository name isn't provided, the name of the current working directory is used.
+func extractLocalGitParamsFromEnvs() (gitEnvParams *Git, err error) {
+	gitEnvParams = &Git{}
+	if getTrimmedEnv(GitProvider) != "" {
+		// The Git provider determines the format of the generated report
+		if gitEnvParams.GitProvider, err = extractVcsProviderFromEnv(); err != nil {
+			return nil, err
+		}
+	}
+	gitEnvParams.RepoOwner = getTrimmedEnv(GitRepoOwnerEnv)
+	if gitEnvParams.RepoName = getTrimmedEnv(GitRepoEnv); gitEnvParams.RepoName == "" {
+		var wd string
+		if wd, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+		gitEnvParams.RepoName = filepath.Base(wd)
+	}
+	return
+}
+
 func verifyValidApiEndpoint(apiEndpoint string) error {
 	// Empty string will resolve to default values.
 	if apiEndpoint == "" {
@@ -522,6 +792,25 @@ func readParamFromEnv(envKey string, paramValue *string) error {
 	return nil
 }
 
+// ReadSecretEnv returns the value of a secret environment variable, such as JF_ACCESS_TOKEN.
+// If the variable isn't set, the value is read from the file in the <envKey>_FILE environment variable, allowing secrets to be mounted as files.
+func ReadSecretEnv(envKey string) (string, error) {
+	value := getTrimmedEnv(envKey)
+	secretFileEnv := envKey + SecretFileEnvSuffix
+	secretFile := getTrimmedEnv(secretFileEnv)
+	if secretFile == "" {
+		return value, nil
+	}
+	if value != "" {
+		return "", fmt.Errorf("only one of the %s and %s environment variables should be set", envKey, secretFileEnv)
+	}
+	content, err := os.ReadFile(secretFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the file in the %s environment variable: %w", secretFileEnv, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
 func getTrimmedEnv(envKey string) string {
 	return strings.TrimSpace(os.Getenv(envKey))
 }
@@ -558,7 +847,7 @@ func SanitizeEnv() error {
 // ReadConfigFromFileSystem looks for .frogbot/frogbot-config.yml from the given path and return its content. The path is relative and starts from the root of the project.
 // If the config file is not found in the relative path, it will search in parent dirs.
 func ReadConfigFromFileSystem(configRelativePath string) (configFileContent []byte, err error) {
-	log.Debug("Reading config from file system. Looking for", osFrogbotConfigPath)
+	log.Debug("Reading config from file system. Looking for", OsFrogbotConfigPath)
 	fullConfigDirPath, err := filepath.Abs(configRelativePath)
 	if err != nil {
 		return nil, err
@@ -579,8 +868,12 @@ func ReadConfigFromFileSystem(configRelativePath string) (configFileContent []by
 	configFileContent, err = os.ReadFile(filepath.Clean(fullConfigDirPath))
 	if err != nil {
 		err = fmt.Errorf("an error occurd while reading the %s file at: %s\n%s", FrogbotConfigFile, configRelativePath, err.Error())
+		return
 	}
-	return
+	configDir := filepath.Dir(fullConfigDirPath)
+	return resolveConfigExtends(configFileContent, func(extendedPath string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(configDir, filepath.FromSlash(extendedPath)))
+	})
 }
 
 func setProjectInstallCommand(installCommand string, project *Project) {
@@ -631,6 +924,17 @@ func readConfigFromTarget(client vcsclient.VcsClient, gitParamsFromEnv *Git) (co
 		if statusCode == http.StatusUnauthorized {
 			log.Warn("Your credentials seem to be invalid. If you are using an on-premises Git provider, please set the API endpoint of your Git provider using the 'JF_GIT_API_ENDPOINT' environment variable (example: 'https://gitlab.example.com'). Additionally, make sure that the provided credentials have the required Git permissions.")
 		}
+		if err != nil {
+			return
+		}
+		// Files extended by the frogbot-config.yml file are downloaded from the same branch
+		return resolveConfigExtends(configContent, func(extendedPath string) ([]byte, error) {
+			content, status, e := client.DownloadFileFromRepo(context.Background(), repoOwner, repoName, branch, path.Join(frogbotConfigDir, extendedPath))
+			if e == nil && status != http.StatusOK {
+				e = fmt.Errorf("received the %d status code", status)
+			}
+			return content, e
+		})
 	}
 
 	return configContent, err
@@ -11,6 +11,7 @@ import (
 
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -30,7 +31,7 @@ func TestExtractParamsFromEnvError(t *testing.T) {
 
 	SetEnvAndAssert(t, map[string]string{JFrogUrlEnv: "http://127.0.0.1:8081"})
 	_, err = extractJFrogCredentialsFromEnvs()
-	assert.EqualError(t, err, "JF_USER and JF_PASSWORD or JF_ACCESS_TOKEN environment variables are missing")
+	assert.EqualError(t, err, "JF_USER and JF_PASSWORD, JF_ACCESS_TOKEN or JF_OIDC_PROVIDER_NAME environment variables are missing")
 }
 
 // Test extraction of env params in ScanPullRequest command
@@ -145,6 +146,31 @@ func TestExtractClientInfo(t *testing.T) {
 	assert.EqualError(t, err, "'JF_GIT_REPO' environment variable is missing")
 }
 
+func TestExtractLocalGitParamsFromEnvs(t *testing.T) {
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+
+	// None of the Git environment variables are mandatory
+	gitParams, err := extractLocalGitParamsFromEnvs()
+	assert.NoError(t, err)
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Base(wd), gitParams.RepoName)
+	assert.Equal(t, vcsutils.GitHub, gitParams.GitProvider)
+
+	SetEnvAndAssert(t, map[string]string{GitProvider: string(BitbucketServer), GitRepoEnv: "frogbot", GitRepoOwnerEnv: "jfrog"})
+	gitParams, err = extractLocalGitParamsFromEnvs()
+	assert.NoError(t, err)
+	assert.Equal(t, "frogbot", gitParams.RepoName)
+	assert.Equal(t, "jfrog", gitParams.RepoOwner)
+	assert.Equal(t, vcsutils.BitbucketServer, gitParams.GitProvider)
+
+	SetEnvAndAssert(t, map[string]string{GitProvider: "invalid"})
+	_, err = extractLocalGitParamsFromEnvs()
+	assert.Error(t, err)
+}
+
 func TestExtractAndAssertRepoParams(t *testing.T) {
 	SetEnvAndAssert(t, map[string]string{
 		JFrogUrlEnv:          "http://127.0.0.1:8081",
@@ -337,6 +363,7 @@ func TestGenerateConfigAggregatorFromEnv(t *testing.T) {
 		DepsRepoEnv:                  "deps-remote",
 		IncludeAllVulnerabilitiesEnv: "true",
 		FailOnSecurityIssuesEnv:      "false",
+		PublishCommitStatusEnv:       "true",
 		MinSeverityEnv:               "medium",
 		FixableOnlyEnv:               "true",
 		AllowedLicensesEnv:           "MIT, Apache-2.0",
@@ -367,6 +394,7 @@ func TestGenerateConfigAggregatorFromEnv(t *testing.T) {
 	assert.Equal(t, "repoName", repo.RepoName)
 	assert.ElementsMatch(t, repo.Watches, []string{"watch-1", "watch-2", "watch-3"})
 	assert.Equal(t, false, *repo.FailOnSecurityIssues)
+	assert.True(t, repo.PublishCommitStatus)
 	assert.Equal(t, "Medium", repo.MinSeverity)
 	assert.Equal(t, true, repo.FixableOnly)
 	assert.ElementsMatch(t, []string{"MIT", "Apache-2.0"}, repo.AllowedLicenses)
@@ -535,6 +563,116 @@ func TestBuildMergedRepoAggregator(t *testing.T) {
 	assert.False(t, *project.UseWrapper)
 }
 
+func TestBuildRepoAggregatorWithFlags(t *testing.T) {
+	SetEnvAndAssert(t, map[string]string{
+		IncludeAllVulnerabilitiesEnv: "false",
+		MinSeverityEnv:               "low",
+		WorkingDirectoryEnv:          "c/d",
+		PullRequestTitleTemplateEnv:  "flag-title",
+	})
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+	fileContent, err := os.ReadFile(filepath.Join("..", "testdata", "config", "frogbot-config-test-params-merge.yml"))
+	assert.NoError(t, err)
+	gitParams := &Git{RepoName: "repoName", Branches: []string{"master"}, RepoOwner: "jfrog"}
+	flagsEnvs := []string{IncludeAllVulnerabilitiesEnv, MinSeverityEnv, WorkingDirectoryEnv}
+
+	repoAggregator, err := buildRepoAggregator(fileContent, gitParams, &config.ServerDetails{}, ScanRepository, flagsEnvs)
+	assert.NoError(t, err)
+	repo := repoAggregator[0]
+	// Values set by flags override the config file
+	assert.False(t, repo.IncludeAllVulnerabilities)
+	assert.Equal(t, "Low", repo.MinSeverity)
+	assert.Equal(t, []string{"c/d"}, repo.Projects[0].WorkingDirs)
+	// Values that were not set by flags are taken from the config file
+	assert.Equal(t, "myPullRequests", repo.PullRequestTitleTemplate)
+	assert.True(t, repo.FixableOnly)
+}
+
+func TestBuildRepoAggregatorWithProjectOverrides(t *testing.T) {
+	SetEnvAndAssert(t, map[string]string{FixableOnlyEnv: "true"})
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+	configFileContent := []byte(`
+- params:
+    git:
+      repoName: repoName
+      branches: [master]
+    scan:
+      minSeverity: Low
+      allowedLicenses: [MIT]
+      projects:
+        - workingDirs: [service]
+          minSeverity: critical
+          failOnSecurityIssues: true
+          includeAllVulnerabilities: true
+          allowedLicenses: [Apache-2.0]
+        - workingDirs: [tools]
+          fixableOnly: false
+          failOnSecurityIssues: false
+`)
+	gitParams := &Git{RepoName: "repoName", Branches: []string{"master"}, RepoOwner: "jfrog"}
+	repoAggregator, err := BuildRepoAggregator(configFileContent, gitParams, &config.ServerDetails{}, ScanRepository)
+	require.NoError(t, err)
+	repo := repoAggregator[0]
+	require.Len(t, repo.Projects, 2)
+
+	service := repo.Projects[0].GetScanPolicy(&repo.Scan)
+	assert.Equal(t, "Critical", service.MinSeverity)
+	assert.True(t, service.FixableOnly)
+	assert.True(t, *service.FailOnSecurityIssues)
+	assert.True(t, service.IncludeAllVulnerabilities)
+	assert.Equal(t, []string{"Apache-2.0"}, service.AllowedLicenses)
+
+	// Parameters that aren't overridden by the project are taken from the repository
+	tools := repo.Projects[1].GetScanPolicy(&repo.Scan)
+	assert.Equal(t, "Low", tools.MinSeverity)
+	assert.False(t, tools.FixableOnly)
+	assert.False(t, *tools.FailOnSecurityIssues)
+	assert.False(t, tools.IncludeAllVulnerabilities)
+	assert.Equal(t, []string{"MIT"}, tools.AllowedLicenses)
+}
+
+func TestBuildRepoAggregatorWithFailPolicy(t *testing.T) {
+	SetEnvAndAssert(t, map[string]string{FailFixableOnlyEnv: "true"})
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+	configFileContent := []byte(`
+- params:
+    git:
+      repoName: repoName
+      branches: [master]
+    scan:
+      minSeverity: Low
+      failPolicy:
+        minSeverity: high
+        thresholds:
+          iac: 5
+          sast: -1
+      projects:
+        - workingDirs: [service]
+          failPolicy:
+            minSeverity: critical
+            applicableOnly: true
+        - workingDirs: [tools]
+`)
+	gitParams := &Git{RepoName: "repoName", Branches: []string{"master"}, RepoOwner: "jfrog"}
+	repoAggregator, err := BuildRepoAggregator(configFileContent, gitParams, &config.ServerDetails{}, ScanRepository)
+	require.NoError(t, err)
+	repo := repoAggregator[0]
+	require.Len(t, repo.Projects, 2)
+	fixableOnly := true
+	assert.Equal(t, FailPolicy{MinSeverity: "High", FixableOnly: &fixableOnly, Thresholds: FailThresholds{Iac: 5, Sast: -1}}, repo.FailPolicy)
+	// The fail policy of the project replaces the repository's policy
+	assert.Equal(t, FailPolicy{MinSeverity: "Critical", ApplicableOnly: true}, repo.Projects[0].GetScanPolicy(&repo.Scan).FailPolicy)
+	assert.Equal(t, repo.FailPolicy, repo.Projects[1].GetScanPolicy(&repo.Scan).FailPolicy)
+	// The minimum severity of the issues to show is independent of the fail policy
+	assert.Equal(t, "Low", repo.MinSeverity)
+}
+
 func TestSetEmailDetails(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,254 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+	rtutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// Cached results older than this are audited again, so that issues published since are found
+	resultsCacheExpiration     = 24 * time.Hour
+	resultsCacheRequestTimeout = 30 * time.Second
+	resultsCacheFileExtension  = ".json"
+)
+
+// ResultsCacheStorage stores the content of the cached results by their keys.
+type ResultsCacheStorage interface {
+	// Returns false if no content is stored by the key
+	Read(key string) (content []byte, found bool, err error)
+	Write(key string, content []byte) error
+}
+
+// ResultsCache stores the results of audited commits, so that pull requests targeting the same commit reuse the results instead of auditing the commit again.
+// A nil cache means that caching is disabled. All the methods are safe to call on a nil cache, in which case nothing is cached.
+// Failing to read or write the cache doesn't fail the scan, since the results can always be audited again.
+type ResultsCache struct {
+	storage ResultsCacheStorage
+}
+
+type resultsCacheEntry struct {
+	Created time.Time       `json:"created"`
+	Results json.RawMessage `json:"results"`
+}
+
+// The parameters that determine the results of an audit
+type resultsCacheKey struct {
+	FrogbotVersion           string   `json:"frogbotVersion"`
+	XrayUrl                  string   `json:"xrayUrl"`
+	Owner                    string   `json:"owner"`
+	Repository               string   `json:"repository"`
+	CommitSha                string   `json:"commitSha"`
+	WorkingDirs              []string `json:"workingDirs"`
+	InstallCommandName       string   `json:"installCommandName"`
+	InstallCommandArgs       []string `json:"installCommandArgs"`
+	PipRequirementsFile      string   `json:"pipRequirementsFile"`
+	UseWrapper               bool     `json:"useWrapper"`
+	DepsRepo                 string   `json:"depsRepo"`
+	Watches                  []string `json:"watches"`
+	ProjectKey               string   `json:"projectKey"`
+	IncludeVulnerabilities   bool     `json:"includeVulnerabilities"`
+	IncludeLicenses          bool     `json:"includeLicenses"`
+	FailOnInstallationErrors bool     `json:"failOnInstallationErrors"`
+}
+
+func NewResultsCache(storage ResultsCacheStorage) *ResultsCache {
+	return &ResultsCache{storage: storage}
+}
+
+// Creates the results cache according to the environment variables.
+// The results are cached in a local directory by default, or in a generic repository in Artifactory, if provided.
+func newResultsCacheFromEnv(serverDetails *config.ServerDetails) (*ResultsCache, error) {
+	disabled, err := getBoolEnv(DisableResultsCacheEnv, false)
+	if err != nil || disabled {
+		return nil, err
+	}
+	if repo := strings.Trim(getTrimmedEnv(ResultsCacheRepoEnv), "/"); repo != "" {
+		if serverDetails.ArtifactoryUrl == "" {
+			return nil, fmt.Errorf("the JFrog Artifactory URL is required for caching the results in the %s repository", repo)
+		}
+		storage, storageErr := newArtifactoryResultsCacheStorage(serverDetails, repo)
+		if storageErr != nil {
+			return nil, storageErr
+		}
+		log.Debug("The audit results are cached in the", repo, "Artifactory repository")
+		return NewResultsCache(storage), nil
+	}
+	dir := getTrimmedEnv(ResultsCacheDirEnv)
+	if dir == "" {
+		cacheDir, cacheDirErr := os.UserCacheDir()
+		if cacheDirErr != nil {
+			cacheDir = os.TempDir()
+		}
+		dir = filepath.Join(cacheDir, "frogbot", "results")
+	}
+	log.Debug("The audit results are cached in", dir)
+	return NewResultsCache(&localResultsCacheStorage{dir: dir}), nil
+}
+
+// Get reads the results cached by the key into the results.
+// Returns false if no results are cached by the key, or if the cached results have expired.
+func (rc *ResultsCache) Get(key string, results interface{}) bool {
+	if rc == nil {
+		return false
+	}
+	content, found, err := rc.storage.Read(key)
+	if err != nil {
+		log.Warn("Couldn't read the cached audit results:", err.Error())
+		return false
+	}
+	if !found {
+		return false
+	}
+	var entry resultsCacheEntry
+	if err = json.Unmarshal(content, &entry); err != nil {
+		log.Warn("Couldn't parse the cached audit results:", err.Error())
+		return false
+	}
+	if time.Since(entry.Created) > resultsCacheExpiration {
+		log.Debug("The cached audit results of", key, "have expired")
+		return false
+	}
+	if err = json.Unmarshal(entry.Results, results); err != nil {
+		log.Warn("Couldn't parse the cached audit results:", err.Error())
+		return false
+	}
+	return true
+}
+
+// Put caches the results by the key.
+func (rc *ResultsCache) Put(key string, results interface{}) {
+	if rc == nil {
+		return
+	}
+	resultsContent, err := json.Marshal(results)
+	if err != nil {
+		log.Warn("Couldn't cache the audit results:", err.Error())
+		return
+	}
+	content, err := json.Marshal(resultsCacheEntry{Created: time.Now(), Results: resultsContent})
+	if err != nil {
+		log.Warn("Couldn't cache the audit results:", err.Error())
+		return
+	}
+	if err = rc.storage.Write(key, content); err != nil {
+		log.Warn("Couldn't cache the audit results:", err.Error())
+		return
+	}
+	log.Debug("Cached the audit results of", key)
+}
+
+// GetResultsCacheKey returns the key of the results of auditing the project at the given commit of the branch, with the scan parameters.
+func (sc *ScanDetails) GetResultsCacheKey(branch vcsclient.BranchInfo, commitSha string) (string, error) {
+	key := resultsCacheKey{
+		FrogbotVersion:           FrogbotVersion,
+		Owner:                    branch.Owner,
+		Repository:               branch.Repository,
+		CommitSha:                commitSha,
+		FailOnInstallationErrors: sc.failOnInstallationErrors,
+	}
+	if sc.ServerDetails != nil {
+		key.XrayUrl = sc.ServerDetails.XrayUrl
+	}
+	if sc.Project != nil {
+		key.WorkingDirs = slices.Clone(sc.WorkingDirs)
+		slices.Sort(key.WorkingDirs)
+		key.InstallCommandName = sc.InstallCommandName
+		key.InstallCommandArgs = sc.InstallCommandArgs
+		key.PipRequirementsFile = sc.PipRequirementsFile
+		key.UseWrapper = sc.UseWrapper != nil && *sc.UseWrapper
+		key.DepsRepo = sc.DepsRepo
+	}
+	if sc.XrayGraphScanParams != nil {
+		key.Watches = sc.XrayGraphScanParams.Watches
+		key.ProjectKey = sc.XrayGraphScanParams.ProjectKey
+		key.IncludeVulnerabilities = sc.XrayGraphScanParams.IncludeVulnerabilities
+		key.IncludeLicenses = sc.XrayGraphScanParams.IncludeLicenses
+	}
+	content, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return Md5Hash(string(content))
+}
+
+// Stores the cached results as files in a local directory
+type localResultsCacheStorage struct {
+	dir string
+}
+
+func (s *localResultsCacheStorage) Read(key string) (content []byte, found bool, err error) {
+	content, err = os.ReadFile(filepath.Join(s.dir, key+resultsCacheFileExtension))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	return content, err == nil, err
+}
+
+func (s *localResultsCacheStorage) Write(key string, content []byte) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, key+resultsCacheFileExtension), content, 0600)
+}
+
+// Stores the cached results as files in a generic repository in Artifactory.
+// The requests are sent by the Artifactory service manager of jfrog-client-go, so that the authentication, client certificate and TLS settings of the server are used.
+type artifactoryResultsCacheStorage struct {
+	serviceManager artifactory.ArtifactoryServicesManager
+	repo           string
+}
+
+func newArtifactoryResultsCacheStorage(serverDetails *config.ServerDetails, repo string) (*artifactoryResultsCacheStorage, error) {
+	// The requests aren't retried, since failing to read or write the cache doesn't fail the scan
+	serviceManager, err := rtutils.CreateServiceManagerWithContext(context.Background(), serverDetails, false, 0, 0, 0, resultsCacheRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &artifactoryResultsCacheStorage{serviceManager: serviceManager, repo: repo}, nil
+}
+
+func (s *artifactoryResultsCacheStorage) Read(key string) (content []byte, found bool, err error) {
+	httpClientDetails := s.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	response, content, _, err := s.serviceManager.Client().SendGet(s.getFileUrl(key), true, &httpClientDetails)
+	if err != nil || response.StatusCode == http.StatusNotFound {
+		return nil, false, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("failed to download the cached results from Artifactory. Received status %d: %s", response.StatusCode, string(content))
+	}
+	return content, true, nil
+}
+
+func (s *artifactoryResultsCacheStorage) Write(key string, content []byte) error {
+	httpClientDetails := s.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	if httpClientDetails.Headers == nil {
+		httpClientDetails.Headers = map[string]string{}
+	}
+	httpClientDetails.Headers["Content-Type"] = "application/json"
+	response, responseContent, err := s.serviceManager.Client().SendPut(s.getFileUrl(key), content, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload the cached results to Artifactory. Received status %d: %s", response.StatusCode, string(responseContent))
+	}
+	return nil
+}
+
+func (s *artifactoryResultsCacheStorage) getFileUrl(key string) string {
+	return strings.TrimSuffix(s.serviceManager.GetConfig().GetServiceDetails().GetUrl(), "/") + "/" + s.repo + "/" + key + resultsCacheFileExtension
+}
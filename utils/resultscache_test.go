@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedTestResults struct {
+	Keys []string `json:"keys"`
+}
+
+func TestResultsCacheLocalStorage(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	resultsCache := NewResultsCache(&localResultsCacheStorage{dir: cacheDir})
+	results := &cachedTestResults{}
+	assert.False(t, resultsCache.Get("key", results))
+
+	resultsCache.Put("key", &cachedTestResults{Keys: []string{"XRAY-1"}})
+	require.True(t, resultsCache.Get("key", results))
+	assert.Equal(t, []string{"XRAY-1"}, results.Keys)
+	assert.False(t, resultsCache.Get("other-key", results))
+
+	// Expired results are audited again
+	expiredEntry, err := json.Marshal(resultsCacheEntry{Created: time.Now().Add(-resultsCacheExpiration - time.Minute), Results: []byte(`{"keys":["XRAY-2"]}`)})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "expired"+resultsCacheFileExtension), expiredEntry, 0600))
+	assert.False(t, resultsCache.Get("expired", results))
+
+	// Nothing is cached when caching is disabled
+	var disabledCache *ResultsCache
+	disabledCache.Put("key", results)
+	assert.False(t, disabledCache.Get("key", results))
+}
+
+func TestResultsCacheArtifactoryStorage(t *testing.T) {
+	storedContent := map[string][]byte{}
+	// The server has a self-signed certificate, which is accepted by the InsecureTls setting of the server details
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodPut:
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			storedContent[r.URL.Path] = content
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			content, exists := storedContent[r.URL.Path]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, err := w.Write(content)
+			assert.NoError(t, err)
+		}
+	}))
+	defer server.Close()
+
+	storage, err := newArtifactoryResultsCacheStorage(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/", AccessToken: "token", InsecureTls: true}, "frogbot-cache")
+	require.NoError(t, err)
+	resultsCache := NewResultsCache(storage)
+	results := &cachedTestResults{}
+	assert.False(t, resultsCache.Get("key", results))
+	resultsCache.Put("key", &cachedTestResults{Keys: []string{"XRAY-1"}})
+	assert.Contains(t, storedContent, "/artifactory/frogbot-cache/key.json")
+	require.True(t, resultsCache.Get("key", results))
+	assert.Equal(t, []string{"XRAY-1"}, results.Keys)
+}
+
+func TestNewResultsCacheFromEnv(t *testing.T) {
+	defer func() {
+		assert.NoError(t, SanitizeEnv())
+	}()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://jfrog.io/artifactory/"}
+	resultsCache, err := newResultsCacheFromEnv(serverDetails)
+	require.NoError(t, err)
+	assert.IsType(t, &localResultsCacheStorage{}, resultsCache.storage)
+
+	SetEnvAndAssert(t, map[string]string{ResultsCacheDirEnv: "cache-dir"})
+	resultsCache, err = newResultsCacheFromEnv(serverDetails)
+	require.NoError(t, err)
+	assert.Equal(t, &localResultsCacheStorage{dir: "cache-dir"}, resultsCache.storage)
+
+	SetEnvAndAssert(t, map[string]string{ResultsCacheRepoEnv: "frogbot-cache/"})
+	resultsCache, err = newResultsCacheFromEnv(serverDetails)
+	require.NoError(t, err)
+	require.IsType(t, &artifactoryResultsCacheStorage{}, resultsCache.storage)
+	assert.Equal(t, "https://jfrog.io/artifactory/frogbot-cache/key.json", resultsCache.storage.(*artifactoryResultsCacheStorage).getFileUrl("key"))
+	_, err = newResultsCacheFromEnv(&config.ServerDetails{})
+	assert.ErrorContains(t, err, "the JFrog Artifactory URL is required")
+
+	SetEnvAndAssert(t, map[string]string{DisableResultsCacheEnv: "true"})
+	resultsCache, err = newResultsCacheFromEnv(serverDetails)
+	require.NoError(t, err)
+	assert.Nil(t, resultsCache)
+}
+
+func TestGetResultsCacheKey(t *testing.T) {
+	branch := vcsclient.BranchInfo{Name: "master", Repository: "frogbot", Owner: "jfrog"}
+	newScanDetails := func(workingDirs ...string) *ScanDetails {
+		return NewScanDetails(nil, &config.ServerDetails{XrayUrl: "https://jfrog.io/xray/"}, &Git{}).
+			SetProject(&Project{WorkingDirs: workingDirs}).
+			SetXrayGraphScanParams([]string{"watch"}, "", true)
+	}
+	getKey := func(scanDetails *ScanDetails, commitSha string) string {
+		key, err := scanDetails.GetResultsCacheKey(branch, commitSha)
+		require.NoError(t, err)
+		return key
+	}
+	key := getKey(newScanDetails("api", "web"), "sha1")
+	assert.Equal(t, key, getKey(newScanDetails("web", "api"), "sha1"))
+	assert.NotEqual(t, key, getKey(newScanDetails("api", "web"), "sha2"))
+	assert.NotEqual(t, key, getKey(newScanDetails("api"), "sha1"))
+	assert.NotEqual(t, key, getKey(newScanDetails("api", "web").SetXrayGraphScanParams([]string{"other-watch"}, "", true), "sha1"))
+}
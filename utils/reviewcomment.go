@@ -2,12 +2,16 @@ package utils
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/jfrog/frogbot/utils/outputwriter"
 	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/gofrog/datastructures"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
@@ -18,6 +22,11 @@ type ReviewComment struct {
 	Location    formats.Location
 	CommentInfo vcsclient.PullRequestComment
 	Type        ReviewCommentType
+	Severity    string
+	// A plain text description of the issue, used where the Markdown content of the comment can't be displayed
+	Summary string
+	// Identifies the issue across scans, by its type, file, summary and snippet. The key is included in the content of the comment
+	Key string
 }
 
 const (
@@ -26,23 +35,37 @@ const (
 	SastComment       ReviewCommentType = "Sast"
 
 	CommentId = "FrogbotReviewComment"
+	// Precedes the key of the issue in the content of the review comment
+	reviewCommentKeyPrefix = CommentId + "Key: "
 )
 
+var reviewCommentKeyRegex = regexp.MustCompile(regexp.QuoteMeta(reviewCommentKeyPrefix) + `([0-9a-f]+)`)
+
+// AddReviewComments adds a review comment for each issue that isn't commented on yet, and deletes the review comments of the issues that no longer exist.
+// The review comments of the remaining issues, along with their replies, are left as is.
+// Review comments are matched to the issues by a key in their content, which doesn't depend on the lines and columns of the issue,
+// so that changes to other lines of the file don't replace the review comment.
 func AddReviewComments(repo *Repository, pullRequestID int, client vcsclient.VcsClient, issues *IssuesCollection) (err error) {
-	if err = deleteOldReviewComments(repo, pullRequestID, client); err != nil {
+	commentsToAdd := GetNewReviewComments(repo, issues)
+	issueKeys := datastructures.MakeSet[string]()
+	for _, comment := range commentsToAdd {
+		issueKeys.Add(comment.Key)
+	}
+	commentedKeys := datastructures.MakeSet[string]()
+	if err = deleteResolvedReviewComments(repo, pullRequestID, client, issueKeys, commentedKeys); err != nil {
 		err = errors.New("couldn't delete pull request review comment: " + err.Error())
 		return
 	}
-	if err = deleteOldFallbackComments(repo, pullRequestID, client); err != nil {
+	if err = deleteResolvedFallbackComments(repo, pullRequestID, client, issueKeys, commentedKeys); err != nil {
 		err = errors.New("couldn't delete pull request comment: " + err.Error())
 		return
 	}
-	commentsToAdd := getNewReviewComments(repo, issues)
-	if len(commentsToAdd) == 0 {
-		return
-	}
-	// Add review comments for the given data
+	// Add review comments for the issues that aren't commented on yet
 	for _, comment := range commentsToAdd {
+		if commentedKeys.Exists(comment.Key) {
+			log.Debug("a review comment already exists for", comment.Type, comment.Location.File, comment.Location.StartLine, comment.Location.StartColumn)
+			continue
+		}
 		log.Debug("creating a review comment for", comment.Type, comment.Location.File, comment.Location.StartLine, comment.Location.StartColumn)
 		if e := client.AddPullRequestReviewComments(context.Background(), repo.RepoOwner, repo.RepoName, pullRequestID, comment.CommentInfo); e != nil {
 			log.Debug("couldn't add pull request review comment, fallback to regular comment: " + e.Error())
@@ -51,20 +74,21 @@ func AddReviewComments(repo *Repository, pullRequestID int, client vcsclient.Vcs
 				return
 			}
 		}
+		commentedKeys.Add(comment.Key)
 	}
 	return
 }
 
-func deleteOldReviewComments(repo *Repository, pullRequestID int, client vcsclient.VcsClient) (err error) {
+func deleteResolvedReviewComments(repo *Repository, pullRequestID int, client vcsclient.VcsClient, issueKeys, commentedKeys *datastructures.Set[string]) (err error) {
 	// Get all comments in PR
 	var existingComments []vcsclient.CommentInfo
 	if existingComments, err = client.ListPullRequestReviewComments(context.Background(), repo.RepoOwner, repo.RepoName, pullRequestID); err != nil {
 		err = errors.New("couldn't list existing review comments: " + err.Error())
 		return
 	}
-	// Delete old review comments
-	if len(existingComments) > 0 {
-		if err = client.DeletePullRequestReviewComments(context.Background(), repo.RepoOwner, repo.RepoName, pullRequestID, getFrogbotReviewComments(existingComments)...); err != nil {
+	// Delete the review comments of the resolved issues
+	if resolvedComments := getResolvedReviewComments(existingComments, issueKeys, commentedKeys); len(resolvedComments) > 0 {
+		if err = client.DeletePullRequestReviewComments(context.Background(), repo.RepoOwner, repo.RepoName, pullRequestID, resolvedComments...); err != nil {
 			err = errors.New("couldn't delete pull request review comment: " + err.Error())
 			return
 		}
@@ -72,72 +96,99 @@ func deleteOldReviewComments(repo *Repository, pullRequestID int, client vcsclie
 	return
 }
 
-func deleteOldFallbackComments(repo *Repository, pullRequestID int, client vcsclient.VcsClient) (err error) {
+func deleteResolvedFallbackComments(repo *Repository, pullRequestID int, client vcsclient.VcsClient, issueKeys, commentedKeys *datastructures.Set[string]) (err error) {
 	// Get all comments in PR
 	existingComments, err := GetSortedPullRequestComments(client, repo.RepoOwner, repo.RepoName, pullRequestID)
 	if err != nil {
 		err = errors.New("couldn't list existing regular comments: " + err.Error())
 		return
 	}
-	// Delete old review comments
-	if len(existingComments) > 0 {
-		for _, commentToDelete := range getFrogbotReviewComments(existingComments) {
-			if err = client.DeletePullRequestComment(context.Background(), repo.RepoOwner, repo.RepoName, pullRequestID, int(commentToDelete.ID)); err != nil {
-				err = errors.New("couldn't delete pull request regular comment: " + err.Error())
-				return
-			}
+	// Delete the regular comments of the resolved issues
+	for _, commentToDelete := range getResolvedReviewComments(existingComments, issueKeys, commentedKeys) {
+		if err = client.DeletePullRequestComment(context.Background(), repo.RepoOwner, repo.RepoName, pullRequestID, int(commentToDelete.ID)); err != nil {
+			err = errors.New("couldn't delete pull request regular comment: " + err.Error())
+			return
 		}
 	}
 	return
 }
 
-func getFrogbotReviewComments(existingComments []vcsclient.CommentInfo) (reviewComments []vcsclient.CommentInfo) {
+// Returns the Frogbot review comments whose issues no longer exist, and adds the keys of the rest of the review comments to commentedKeys.
+// Review comments without a key, added by previous versions of Frogbot, and duplicate review comments of the same issue are returned as well.
+func getResolvedReviewComments(existingComments []vcsclient.CommentInfo, issueKeys, commentedKeys *datastructures.Set[string]) (resolvedComments []vcsclient.CommentInfo) {
 	for _, comment := range existingComments {
-		if strings.Contains(comment.Content, CommentId) {
-			log.Debug("Deleting comment id:", comment.ID)
-			reviewComments = append(reviewComments, comment)
+		if !strings.Contains(comment.Content, CommentId) {
+			continue
+		}
+		if key := extractReviewCommentKey(comment.Content); key != "" && issueKeys.Exists(key) && !commentedKeys.Exists(key) {
+			commentedKeys.Add(key)
+			continue
 		}
+		log.Debug("Deleting comment id:", comment.ID)
+		resolvedComments = append(resolvedComments, comment)
 	}
 	return
 }
 
+// Returns the key of the issue a review comment was added for, according to its content
+func extractReviewCommentKey(content string) string {
+	if match := reviewCommentKeyRegex.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Returns the key of the issue, which identifies the review comment of the issue across scans.
+// The summary includes the finding, or the CVE and the vulnerable component. The position of the issue isn't part of the key, since it changes when other lines of the file change.
+// Identical issues in the same file, with the same snippet, share a single review comment.
+func getReviewCommentKey(commentType ReviewCommentType, location formats.Location, summary string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s", commentType, location.File, summary, location.Snippet)))
+	return hex.EncodeToString(hash[:])
+}
+
 func getRegularCommentContent(comment ReviewComment) string {
 	content := outputwriter.MarkdownComment(CommentId)
 	return content + outputwriter.GetLocationDescription(comment.Location) + comment.CommentInfo.Content
 }
 
-func getNewReviewComments(repo *Repository, issues *IssuesCollection) (commentsToAdd []ReviewComment) {
+// GetNewReviewComments returns the review comments for the issues found, each one attached to the location of its issue.
+func GetNewReviewComments(repo *Repository, issues *IssuesCollection) (commentsToAdd []ReviewComment) {
 	writer := repo.OutputWriter
 
 	for _, vulnerability := range issues.Vulnerabilities {
 		for _, cve := range vulnerability.Cves {
 			if cve.Applicability != nil {
 				for _, evidence := range cve.Applicability.Evidence {
-					commentsToAdd = append(commentsToAdd, generateReviewComment(ApplicableComment, evidence.Location, generateApplicabilityReviewContent(evidence, cve, vulnerability, writer)))
+					summary := fmt.Sprintf("%s is applicable in %s:%s", cve.Id, vulnerability.ImpactedDependencyName, vulnerability.ImpactedDependencyVersion)
+					commentsToAdd = append(commentsToAdd, generateReviewComment(ApplicableComment, evidence.Location, vulnerability.Severity, summary, generateApplicabilityReviewContent(evidence, cve, vulnerability, writer)))
 				}
 			}
 		}
 	}
 	for _, iac := range issues.Iacs {
-		commentsToAdd = append(commentsToAdd, generateReviewComment(IacComment, iac.Location, generateReviewCommentContent(IacComment, iac, writer)))
+		commentsToAdd = append(commentsToAdd, generateReviewComment(IacComment, iac.Location, iac.Severity, iac.Finding, generateReviewCommentContent(IacComment, iac, writer)))
 	}
 
 	for _, sast := range issues.Sast {
-		commentsToAdd = append(commentsToAdd, generateReviewComment(SastComment, sast.Location, generateReviewCommentContent(SastComment, sast, writer)))
+		commentsToAdd = append(commentsToAdd, generateReviewComment(SastComment, sast.Location, sast.Severity, sast.Finding, generateReviewCommentContent(SastComment, sast, writer)))
 	}
 	return
 }
 
-func generateReviewComment(commentType ReviewCommentType, location formats.Location, content string) (comment ReviewComment) {
+func generateReviewComment(commentType ReviewCommentType, location formats.Location, severity, summary, content string) (comment ReviewComment) {
+	key := getReviewCommentKey(commentType, location, summary)
 	return ReviewComment{
 		Location: location,
 		CommentInfo: vcsclient.PullRequestComment{
 			CommentInfo: vcsclient.CommentInfo{
-				Content: content,
+				Content: content + outputwriter.MarkdownComment(reviewCommentKeyPrefix+key),
 			},
 			PullRequestDiff: createPullRequestDiff(location),
 		},
-		Type: commentType,
+		Type:     commentType,
+		Severity: severity,
+		Summary:  summary,
+		Key:      key,
 	}
 
 }
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jfrog/frogbot/testdata"
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddReviewComments(t *testing.T) {
+	repo := &Repository{Params: Params{Git: Git{RepoOwner: "jfrog", RepoName: "frogbot"}}, OutputWriter: &outputwriter.StandardOutput{}}
+	iac := func(file, finding string) formats.SourceCodeRow {
+		return formats.SourceCodeRow{SeverityDetails: formats.SeverityDetails{Severity: "High"}, Location: formats.Location{File: file, StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 5, Snippet: "text"}, Finding: finding}
+	}
+	unchanged, added, resolved := iac("main.tf", "unchanged finding"), iac("main.tf", "new finding"), iac("other.tf", "resolved finding")
+	existingComments := GetNewReviewComments(repo, &IssuesCollection{Iacs: []formats.SourceCodeRow{unchanged, resolved}})
+	// The review comment of the issue before the lines above it were changed
+	moved := unchanged
+	moved.StartLine, moved.EndLine = 10, 10
+	movedComments := GetNewReviewComments(repo, &IssuesCollection{Iacs: []formats.SourceCodeRow{moved}})
+	commentInfo := func(id int64, comment ReviewComment) vcsclient.CommentInfo {
+		return vcsclient.CommentInfo{ID: id, Content: comment.CommentInfo.Content}
+	}
+	legacyComment := vcsclient.CommentInfo{ID: 4, Content: outputwriter.MarkdownComment(CommentId) + "legacy"}
+	userReply := vcsclient.CommentInfo{ID: 5, Content: "a reply to the review comment"}
+
+	testCases := []struct {
+		name                   string
+		existingReviewComments []vcsclient.CommentInfo
+		existingComments       []vcsclient.CommentInfo
+		expectedDeleted        []vcsclient.CommentInfo
+		expectedDeletedIDs     []int
+		addReviewCommentErr    error
+		expectedAdded          int
+	}{
+		{
+			name:                   "Diff the review comments",
+			existingReviewComments: []vcsclient.CommentInfo{commentInfo(1, existingComments[0]), commentInfo(2, existingComments[1]), legacyComment, userReply},
+			expectedDeleted:        []vcsclient.CommentInfo{commentInfo(2, existingComments[1]), legacyComment},
+			expectedAdded:          1,
+		},
+		{
+			name:                   "Keep the review comments of issues moved by other changes",
+			existingReviewComments: []vcsclient.CommentInfo{commentInfo(1, movedComments[0]), userReply},
+			expectedAdded:          1,
+		},
+		{
+			name:                   "Delete duplicate review comments",
+			existingReviewComments: []vcsclient.CommentInfo{commentInfo(1, existingComments[0]), commentInfo(3, existingComments[0])},
+			expectedDeleted:        []vcsclient.CommentInfo{commentInfo(3, existingComments[0])},
+			expectedAdded:          1,
+		},
+		{
+			name:                "Diff the fallback regular comments",
+			existingComments:    []vcsclient.CommentInfo{commentInfo(1, existingComments[0]), commentInfo(2, existingComments[1]), userReply},
+			expectedDeletedIDs:  []int{2},
+			addReviewCommentErr: errors.New("review comments aren't supported"),
+			expectedAdded:       1,
+		},
+		{
+			name:          "No existing comments",
+			expectedAdded: 2,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := testdata.NewMockVcsClient(ctrl)
+			client.EXPECT().ListPullRequestReviewComments(context.Background(), "jfrog", "frogbot", 17).Return(test.existingReviewComments, nil)
+			client.EXPECT().ListPullRequestComments(context.Background(), "jfrog", "frogbot", 17).Return(test.existingComments, nil)
+			if len(test.expectedDeleted) > 0 {
+				client.EXPECT().DeletePullRequestReviewComments(context.Background(), "jfrog", "frogbot", 17, test.expectedDeleted).Return(nil)
+			}
+			for _, id := range test.expectedDeletedIDs {
+				client.EXPECT().DeletePullRequestComment(context.Background(), "jfrog", "frogbot", 17, id).Return(nil)
+			}
+			addedCount := 0
+			client.EXPECT().AddPullRequestReviewComments(context.Background(), "jfrog", "frogbot", 17, gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, _ int, _ ...vcsclient.PullRequestComment) error {
+				if test.addReviewCommentErr == nil {
+					addedCount++
+				}
+				return test.addReviewCommentErr
+			}).AnyTimes()
+			client.EXPECT().AddPullRequestComment(context.Background(), "jfrog", "frogbot", gomock.Any(), 17).DoAndReturn(func(_ context.Context, _, _, _ string, _ int) error {
+				addedCount++
+				return nil
+			}).AnyTimes()
+			assert.NoError(t, AddReviewComments(repo, 17, client, &IssuesCollection{Iacs: []formats.SourceCodeRow{unchanged, added}}))
+			assert.Equal(t, test.expectedAdded, addedCount)
+		})
+	}
+}
+
+func TestReviewCommentKey(t *testing.T) {
+	location := formats.Location{File: "main.tf", StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 5, Snippet: "resource"}
+	comment := generateReviewComment(IacComment, location, "High", "finding", "content")
+	assert.Equal(t, comment.Key, extractReviewCommentKey(comment.CommentInfo.Content))
+	assert.Equal(t, comment.Key, generateReviewComment(IacComment, location, "Low", "finding", "other content").Key)
+	assert.NotEqual(t, comment.Key, generateReviewComment(SastComment, location, "High", "finding", "content").Key)
+	assert.NotEqual(t, comment.Key, generateReviewComment(IacComment, location, "High", "other finding", "content").Key)
+	// The position of the issue doesn't affect the key, since it changes when other lines of the file change
+	moved := location
+	moved.StartLine, moved.EndLine, moved.StartColumn, moved.EndColumn = 7, 8, 3, 9
+	assert.Equal(t, comment.Key, generateReviewComment(IacComment, moved, "High", "finding", "content").Key)
+	otherFile, otherSnippet := location, location
+	otherFile.File = "other.tf"
+	otherSnippet.Snippet = "other resource"
+	assert.NotEqual(t, comment.Key, generateReviewComment(IacComment, otherFile, "High", "finding", "content").Key)
+	assert.NotEqual(t, comment.Key, generateReviewComment(IacComment, otherSnippet, "High", "finding", "content").Key)
+	assert.Empty(t, extractReviewCommentKey(outputwriter.MarkdownComment(CommentId)))
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	PullRequestCreated = "created"
+	PullRequestUpdated = "updated"
+)
+
+// RunSummary is a machine-readable summary of a Frogbot command, written as a JSON file at the end of the command.
+// The summary allows pipelines to act according to the result of the command, without parsing the logs.
+// A nil summary means that no summary file was requested. All the methods are safe to call on a nil summary, in which case nothing is recorded.
+type RunSummary struct {
+	Command  string         `json:"command"`
+	ExitCode ExitCode       `json:"exitCode"`
+	Error    string         `json:"error,omitempty"`
+	Scans    []*ScanSummary `json:"scans"`
+	// The path of the JSON file to write the summary to
+	path  string
+	mutex sync.Mutex
+}
+
+// ScanSummary summarizes the scan of a single pull request or branch.
+type ScanSummary struct {
+	Owner         string      `json:"owner,omitempty"`
+	Repository    string      `json:"repository"`
+	Branch        string      `json:"branch,omitempty"`
+	PullRequestID int64       `json:"pullRequestId,omitempty"`
+	Issues        IssuesCount `json:"issues"`
+	// The issues of the target branch fixed by the pull request
+	FixedIssues []outputwriter.FixedIssue `json:"fixedIssues,omitempty"`
+	// The fix pull requests created or updated by Frogbot
+	PullRequests []PullRequestSummary `json:"pullRequests,omitempty"`
+	Error        string               `json:"error,omitempty"`
+}
+
+type IssuesCount struct {
+	Vulnerabilities int `json:"vulnerabilities"`
+	Licenses        int `json:"licenses"`
+	Secrets         int `json:"secrets"`
+	Iac             int `json:"iac"`
+	Sast            int `json:"sast"`
+}
+
+type PullRequestSummary struct {
+	// Either created or updated
+	Action string `json:"action"`
+	Branch string `json:"branch"`
+	Url    string `json:"url,omitempty"`
+}
+
+// NewRunSummary returns a summary to be written to the given path. If the path is empty, no summary is recorded, and nil is returned.
+func NewRunSummary(command, path string) *RunSummary {
+	if path == "" {
+		return nil
+	}
+	return &RunSummary{Command: command, Scans: []*ScanSummary{}, path: path}
+}
+
+// AddScan adds the summary of a new scan. The returned summary should be updated with the results of the scan.
+func (rs *RunSummary) AddScan(owner, repository, branch string, pullRequestID int64) *ScanSummary {
+	if rs == nil {
+		return nil
+	}
+	scanSummary := &ScanSummary{Owner: owner, Repository: repository, Branch: branch, PullRequestID: pullRequestID}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	rs.Scans = append(rs.Scans, scanSummary)
+	return scanSummary
+}
+
+// Write sets the exit code according to the error of the command, and writes the summary file.
+func (rs *RunSummary) Write(commandErr error) (err error) {
+	if rs == nil {
+		return
+	}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	rs.ExitCode = GetExitCode(commandErr)
+	if commandErr != nil {
+		rs.Error = commandErr.Error()
+	}
+	content, err := json.MarshalIndent(rs, "", "  ")
+	if err != nil {
+		return
+	}
+	log.Info("Writing the run summary to", rs.path)
+	return os.WriteFile(rs.path, content, 0600)
+}
+
+func (ss *ScanSummary) SetIssues(issues *IssuesCollection) {
+	if ss == nil || issues == nil {
+		return
+	}
+	ss.Issues = IssuesCount{
+		Vulnerabilities: len(issues.Vulnerabilities),
+		Licenses:        len(issues.Licenses),
+		Secrets:         len(issues.Secrets),
+		Iac:             len(issues.Iacs),
+		Sast:            len(issues.Sast),
+	}
+	ss.FixedIssues = issues.FixedIssues
+}
+
+func (ss *ScanSummary) AddVulnerabilities(count int) {
+	if ss == nil {
+		return
+	}
+	ss.Issues.Vulnerabilities += count
+}
+
+func (ss *ScanSummary) AddPullRequest(action, branch, url string) {
+	if ss == nil {
+		return
+	}
+	ss.PullRequests = append(ss.PullRequests, PullRequestSummary{Action: action, Branch: branch, Url: url})
+}
+
+func (ss *ScanSummary) SetError(err error) {
+	if ss == nil || err == nil {
+		return
+	}
+	ss.Error = err.Error()
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/frogbot/utils/outputwriter"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSummaryWrite(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.json")
+	runSummary := NewRunSummary(ScanRepository, summaryPath)
+
+	scanSummary := runSummary.AddScan("jfrog", "frogbot", "master", 0)
+	scanSummary.AddVulnerabilities(2)
+	scanSummary.AddVulnerabilities(1)
+	scanSummary.AddPullRequest(PullRequestCreated, "frogbot-minimist-258ad6a538b5ba800f18ae4f6d660302", "https://github.com/jfrog/frogbot/pull/1")
+	scanSummary.AddPullRequest(PullRequestUpdated, "frogbot-mpath-41b1f45136b25e3624b15999bd57a476", "")
+
+	failedScanSummary := runSummary.AddScan("jfrog", "frogbot", "dev", 0)
+	failedScanSummary.SetIssues(&IssuesCollection{
+		Vulnerabilities: []formats.VulnerabilityOrViolationRow{{}},
+		Secrets:         []formats.SourceCodeRow{{}, {}},
+		Sast:            []formats.SourceCodeRow{{}},
+		FixedIssues:     []outputwriter.FixedIssue{{Severity: "High", Type: "Vulnerability", Issue: "CVE-2023-0001", Location: "lodash:4.17.20"}},
+	})
+	commandErr := WithExitCode(errors.New("failed to push"), ExitCodeVcsApiError)
+	failedScanSummary.SetError(commandErr)
+	require.NoError(t, runSummary.Write(commandErr))
+
+	content, err := os.ReadFile(summaryPath)
+	require.NoError(t, err)
+	var actualSummary RunSummary
+	require.NoError(t, json.Unmarshal(content, &actualSummary))
+	assert.Equal(t, ScanRepository, actualSummary.Command)
+	assert.Equal(t, ExitCodeVcsApiError, actualSummary.ExitCode)
+	assert.Equal(t, "failed to push", actualSummary.Error)
+	assert.Equal(t, []*ScanSummary{
+		{
+			Owner:      "jfrog",
+			Repository: "frogbot",
+			Branch:     "master",
+			Issues:     IssuesCount{Vulnerabilities: 3},
+			PullRequests: []PullRequestSummary{
+				{Action: PullRequestCreated, Branch: "frogbot-minimist-258ad6a538b5ba800f18ae4f6d660302", Url: "https://github.com/jfrog/frogbot/pull/1"},
+				{Action: PullRequestUpdated, Branch: "frogbot-mpath-41b1f45136b25e3624b15999bd57a476"},
+			},
+		},
+		{
+			Owner:       "jfrog",
+			Repository:  "frogbot",
+			Branch:      "dev",
+			Issues:      IssuesCount{Vulnerabilities: 1, Secrets: 2, Sast: 1},
+			FixedIssues: []outputwriter.FixedIssue{{Severity: "High", Type: "Vulnerability", Issue: "CVE-2023-0001", Location: "lodash:4.17.20"}},
+			Error:       "failed to push",
+		},
+	}, actualSummary.Scans)
+}
+
+func TestRunSummaryDisabled(t *testing.T) {
+	// Without a path, nothing is recorded or written
+	runSummary := NewRunSummary(ScanPullRequest, "")
+	assert.Nil(t, runSummary)
+	scanSummary := runSummary.AddScan("jfrog", "frogbot", "master", 1)
+	assert.Nil(t, scanSummary)
+	scanSummary.SetIssues(&IssuesCollection{})
+	scanSummary.AddVulnerabilities(1)
+	scanSummary.AddPullRequest(PullRequestCreated, "branch", "")
+	scanSummary.SetError(errors.New("error"))
+	assert.NoError(t, runSummary.Write(errors.New("error")))
+}
@@ -7,19 +7,24 @@ import (
 	"github.com/jfrog/froggit-go/vcsclient"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit"
-	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/jfrog/jfrog-client-go/xray/services"
 
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 const (
 	installationCmdFailedErr = "Couldn't run the installation command on the base branch. Assuming new project in the source branch: "
 )
 
+// The audit of jfrog-cli-core, and the resolution of dependencies from Artifactory, change the working directory of the process,
+// so only one of them can run at a time in the process. Audits that should run concurrently run in subprocesses instead.
+// The installation commands run in their own working directory, so the branches are downloaded and installed concurrently.
+var workingDirMutex sync.Mutex
+
 type ScanDetails struct {
 	*Project
 	*Git
@@ -30,6 +35,7 @@ type ScanDetails struct {
 	fixableOnly              bool
 	minSeverityFilter        string
 	baseBranch               string
+	auditInSubprocess        bool
 }
 
 func NewScanDetails(client vcsclient.VcsClient, server *config.ServerDetails, git *Git) *ScanDetails {
@@ -51,6 +57,12 @@ func (sc *ScanDetails) SetXrayGraphScanParams(watches []string, jfrogProjectKey
 	return sc
 }
 
+// SetAuditInSubprocess runs the audits in subprocesses of Frogbot, so that several branches can be audited at the same time
+func (sc *ScanDetails) SetAuditInSubprocess(inSubprocess bool) *ScanDetails {
+	sc.auditInSubprocess = inSubprocess
+	return sc
+}
+
 func (sc *ScanDetails) SetFixableOnly(fixable bool) *ScanDetails {
 	sc.fixableOnly = fixable
 	return sc
@@ -114,27 +126,35 @@ func createXrayScanParams(watches []string, project string, includeLicenses bool
 }
 
 func (sc *ScanDetails) RunInstallAndAudit(workDirs ...string) (auditResults *audit.Results, err error) {
+	defer func() {
+		err = WithExitCode(err, ExitCodeAuditError)
+	}()
 	for _, wd := range workDirs {
 		if err = sc.runInstallIfNeeded(wd); err != nil {
 			return nil, err
 		}
 	}
 
-	auditBasicParams := (&xrayutils.AuditBasicParams{}).
-		SetPipRequirementsFile(sc.PipRequirementsFile).
-		SetUseWrapper(*sc.UseWrapper).
-		SetDepsRepo(sc.DepsRepo).
-		SetIgnoreConfigFile(true).
-		SetServerDetails(sc.ServerDetails)
-
-	auditParams := audit.NewAuditParams().
-		SetXrayGraphScanParams(sc.XrayGraphScanParams).
-		SetWorkingDirs(workDirs).
-		SetMinSeverityFilter(sc.MinSeverityFilter()).
-		SetFixableOnly(sc.FixableOnly()).
-		SetGraphBasicParams(auditBasicParams)
-
-	auditResults, err = audit.RunAudit(auditParams)
+	auditReq := &auditRequest{
+		WorkingDirs:         workDirs,
+		ServerDetails:       sc.ServerDetails,
+		XrayGraphScanParams: sc.XrayGraphScanParams,
+		PipRequirementsFile: sc.PipRequirementsFile,
+		UseWrapper:          *sc.UseWrapper,
+		DepsRepo:            sc.DepsRepo,
+		MinSeverityFilter:   sc.MinSeverityFilter(),
+		FixableOnly:         sc.FixableOnly(),
+	}
+	if sc.ServerDetails != nil {
+		auditReq.InsecureTls = sc.ServerDetails.InsecureTls
+	}
+	if sc.auditInSubprocess {
+		auditResults, err = runAuditInSubprocess(auditReq)
+	} else {
+		workingDirMutex.Lock()
+		auditResults, err = runAudit(auditReq.auditParams())
+		workingDirMutex.Unlock()
+	}
 	if auditResults != nil {
 		err = errors.Join(err, auditResults.ScaError, auditResults.JasError)
 	}
@@ -145,12 +165,8 @@ func (sc *ScanDetails) runInstallIfNeeded(workDir string) (err error) {
 	if sc.InstallCommandName == "" {
 		return nil
 	}
-	restoreDir, err := Chdir(workDir)
-	defer func() {
-		err = errors.Join(err, restoreDir())
-	}()
 	log.Info(fmt.Sprintf("Executing '%s %s' at %s", sc.InstallCommandName, strings.Join(sc.InstallCommandArgs, " "), workDir))
-	output, err := sc.runInstallCommand()
+	output, err := sc.runInstallCommand(workDir)
 	if err != nil && !sc.FailOnInstallationErrors() {
 		log.Info(installationCmdFailedErr, err.Error())
 		if len(output) > 0 {
@@ -162,15 +178,27 @@ func (sc *ScanDetails) runInstallIfNeeded(workDir string) (err error) {
 	return
 }
 
-func (sc *ScanDetails) runInstallCommand() ([]byte, error) {
+func (sc *ScanDetails) runInstallCommand(workDir string) (output []byte, err error) {
 	if sc.DepsRepo == "" {
 		//#nosec G204 -- False positive - the subprocess only runs after the user's approval.
-		return exec.Command(sc.InstallCommandName, sc.InstallCommandArgs...).CombinedOutput()
+		installCmd := exec.Command(sc.InstallCommandName, sc.InstallCommandArgs...)
+		installCmd.Dir = workDir
+		return installCmd.CombinedOutput()
 	}
 	resolveDepsFunc := MapTechToResolvingFunc[sc.InstallCommandName]
 	if resolveDepsFunc == nil {
 		return nil, fmt.Errorf(sc.InstallCommandName, "isn't recognized as an install command")
 	}
+	// The dependencies are resolved in the current working directory of the process
+	workingDirMutex.Lock()
+	defer workingDirMutex.Unlock()
+	restoreDir, err := Chdir(workDir)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, restoreDir())
+	}()
 	log.Info("Resolving dependencies from", sc.ServerDetails.Url, "from repo", sc.DepsRepo)
 	return resolveDepsFunc(sc)
 }
@@ -3,6 +3,8 @@ package utils
 import (
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -64,6 +66,31 @@ func TestRunInstallIfNeeded(t *testing.T) {
 	assert.Error(t, scanSetup.runInstallIfNeeded(tmpDir))
 }
 
+func TestRunInstallConcurrently(t *testing.T) {
+	// Each installation waits for the other one to start, so it fails if the branches are installed one at a time
+	branchesDir := t.TempDir()
+	var installTasks []func() error
+	for _, branch := range []string{"source", "target"} {
+		workDir := filepath.Join(branchesDir, branch)
+		require.NoError(t, os.Mkdir(workDir, 0755))
+		scanDetails := (&ScanDetails{Project: &Project{
+			InstallCommandName: "sh",
+			InstallCommandArgs: []string{"-c", "touch ../$(basename $PWD).started; for i in $(seq 50); do [ -f ../source.started ] && [ -f ../target.started ] && exit 0; sleep 0.1; done; exit 1"},
+		}}).SetFailOnInstallationErrors(true)
+		installTasks = append(installTasks, func() error {
+			return scanDetails.runInstallIfNeeded(workDir)
+		})
+	}
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.NoError(t, RunConcurrently(2, installTasks...))
+	// The installation commands run in their own working directory, without changing the working directory of the process
+	currentWd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, wd, currentWd)
+	assert.FileExists(t, filepath.Join(branchesDir, "source.started"))
+}
+
 func TestGetFullPathWorkingDirs(t *testing.T) {
 	sampleProject := Project{
 		WorkingDirs: []string{filepath.Join("a", "b"), filepath.Join("a", "b", "c"), ".", filepath.Join("c", "d", "e", "f")},
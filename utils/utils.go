@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/jfrog/froggit-go/vcsclient"
 	"github.com/jfrog/gofrog/version"
@@ -30,6 +31,13 @@ const (
 	ScanAllPullRequests      = "scan-all-pull-requests"
 	ScanRepository           = "scan-repository"
 	ScanMultipleRepositories = "scan-multiple-repositories"
+	ScanLocal                = "scan-local"
+	ScanDiff                 = "scan-diff"
+	ValidateConfig           = "validate-config"
+	Serve                    = "serve"
+	Report                   = "report"
+	Init                     = "init"
+	MigrateConfig            = "migrate-config"
 	RootDir                  = "."
 	branchNameRegex          = `[~^:?\\\[\]@{}*]`
 
@@ -285,17 +293,40 @@ func DownloadRepoToTempDir(client vcsclient.VcsClient, repoOwner, repoName, bran
 	}
 	log.Debug(fmt.Sprintf("Downloading <%s/%s/%s> to: '%s'", repoOwner, repoName, branch, wd))
 	if err = client.DownloadRepository(context.Background(), repoOwner, repoName, branch, wd); err != nil {
-		err = fmt.Errorf("failed to download branch: <%s/%s/%s> with error: %s", repoOwner, repoName, branch, err.Error())
+		err = WithExitCode(fmt.Errorf("failed to download branch: <%s/%s/%s> with error: %s", repoOwner, repoName, branch, err.Error()), ExitCodeVcsApiError)
 		return
 	}
 	log.Debug("Repository download completed")
 	return
 }
 
+// RunConcurrently runs the tasks in parallel, with up to maxConcurrent tasks at a time, and returns the joined errors of all the tasks.
+func RunConcurrently(maxConcurrent int, tasks ...func() error) error {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	errs := make([]error, len(tasks))
+	semaphore := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, task func() error) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			errs[i] = task()
+		}(i, task)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
 func ValidateSingleRepoConfiguration(configAggregator *RepoAggregator) error {
 	// Multi repository configuration is supported only in the scanallpullrequests and scanmultiplerepositories commands.
 	if len(*configAggregator) > 1 {
-		return errors.New(errUnsupportedMultiRepo)
+		return WithExitCode(errors.New(errUnsupportedMultiRepo), ExitCodeConfigurationError)
 	}
 	return nil
 }
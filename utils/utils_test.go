@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"errors"
 	"os"
 	"path"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
@@ -41,6 +44,32 @@ func TestChdirErr(t *testing.T) {
 	assert.Equal(t, originCwd, cwd)
 }
 
+func TestRunConcurrently(t *testing.T) {
+	var running, maxRunning, completed int32
+	task := func(taskErr error) func() error {
+		return func() error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				observed := atomic.LoadInt32(&maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&completed, 1)
+			return taskErr
+		}
+	}
+	err := RunConcurrently(2, task(nil), task(errors.New("first error")), task(nil), task(errors.New("second error")), task(nil))
+	assert.ErrorContains(t, err, "first error")
+	assert.ErrorContains(t, err, "second error")
+	assert.Equal(t, int32(5), completed)
+	assert.Equal(t, int32(2), maxRunning)
+
+	assert.NoError(t, RunConcurrently(2))
+}
+
 func getDummyRepoNames() []string {
 	return []string{"repository1", "repository2"}
 }
@@ -0,0 +1,229 @@
+package webhookserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	gitHubEventHeader              = "X-GitHub-Event"
+	gitHubSignatureHeader          = "X-Hub-Signature-256"
+	gitLabEventHeader              = "X-Gitlab-Event"
+	gitLabTokenHeader              = "X-Gitlab-Token"
+	bitbucketServerEventHeader     = "X-Event-Key"
+	bitbucketServerSignatureHeader = "X-Hub-Signature"
+	sha256SignaturePrefix          = "sha256="
+)
+
+var errInvalidSignature = errors.New("the webhook signature doesn't match the webhook secret")
+
+// A pull request event that requires a Frogbot scan
+type pullRequestEvent struct {
+	// Empty for Azure Repos, as the organization isn't included in the payload
+	repoOwner     string
+	repoName      string
+	pullRequestID int
+	// The latest commit of the pull request source branch
+	headSha string
+}
+
+// Identifies the pull request, regardless of its head commit
+func (pre *pullRequestEvent) pullRequestKey() string {
+	return fmt.Sprintf("%s/%s#%d", pre.repoOwner, pre.repoName, pre.pullRequestID)
+}
+
+// Verifies and parses a webhook request sent by the Git provider.
+// Returns nil if the request isn't a pull request event that should be scanned, such as a closed pull request.
+func parseWebhookEvent(provider vcsutils.VcsProvider, request *http.Request, payload []byte, secret string) (*pullRequestEvent, error) {
+	switch provider {
+	case vcsutils.GitHub:
+		return parseGitHubEvent(request, payload, secret)
+	case vcsutils.GitLab:
+		return parseGitLabEvent(request, payload, secret)
+	case vcsutils.BitbucketServer:
+		return parseBitbucketServerEvent(request, payload, secret)
+	case vcsutils.AzureRepos:
+		return parseAzureReposEvent(request, payload, secret)
+	}
+	return nil, fmt.Errorf("webhooks of %s aren't supported", provider.String())
+}
+
+type gitHubPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Head struct {
+			Sha string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+func parseGitHubEvent(request *http.Request, payload []byte, secret string) (*pullRequestEvent, error) {
+	if err := verifySha256Signature(request.Header.Get(gitHubSignatureHeader), payload, secret); err != nil {
+		return nil, err
+	}
+	if request.Header.Get(gitHubEventHeader) != "pull_request" {
+		return nil, nil
+	}
+	var event gitHubPayload
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.Action != "opened" && event.Action != "reopened" && event.Action != "synchronize" {
+		return nil, nil
+	}
+	return &pullRequestEvent{
+		repoOwner:     event.Repository.Owner.Login,
+		repoName:      event.Repository.Name,
+		pullRequestID: event.Number,
+		headSha:       event.PullRequest.Head.Sha,
+	}, nil
+}
+
+type gitLabPayload struct {
+	ObjectAttributes struct {
+		Iid    int    `json:"iid"`
+		Action string `json:"action"`
+		// Set only if the update added commits to the merge request
+		OldRev     string `json:"oldrev"`
+		LastCommit struct {
+			Id string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+}
+
+func parseGitLabEvent(request *http.Request, payload []byte, secret string) (*pullRequestEvent, error) {
+	if subtle.ConstantTimeCompare([]byte(request.Header.Get(gitLabTokenHeader)), []byte(secret)) != 1 {
+		return nil, errInvalidSignature
+	}
+	if request.Header.Get(gitLabEventHeader) != "Merge Request Hook" {
+		return nil, nil
+	}
+	var event gitLabPayload
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	action := event.ObjectAttributes.Action
+	if action != "open" && action != "reopen" && !(action == "update" && event.ObjectAttributes.OldRev != "") {
+		return nil, nil
+	}
+	// The project path is in the format of <namespace>/<project>, where the namespace may include subgroups
+	var repoOwner, repoName string
+	if separatorIndex := strings.LastIndex(event.Project.PathWithNamespace, "/"); separatorIndex >= 0 {
+		repoOwner, repoName = event.Project.PathWithNamespace[:separatorIndex], event.Project.PathWithNamespace[separatorIndex+1:]
+	} else {
+		repoName = event.Project.PathWithNamespace
+	}
+	return &pullRequestEvent{
+		repoOwner:     repoOwner,
+		repoName:      repoName,
+		pullRequestID: event.ObjectAttributes.Iid,
+		headSha:       event.ObjectAttributes.LastCommit.Id,
+	}, nil
+}
+
+type bitbucketServerPayload struct {
+	PullRequest struct {
+		Id      int `json:"id"`
+		FromRef struct {
+			LatestCommit string `json:"latestCommit"`
+		} `json:"fromRef"`
+		ToRef struct {
+			Repository struct {
+				Slug    string `json:"slug"`
+				Project struct {
+					Key string `json:"key"`
+				} `json:"project"`
+			} `json:"repository"`
+		} `json:"toRef"`
+	} `json:"pullRequest"`
+}
+
+func parseBitbucketServerEvent(request *http.Request, payload []byte, secret string) (*pullRequestEvent, error) {
+	if err := verifySha256Signature(request.Header.Get(bitbucketServerSignatureHeader), payload, secret); err != nil {
+		return nil, err
+	}
+	eventKey := request.Header.Get(bitbucketServerEventHeader)
+	if eventKey != "pr:opened" && eventKey != "pr:from_ref_updated" {
+		return nil, nil
+	}
+	var event bitbucketServerPayload
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return &pullRequestEvent{
+		repoOwner:     event.PullRequest.ToRef.Repository.Project.Key,
+		repoName:      event.PullRequest.ToRef.Repository.Slug,
+		pullRequestID: event.PullRequest.Id,
+		headSha:       event.PullRequest.FromRef.LatestCommit,
+	}, nil
+}
+
+type azureReposPayload struct {
+	EventType string `json:"eventType"`
+	Resource  struct {
+		PullRequestId         int    `json:"pullRequestId"`
+		Status                string `json:"status"`
+		LastMergeSourceCommit struct {
+			CommitId string `json:"commitId"`
+		} `json:"lastMergeSourceCommit"`
+		Repository struct {
+			Name string `json:"name"`
+		} `json:"repository"`
+	} `json:"resource"`
+}
+
+// Azure Repos service hooks don't sign the payload. The webhook secret is expected as the basic authentication password.
+func parseAzureReposEvent(request *http.Request, payload []byte, secret string) (*pullRequestEvent, error) {
+	_, password, _ := request.BasicAuth()
+	if subtle.ConstantTimeCompare([]byte(password), []byte(secret)) != 1 {
+		return nil, errInvalidSignature
+	}
+	var event azureReposPayload
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if (event.EventType != "git.pullrequest.created" && event.EventType != "git.pullrequest.updated") || event.Resource.Status != "active" {
+		return nil, nil
+	}
+	return &pullRequestEvent{
+		repoName:      event.Resource.Repository.Name,
+		pullRequestID: event.Resource.PullRequestId,
+		headSha:       event.Resource.LastMergeSourceCommit.CommitId,
+	}, nil
+}
+
+// Verifies a signature in the format of sha256=<HMAC hex digest of the payload>
+func verifySha256Signature(signature string, payload []byte, secret string) error {
+	if !strings.HasPrefix(signature, sha256SignaturePrefix) {
+		return errInvalidSignature
+	}
+	receivedMac, err := hex.DecodeString(strings.TrimPrefix(signature, sha256SignaturePrefix))
+	if err != nil {
+		return errInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(receivedMac, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package webhookserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "frogbot-webhook-secret"
+
+func TestParseWebhookEvent(t *testing.T) {
+	testCases := []struct {
+		name          string
+		provider      vcsutils.VcsProvider
+		payloadFile   string
+		setHeaders    func(request *http.Request, payload []byte)
+		expectedEvent *pullRequestEvent
+		expectedErr   error
+	}{
+		{
+			name:        "GitHub pull request opened",
+			provider:    vcsutils.GitHub,
+			payloadFile: "github-pull-request-opened.json",
+			setHeaders: func(request *http.Request, payload []byte) {
+				request.Header.Set(gitHubEventHeader, "pull_request")
+				request.Header.Set(gitHubSignatureHeader, sign(payload, testWebhookSecret))
+			},
+			expectedEvent: &pullRequestEvent{repoOwner: "jfrog", repoName: "frogbot-demo", pullRequestID: 2, headSha: "4ab1c4ff7e0a8b1d6d3d3f0b4bfae0a2d8c2b0f1"},
+		},
+		{
+			name:        "GitHub push event",
+			provider:    vcsutils.GitHub,
+			payloadFile: "github-pull-request-opened.json",
+			setHeaders: func(request *http.Request, payload []byte) {
+				request.Header.Set(gitHubEventHeader, "push")
+				request.Header.Set(gitHubSignatureHeader, sign(payload, testWebhookSecret))
+			},
+		},
+		{
+			name:        "GitHub wrong signature",
+			provider:    vcsutils.GitHub,
+			payloadFile: "github-pull-request-opened.json",
+			setHeaders: func(request *http.Request, payload []byte) {
+				request.Header.Set(gitHubEventHeader, "pull_request")
+				request.Header.Set(gitHubSignatureHeader, sign(payload, "wrong-secret"))
+			},
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "GitHub missing signature",
+			provider:    vcsutils.GitHub,
+			payloadFile: "github-pull-request-opened.json",
+			setHeaders: func(request *http.Request, payload []byte) {
+				request.Header.Set(gitHubEventHeader, "pull_request")
+			},
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "GitLab merge request update with new commits",
+			provider:    vcsutils.GitLab,
+			payloadFile: "gitlab-merge-request-update.json",
+			setHeaders: func(request *http.Request, _ []byte) {
+				request.Header.Set(gitLabEventHeader, "Merge Request Hook")
+				request.Header.Set(gitLabTokenHeader, testWebhookSecret)
+			},
+			expectedEvent: &pullRequestEvent{repoOwner: "jfrog/security", repoName: "frogbot-demo", pullRequestID: 7, headSha: "da1560886d4f094c3e6c9ef40349f7d38b5d27d7"},
+		},
+		{
+			name:        "GitLab wrong token",
+			provider:    vcsutils.GitLab,
+			payloadFile: "gitlab-merge-request-update.json",
+			setHeaders: func(request *http.Request, _ []byte) {
+				request.Header.Set(gitLabEventHeader, "Merge Request Hook")
+				request.Header.Set(gitLabTokenHeader, "wrong-secret")
+			},
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "Bitbucket Server pull request opened",
+			provider:    vcsutils.BitbucketServer,
+			payloadFile: "bitbucketserver-pull-request-opened.json",
+			setHeaders: func(request *http.Request, payload []byte) {
+				request.Header.Set(bitbucketServerEventHeader, "pr:opened")
+				request.Header.Set(bitbucketServerSignatureHeader, sign(payload, testWebhookSecret))
+			},
+			expectedEvent: &pullRequestEvent{repoOwner: "JFROG", repoName: "frogbot-demo", pullRequestID: 3, headSha: "ef8755f06ee4b28c96a847a95cb8ec8ed6ddd1ca"},
+		},
+		{
+			name:        "Bitbucket Server pull request merged",
+			provider:    vcsutils.BitbucketServer,
+			payloadFile: "bitbucketserver-pull-request-opened.json",
+			setHeaders: func(request *http.Request, payload []byte) {
+				request.Header.Set(bitbucketServerEventHeader, "pr:merged")
+				request.Header.Set(bitbucketServerSignatureHeader, sign(payload, testWebhookSecret))
+			},
+		},
+		{
+			name:        "Azure Repos pull request updated",
+			provider:    vcsutils.AzureRepos,
+			payloadFile: "azurerepos-pull-request-updated.json",
+			setHeaders: func(request *http.Request, _ []byte) {
+				request.SetBasicAuth("frogbot", testWebhookSecret)
+			},
+			expectedEvent: &pullRequestEvent{repoName: "frogbot-demo", pullRequestID: 1, headSha: "53d54ac915144006c2c9e90d2c7d3880920db49c"},
+		},
+		{
+			name:        "Azure Repos missing basic authentication",
+			provider:    vcsutils.AzureRepos,
+			payloadFile: "azurerepos-pull-request-updated.json",
+			setHeaders:  func(*http.Request, []byte) {},
+			expectedErr: errInvalidSignature,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			request, payload := createWebhookRequest(t, test.payloadFile, test.setHeaders)
+			event, err := parseWebhookEvent(test.provider, request, payload, testWebhookSecret)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedEvent, event)
+		})
+	}
+}
+
+func TestParseGitLabEventIgnoredActions(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("..", "testdata", "webhookserver", "gitlab-merge-request-update.json"))
+	require.NoError(t, err)
+	// An update without new commits, such as a title change, doesn't require a scan
+	payload = []byte(strings.Replace(string(payload), `"oldrev": "2f4e1b7d8a9c6e3f1b0a5d4c3e2f1a0b9c8d7e6f",`, "", 1))
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(payload)))
+	request.Header.Set(gitLabEventHeader, "Merge Request Hook")
+	request.Header.Set(gitLabTokenHeader, testWebhookSecret)
+	event, err := parseGitLabEvent(request, payload, testWebhookSecret)
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func createWebhookRequest(t *testing.T, payloadFile string, setHeaders func(*http.Request, []byte)) (*http.Request, []byte) {
+	payload, err := os.ReadFile(filepath.Join("..", "testdata", "webhookserver", payloadFile))
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(payload)))
+	setHeaders(request, payload)
+	return request, payload
+}
+
+func sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return sha256SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,223 @@
+package webhookserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jfrog/frogbot/scanpullrequest"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DefaultPort = 8080
+	// The maximal number of pull request scans waiting in the queue
+	queueSize = 100
+	// The maximal size of a webhook payload
+	maxPayloadSize    = 25 * 1024 * 1024
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 30 * time.Second
+)
+
+// ServeCmd runs an HTTP server that receives pull request webhooks from the Git provider, and scans the pull requests.
+// The pull requests are scanned one at a time, in the order of the received events.
+type ServeCmd struct {
+	port int
+	// The secret used to verify the webhooks
+	webhookSecret string
+}
+
+func NewServeCmd(port int, webhookSecret string) *ServeCmd {
+	if port == 0 {
+		port = DefaultPort
+	}
+	return &ServeCmd{port: port, webhookSecret: webhookSecret}
+}
+
+func (cmd *ServeCmd) Run(repoAggregator utils.RepoAggregator, client vcsclient.VcsClient) (err error) {
+	if cmd.webhookSecret == "" {
+		return errors.New("a webhook secret is required to verify the webhooks sent by the Git provider")
+	}
+	server := newWebhookServer(repoAggregator, client, cmd.webhookSecret, (&scanpullrequest.ScanPullRequestCmd{}).Run)
+	workerDone := make(chan struct{})
+	go func() {
+		server.processEvents()
+		close(workerDone)
+	}()
+
+	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", cmd.port), Handler: server, ReadHeaderTimeout: readHeaderTimeout}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info(fmt.Sprintf("Listening for webhooks on port %d", cmd.port))
+		serverErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err = <-serverErr:
+	case <-ctx.Done():
+		log.Info("Shutting down the webhook server. Waiting for the running scan to finish...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err = httpServer.Shutdown(shutdownCtx)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+	server.close()
+	<-workerDone
+	return
+}
+
+type webhookServer struct {
+	gitProvider   vcsutils.VcsProvider
+	repositories  utils.RepoAggregator
+	client        vcsclient.VcsClient
+	webhookSecret string
+	// Scans a single pull request
+	scan  func(utils.RepoAggregator, vcsclient.VcsClient) error
+	queue chan *pullRequestEvent
+	// Protects the queue and the queued heads
+	mutex  sync.Mutex
+	closed bool
+	// Maps each pull request to the head commit of its queued or running scan, to skip repeated events.
+	// The entries are removed when the scans finish, so the map is bounded by the size of the queue.
+	queuedHeads map[string]string
+}
+
+func newWebhookServer(repositories utils.RepoAggregator, client vcsclient.VcsClient, webhookSecret string, scan func(utils.RepoAggregator, vcsclient.VcsClient) error) *webhookServer {
+	server := &webhookServer{
+		repositories:  repositories,
+		client:        client,
+		webhookSecret: webhookSecret,
+		scan:          scan,
+		queue:         make(chan *pullRequestEvent, queueSize),
+		queuedHeads:   make(map[string]string),
+	}
+	if len(repositories) > 0 {
+		server.gitProvider = repositories[0].GitProvider
+	}
+	return server
+}
+
+func (ws *webhookServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event, err := parseWebhookEvent(ws.gitProvider, request, payload, ws.webhookSecret)
+	if err != nil {
+		log.Warn("Rejected a webhook request:", err.Error())
+		status := http.StatusBadRequest
+		if errors.Is(err, errInvalidSignature) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(writer, err.Error(), status)
+		return
+	}
+	if event == nil {
+		writeResponse(writer, http.StatusOK, "the event doesn't require a scan")
+		return
+	}
+	if ws.getRepository(event) == nil {
+		log.Debug("Ignoring a webhook of an unconfigured repository:", event.pullRequestKey())
+		writeResponse(writer, http.StatusOK, fmt.Sprintf("the %s repository isn't configured in Frogbot", event.repoName))
+		return
+	}
+	status, message := ws.enqueue(event)
+	writeResponse(writer, status, message)
+}
+
+// Queues the pull request scan, unless a scan of the same pull request head was already queued.
+func (ws *webhookServer) enqueue(event *pullRequestEvent) (status int, message string) {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	if ws.closed {
+		return http.StatusServiceUnavailable, "the server is shutting down"
+	}
+	if ws.queuedHeads[event.pullRequestKey()] == event.headSha {
+		return http.StatusOK, fmt.Sprintf("a scan of pull request %s at %s was already queued", event.pullRequestKey(), event.headSha)
+	}
+	select {
+	case ws.queue <- event:
+		ws.queuedHeads[event.pullRequestKey()] = event.headSha
+		log.Info("Queued a scan of pull request", event.pullRequestKey(), "at", event.headSha)
+		return http.StatusAccepted, fmt.Sprintf("queued a scan of pull request %s", event.pullRequestKey())
+	default:
+		return http.StatusServiceUnavailable, "the scans queue is full"
+	}
+}
+
+// Scans the queued pull requests one at a time, until the server is closed.
+// The scans change the working directory of the process, and therefore can't run concurrently.
+func (ws *webhookServer) processEvents() {
+	for event := range ws.queue {
+		repository := *ws.getRepository(event)
+		repository.PullRequestDetails = vcsclient.PullRequestInfo{ID: int64(event.pullRequestID)}
+		err := ws.scan(utils.RepoAggregator{repository}, ws.client)
+		// Events of the same head received from now on, such as redeliveries of a failed scan, are scanned again
+		ws.forgetHead(event)
+		if err != nil {
+			log.Error(fmt.Sprintf("The scan of pull request %s returned the following error:\n%s", event.pullRequestKey(), err.Error()))
+			continue
+		}
+		log.Info("Finished scanning pull request", event.pullRequestKey())
+	}
+}
+
+// Removes the head of the finished scan, unless a scan of a newer head of the same pull request was queued meanwhile
+func (ws *webhookServer) forgetHead(event *pullRequestEvent) {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	if ws.queuedHeads[event.pullRequestKey()] == event.headSha {
+		delete(ws.queuedHeads, event.pullRequestKey())
+	}
+}
+
+// Stops accepting new events. The queued events are still processed.
+func (ws *webhookServer) close() {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	if !ws.closed {
+		ws.closed = true
+		close(ws.queue)
+	}
+}
+
+// Returns the configuration of the repository of the event, or nil if the repository isn't configured.
+func (ws *webhookServer) getRepository(event *pullRequestEvent) *utils.Repository {
+	for i := range ws.repositories {
+		repository := &ws.repositories[i]
+		if repository.RepoName != event.repoName {
+			continue
+		}
+		if event.repoOwner == "" || strings.EqualFold(repository.RepoOwner, event.repoOwner) {
+			return repository
+		}
+	}
+	return nil
+}
+
+func writeResponse(writer http.ResponseWriter, status int, message string) {
+	writer.WriteHeader(status)
+	if _, err := writer.Write([]byte(message)); err != nil {
+		log.Debug("Failed to write the webhook response:", err.Error())
+	}
+}
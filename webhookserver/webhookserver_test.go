@@ -0,0 +1,111 @@
+package webhookserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jfrog/frogbot/testdata"
+	"github.com/jfrog/frogbot/utils"
+	"github.com/jfrog/froggit-go/vcsclient"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookServer(t *testing.T) {
+	client := testdata.NewMockVcsClient(gomock.NewController(t))
+	repositories := utils.RepoAggregator{
+		{Params: utils.Params{Git: utils.Git{GitProvider: vcsutils.GitHub, RepoOwner: "jfrog", RepoName: "other-repo"}}},
+		{Params: utils.Params{Git: utils.Git{GitProvider: vcsutils.GitHub, RepoOwner: "jfrog", RepoName: "frogbot-demo"}}},
+	}
+	var scannedRepositories utils.RepoAggregator
+	scan := func(repoAggregator utils.RepoAggregator, scanClient vcsclient.VcsClient) error {
+		assert.Equal(t, client, scanClient)
+		scannedRepositories = append(scannedRepositories, repoAggregator...)
+		return nil
+	}
+	server := newWebhookServer(repositories, client, testWebhookSecret, scan)
+	setGitHubHeaders := func(request *http.Request, payload []byte) {
+		request.Header.Set(gitHubEventHeader, "pull_request")
+		request.Header.Set(gitHubSignatureHeader, sign(payload, testWebhookSecret))
+	}
+
+	// The first event is queued
+	request, _ := createWebhookRequest(t, "github-pull-request-opened.json", setGitHubHeaders)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+
+	// A repeated event for the same pull request head is skipped
+	request, _ = createWebhookRequest(t, "github-pull-request-opened.json", setGitHubHeaders)
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "already queued")
+
+	// An event with an invalid signature is rejected
+	request, _ = createWebhookRequest(t, "github-pull-request-opened.json", func(request *http.Request, payload []byte) {
+		request.Header.Set(gitHubEventHeader, "pull_request")
+		request.Header.Set(gitHubSignatureHeader, sign(payload, "wrong-secret"))
+	})
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	// Only POST requests are accepted
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	server.close()
+	server.processEvents()
+	if assert.Len(t, scannedRepositories, 1) {
+		assert.Equal(t, "frogbot-demo", scannedRepositories[0].RepoName)
+		assert.Equal(t, int64(2), scannedRepositories[0].PullRequestDetails.ID)
+	}
+	// The heads of the finished scans aren't kept
+	assert.Empty(t, server.queuedHeads)
+
+	// No events are accepted after the server is closed
+	request, _ = createWebhookRequest(t, "github-pull-request-opened.json", setGitHubHeaders)
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
+
+func TestWebhookServerUnconfiguredRepository(t *testing.T) {
+	repositories := utils.RepoAggregator{
+		{Params: utils.Params{Git: utils.Git{GitProvider: vcsutils.GitHub, RepoOwner: "other-owner", RepoName: "frogbot-demo"}}},
+	}
+	server := newWebhookServer(repositories, nil, testWebhookSecret, nil)
+	request, _ := createWebhookRequest(t, "github-pull-request-opened.json", func(request *http.Request, payload []byte) {
+		request.Header.Set(gitHubEventHeader, "pull_request")
+		request.Header.Set(gitHubSignatureHeader, sign(payload, testWebhookSecret))
+	})
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, server.queue)
+}
+
+func TestWebhookServerFailedScan(t *testing.T) {
+	repositories := utils.RepoAggregator{
+		{Params: utils.Params{Git: utils.Git{GitProvider: vcsutils.AzureRepos, RepoName: "frogbot-demo"}}},
+	}
+	scansCount := 0
+	scan := func(utils.RepoAggregator, vcsclient.VcsClient) error {
+		scansCount++
+		return errors.New("scan failed")
+	}
+	server := newWebhookServer(repositories, nil, testWebhookSecret, scan)
+	event := &pullRequestEvent{repoName: "frogbot-demo", pullRequestID: 1, headSha: "53d54ac915144006c2c9e90d2c7d3880920db49c"}
+	status, _ := server.enqueue(event)
+	assert.Equal(t, http.StatusAccepted, status)
+	server.close()
+	server.processEvents()
+	assert.Equal(t, 1, scansCount)
+	// A failed scan can be triggered again by a redelivery of the event
+	assert.NotContains(t, server.queuedHeads, event.pullRequestKey())
+}